@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/num30/config"
 	"github.com/xtp-tour/xtp-tour/api/pkg"
 	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/importer"
 	"github.com/xtp-tour/xtp-tour/api/pkg/jobs"
 	"github.com/xtp-tour/xtp-tour/api/pkg/metrics"
 	"github.com/xtp-tour/xtp-tour/api/pkg/notifications"
@@ -36,9 +40,19 @@ func main() {
 		db.RunMigrations(&serviceConfig.Db, os.Args[2:]...)
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "ratings" {
+		runRatingsCommand(os.Args[2:]...)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import-facilities" {
+		runImportFacilitiesCommand(os.Args[2:]...)
+		return
+	}
 	if len(os.Args) > 1 && os.Args[1] == "help" {
 		fmt.Println("migrate [up|down] - run migrations")
 		fmt.Println("migrate drop - drop database")
+		fmt.Println("ratings recalc - rebuild player ratings from confirmed match results")
+		fmt.Println("import-facilities [-dry-run] <file.csv|file.geojson> - create or update facilities with their courts and prices")
 		return
 	}
 
@@ -53,6 +67,8 @@ func main() {
 	notifier := notifications.NewNotifier(dbConn, notifications.NewDbQueue(dbConn))
 	startNotificationWorker(&serviceConfig.Db)
 	startExpirationWorker(&serviceConfig.Db, notifier)
+	startCompletionWorker(&serviceConfig.Db, notifier)
+	startReminderWorker(&serviceConfig.Db, notifier)
 
 	metrics.StartMetricsServer(&serviceConfig.Metrics)
 	r := server.NewRouter(&serviceConfig.Service, dbConn, serviceConfig.IsDebugMode, notifier, serviceConfig.Features)
@@ -108,6 +124,102 @@ func startExpirationWorker(dbConf *pkg.DbConfig, notifier jobs.ExpirationNotifie
 	go worker.Start(ctx, serviceConfig.Expiration.Interval)
 }
 
+// Completes played events on schedule
+func startCompletionWorker(dbConf *pkg.DbConfig, notifier jobs.CompletionNotifier) {
+	dbConn, err := db.GetDB(dbConf)
+	if err != nil {
+		slog.Error("Failed to initialize database connection for completion worker", "error", err)
+		os.Exit(1)
+	}
+
+	worker := jobs.NewCompletionWorker(dbConn, notifier, serviceConfig.Completion.PostMatchWindow)
+
+	// Start background completion worker
+	ctx := context.Background()
+	go worker.Start(ctx, serviceConfig.Completion.Interval)
+}
+
+// Reminds players of rescheduled events on schedule
+func startReminderWorker(dbConf *pkg.DbConfig, notifier jobs.ReminderNotifier) {
+	dbConn, err := db.GetDB(dbConf)
+	if err != nil {
+		slog.Error("Failed to initialize database connection for reminder worker", "error", err)
+		os.Exit(1)
+	}
+
+	worker := jobs.NewReminderWorker(dbConn, notifier, serviceConfig.Reminders.RescheduleLead)
+
+	// Start background reminder worker
+	ctx := context.Background()
+	go worker.Start(ctx, serviceConfig.Reminders.Interval)
+}
+
+// Runs rating maintenance commands
+func runRatingsCommand(args ...string) {
+	if len(args) == 0 || args[0] != "recalc" {
+		slog.Error("Unknown ratings command, expected: ratings recalc", "args", args)
+		os.Exit(1)
+	}
+
+	dbConn, err := db.GetDB(&serviceConfig.Db)
+	if err != nil {
+		slog.Error("Failed to initialize database connection", "error", err)
+		os.Exit(1)
+	}
+
+	count, err := dbConn.RecalculateRatings(context.Background())
+	if err != nil {
+		slog.Error("Failed to recalculate ratings", "error", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Recalculated ratings from %d match results\n", count)
+}
+
+// Imports facilities from a CSV or GeoJSON file
+func runImportFacilitiesCommand(args ...string) {
+	flags := flag.NewFlagSet("import-facilities", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "validate the file and report changes without importing")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		slog.Error("Expected: import-facilities [-dry-run] <file>", "args", args)
+		os.Exit(1)
+	}
+	path := flags.Arg(0)
+
+	file, err := os.Open(path)
+	if err != nil {
+		slog.Error("Failed to open import file", "error", err)
+		os.Exit(1)
+	}
+	defer func() { _ = file.Close() }()
+
+	var records []importer.Record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = importer.ParseCSV(file)
+	case ".geojson", ".json":
+		records, err = importer.ParseGeoJSON(file)
+	default:
+		err = fmt.Errorf("unknown file type, expected .csv or .geojson")
+	}
+	if err != nil {
+		slog.Error("Failed to read import file", "path", path, "error", err)
+		os.Exit(1)
+	}
+
+	dbConn, err := db.GetDB(&serviceConfig.Db)
+	if err != nil {
+		slog.Error("Failed to initialize database connection", "error", err)
+		os.Exit(1)
+	}
+
+	report := importer.Import(context.Background(), dbConn, records, *dryRun)
+	report.Write(os.Stdout)
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
+
 // loadConfig reads in config file, ENV variables, and flags if set.
 func loadConfig() {
 	err := config.NewConfReader("service_test").Read(serviceConfig)
@@ -73,6 +73,17 @@ const (
 	JoinRequestStatusRejected          JoinRequestStatus = "REJECTED"
 	JoinRequestStatusCancelled         JoinRequestStatus = "CANCELLED"
 	JoinRequestStatusReservationFailed JoinRequestStatus = "RESERVATION_FAILED"
+	JoinRequestStatusWaitlisted        JoinRequestStatus = "WAITLISTED" // queued because the event is full
+)
+
+// ReservationStatus is the state of the court booking of a confirmed event
+type ReservationStatus string
+
+const (
+	ReservationStatusNotRequired ReservationStatus = "NOT_REQUIRED" // the facility has no reservation provider, the host books the court
+	ReservationStatusPending     ReservationStatus = "PENDING"
+	ReservationStatusReserved    ReservationStatus = "RESERVED"
+	ReservationStatusFailed      ReservationStatus = "FAILED"
 )
 
 // Event visibility constants
@@ -83,6 +94,31 @@ const (
 	EventVisibilityPrivate EventVisibility = "PRIVATE"
 )
 
+// Recurrence frequency constants
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyWeekly   RecurrenceFrequency = "WEEKLY"
+	RecurrenceFrequencyBiweekly RecurrenceFrequency = "BIWEEKLY"
+)
+
+// Series update/cancellation scope constants
+type SeriesScope string
+
+const (
+	SeriesScopeThis   SeriesScope = "THIS"
+	SeriesScopeSeries SeriesScope = "SERIES"
+)
+
+// Match result status constants
+type MatchResultStatus string
+
+const (
+	MatchResultStatusPending   MatchResultStatus = "PENDING"
+	MatchResultStatusConfirmed MatchResultStatus = "CONFIRMED"
+	MatchResultStatusDisputed  MatchResultStatus = "DISPUTED"
+)
+
 type JoinRequestData struct {
 	Id        string   `json:"id,omitempty"`
 	Locations []string `json:"locations" validate:"required,min=1"`
@@ -94,31 +130,86 @@ type JoinRequestData struct {
 // JoinRequest represents a player's acceptance of an event
 type JoinRequest struct {
 	JoinRequestData
-	UserId     string `json:"userId"`
-	CreatedAt  string `json:"createdAt" format:"date" description:"Creation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
-	IsRejected *bool  `json:"isRejected,omitempty"`
+	UserId      string            `json:"userId"`
+	CreatedAt   string            `json:"createdAt" format:"date" description:"Creation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	IsRejected  *bool             `json:"isRejected,omitempty"`
+	Status      JoinRequestStatus `json:"status" enum:"WAITING,ACCEPTED,REJECTED,CANCELLED,RESERVATION_FAILED,WAITLISTED"`
+	HostMessage string            `json:"hostMessage,omitempty" description:"Message of the host who accepted or rejected the request"`
+	// AcknowledgedVersion is the latest confirmation version the accepted player acknowledged
+	AcknowledgedVersion int    `json:"acknowledgedVersion,omitempty" description:"Latest confirmation version the accepted player acknowledged"`
+	PaidAt              string `json:"paidAt,omitempty" format:"date" description:"When the host marked the share of the court cost as paid, in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
 }
 
 type JoinRequestRequest struct {
 	EventId     string          `path:"eventId" validate:"required"`
 	JoinRequest JoinRequestData `json:"joinRequest" validate:"required"`
+	InviteToken string          `json:"inviteToken,omitempty" description:"Invite token of a private event, not needed for users invited by the host"`
 }
 
 type JoinRequestResponse struct {
 	JoinRequest JoinRequest `json:"joinRequest"`
 }
 
+// AnswerJoinRequestRequest is used by the host to accept or reject a join request before confirming the event
+type AnswerJoinRequestRequest struct {
+	EventId       string `path:"eventId" validate:"required"`
+	JoinRequestId string `path:"joinRequestId" validate:"required"`
+	Message       string `json:"message,omitempty" description:"Optional message for the requester"`
+}
+
 type EventConfirmationRequest struct {
-	EventId         string   `path:"eventId" validate:"required"`
-	LocationId      string   `json:"locationId" validate:"required"`
-	DateTime        string   `json:"datetime" validate:"required" format:"date" description:"Event date and time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
-	JoinRequestsIds []string `json:"joinRequestsIds" validate:"required"`
+	EventId         string     `path:"eventId" validate:"required"`
+	LocationId      string     `json:"locationId" validate:"required"`
+	DateTime        string     `json:"datetime" validate:"required" format:"date" description:"Event date and time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	JoinRequestsIds []string   `json:"joinRequestsIds" validate:"required"`
+	Teams           [][]string `json:"teams,omitempty" description:"User ids of the players of each team, the host included. Only for DOUBLES and CUSTOM events"`
 }
 
 type EventConfirmationResponse struct {
 	Confirmation Confirmation `json:"confirmation"`
 }
 
+// RescheduleEventRequest is used by the host to move a confirmed event to another time or court
+type RescheduleEventRequest struct {
+	EventId    string `path:"eventId" validate:"required"`
+	LocationId string `json:"locationId" validate:"required"`
+	DateTime   string `json:"datetime" validate:"required" format:"date" description:"New date and time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Reason     string `json:"reason,omitempty" description:"Why the event is rescheduled, shown to the players"`
+}
+
+// AcknowledgeRescheduleRequest is used by an accepted player to accept the latest confirmation version
+type AcknowledgeRescheduleRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+}
+
+// JoinRequestPaymentRequest is used by the host to record whether an accepted player paid their share of the court
+type JoinRequestPaymentRequest struct {
+	EventId       string `path:"eventId" validate:"required"`
+	JoinRequestId string `path:"joinRequestId" validate:"required"`
+	Paid          bool   `json:"paid" description:"Whether the player paid their share of the court cost to the host"`
+}
+
+type ConfirmationCandidatesRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	Limit   int    `query:"limit" default:"10" description:"Maximum number of candidates to return, at most 100"`
+}
+
+// ConfirmationCandidate is a location and time slot together with the join requests the event can be confirmed with
+type ConfirmationCandidate struct {
+	LocationId      string   `json:"locationId"`
+	DateTime        string   `json:"datetime" format:"date" description:"Time slot in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	JoinRequestsIds []string `json:"joinRequestsIds"`
+	Players         int      `json:"players" description:"Number of players including the host"`
+	Complete        bool     `json:"complete" description:"Whether there are enough players to confirm the event"`
+	BusyUserIds     []string `json:"busyUserIds" description:"Players whose calendar is busy during the session"`
+	Price           *float64 `json:"price,omitempty" description:"Court price of the session when the facility publishes prices"`
+	Holiday         string   `json:"holiday,omitempty" description:"Name of the public holiday at the location on the day of the time slot"`
+}
+
+type ConfirmationCandidatesResponse struct {
+	Candidates []*ConfirmationCandidate `json:"candidates" description:"Best candidates first"`
+}
+
 type CancelJoinRequestRequest struct {
 	EventId       string `path:"eventId" validate:"required"`
 	JoinRequestId string `path:"joinRequestId" validate:"required"`
@@ -129,31 +220,67 @@ type Confirmation struct {
 	EventId    string `json:"eventId"`
 	LocationId string `json:"location"`
 	Datetime   string `json:"datetime" format:"date" description:"Confirmed date and time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Version    int    `json:"version" description:"Starts at 1 and is incremented each time the host reschedules the event"`
 	CreatedAt  string `json:"createdAt" format:"date" description:"Creation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	// ReservationStatus tells whether the court was booked through the reservation provider of the facility
+	ReservationStatus ReservationStatus `json:"reservationStatus" enum:"NOT_REQUIRED,PENDING,RESERVED,FAILED"`
+	CourtNumber       string            `json:"courtNumber,omitempty" description:"Court booked by the reservation provider"`
+	// AwaitingAcknowledgement lists accepted players who have not acknowledged the latest version yet, they are
+	// reminded once before the session. It is advisory, the players keep their places until they cancel.
+	AwaitingAcknowledgement []string `json:"awaitingAcknowledgement,omitempty" description:"User ids of accepted players who have not acknowledged the rescheduled time and place yet. They are reminded once before the session and keep their places until they cancel their join requests"`
+	// CourtCost is missing when the facility publishes no price for the confirmed session
+	CourtCost *CourtCost `json:"courtCost,omitempty"`
+}
+
+// CourtCost is the court price of the confirmed session split across its players. The host pays the court and
+// accepted players pay their share back to the host.
+type CourtCost struct {
+	Total     float64 `json:"total" description:"Court price of the whole session"`
+	Players   int     `json:"players" description:"Number of players sharing the cost, the host included"`
+	PerPlayer float64 `json:"perPlayer" description:"Share of each player rounded up to whole cents"`
 }
 
 // EventData represents user's input for an event
 type EventData struct {
-	Id              string          `json:"id"`
-	UserId          string          `json:"userId"`
-	Locations       []string        `json:"locations" validate:"required,min=1"`
-	SkillLevel      SkillLevel      `json:"skillLevel" validate:"required" enum:"ANY,BEGINNER,INTERMEDIATE,ADVANCED"`
-	Description     string          `json:"description,omitempty" `
-	EventType       EventType       `json:"eventType" validate:"required" enum:"MATCH,TRAINING"`
-	ExpectedPlayers int             `json:"expectedPlayers" validate:"required"`
-	SessionDuration int             `json:"sessionDuration" validate:"required" description:"Session duration in minutes"` // in minutes
-	TimeSlots       []string        `json:"timeSlots" validate:"required,min=1" description:"Time slots in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
-	Visibility      EventVisibility `json:"visibility" validate:"required" enum:"PUBLIC,PRIVATE"`
-	ExpirationTime  string          `json:"expirationTime" description:"Expiration time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Id              string           `json:"id"`
+	UserId          string           `json:"userId"`
+	Locations       []string         `json:"locations" description:"Ids of the facilities, defaults to the favourite facilities of the host"`
+	SkillLevel      SkillLevel       `json:"skillLevel" validate:"required" enum:"ANY,BEGINNER,INTERMEDIATE,ADVANCED"`
+	Description     string           `json:"description,omitempty" `
+	EventType       EventType        `json:"eventType" validate:"required" enum:"MATCH,TRAINING"`
+	Format          SingleDoubleType `json:"format,omitempty" enum:"SINGLE,DOUBLES,CUSTOM" description:"SINGLE needs 2 players and DOUBLES 4, defaults to SINGLE for 2 players, DOUBLES for 4 and CUSTOM otherwise"`
+	ExpectedPlayers int              `json:"expectedPlayers" validate:"required"`
+	SessionDuration int              `json:"sessionDuration" validate:"required" description:"Session duration in minutes"` // in minutes
+	TimeSlots       []string         `json:"timeSlots" validate:"required,min=1" description:"Time slots in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Visibility      EventVisibility  `json:"visibility" validate:"required" enum:"PUBLIC,PRIVATE"`
+	ExpirationTime  string           `json:"expirationTime" description:"Expiration time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Recurrence      *Recurrence      `json:"recurrence,omitempty" description:"Makes the event repeat, every occurrence is created as a separate event of the same series"`
+}
+
+// Recurrence describes how an event repeats. Exactly one of Until and Count must be set.
+type Recurrence struct {
+	Frequency RecurrenceFrequency `json:"frequency" validate:"required" enum:"WEEKLY,BIWEEKLY"`
+	Until     string              `json:"until,omitempty" description:"Last day of the series in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Count     int                 `json:"count,omitempty" description:"Number of occurrences including the first one"`
 }
 
 // Event represents an internal representation of an event
 type Event struct {
 	EventData
-	Status       EventStatus    `json:"status" enum:"OPEN,ACCEPTED,CONFIRMED,CANCELLED,RESERVATION_FAILED,COMPLETED,EXPIRED"`
-	CreatedAt    string         `json:"createdAt" format:"date" description:"Creation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
-	JoinRequests []*JoinRequest `json:"joinRequests"`
-	Confirmation *Confirmation  `json:"confirmation,omitempty"`
+	Status         EventStatus    `json:"status" enum:"OPEN,ACCEPTED,CONFIRMED,CANCELLED,RESERVATION_FAILED,COMPLETED,EXPIRED"`
+	CreatedAt      string         `json:"createdAt" format:"date" description:"Creation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	JoinRequests   []*JoinRequest `json:"joinRequests"`
+	Confirmation   *Confirmation  `json:"confirmation,omitempty"`
+	SeriesId       string         `json:"seriesId,omitempty" description:"Id of the recurring series the event belongs to"`
+	SeriesIndex    int            `json:"seriesIndex,omitempty" description:"Position of the event in its series, starting from 1"`
+	CancelReason   string         `json:"cancelReason,omitempty" description:"Reason given by the host when the event was cancelled"`
+	CancelledAt    string         `json:"cancelledAt,omitempty" format:"date" description:"Cancellation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	CompletedAt    string         `json:"completedAt,omitempty" format:"date" description:"Completion timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	PostMatchUntil string         `json:"postMatchUntil,omitempty" format:"date" description:"End of the window for results, feedback and no-show reports in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Result         *MatchResult   `json:"result,omitempty" description:"Result of a completed match"`
+	Teams          [][]string     `json:"teams,omitempty" description:"User ids of the players of each team, recorded when the event was confirmed"`
+
+	PartnerCardLocations []string `json:"partnerCardLocations" description:"Locations of the event, only the confirmed one once confirmed, accepting a partner card of the host and of every accepted player"`
 }
 
 // API Request/Response types for events
@@ -162,7 +289,8 @@ type CreateEventRequest struct {
 }
 
 type CreateEventResponse struct {
-	Event *Event `json:"event"`
+	Event       *Event   `json:"event"`
+	Occurrences []*Event `json:"occurrences,omitempty" description:"All events of the series when the event is recurring, the first one equals event"`
 }
 
 type GetEventRequest struct {
@@ -177,12 +305,151 @@ type DeleteEventRequest struct {
 	EventId string `path:"eventId" validate:"required"`
 }
 
+type CancelEventRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	Reason  string `json:"reason,omitempty" description:"Reason shown to the joiners"`
+}
+
+type CancelEventResponse struct {
+	Event *Event `json:"event"`
+}
+
+// SetScore is the number of games won in a set, from the point of view of the player who submitted the result
+type SetScore struct {
+	Player   int `json:"player" description:"Games won by the submitting player"`
+	Opponent int `json:"opponent" description:"Games won by the opponent"`
+}
+
+// MatchResult is a set-by-set score of a completed match, confirmed or disputed by a player of the opposing side
+type MatchResult struct {
+	Id            string            `json:"id"`
+	EventId       string            `json:"eventId"`
+	SubmittedBy   string            `json:"submittedBy"`
+	Team          []string          `json:"team" description:"User ids of the submitting player and their partners"`
+	Opponents     []string          `json:"opponents" description:"User ids of the opposing players, any of them confirms or disputes the result"`
+	Winners       []string          `json:"winners" description:"User ids of the players of the winning side"`
+	Sets          []SetScore        `json:"sets"`
+	Status        MatchResultStatus `json:"status" enum:"PENDING,CONFIRMED,DISPUTED"`
+	DisputeReason string            `json:"disputeReason,omitempty"`
+	RespondedBy   string            `json:"respondedBy,omitempty" description:"User id of the opposing player who confirmed or disputed the result"`
+	CreatedAt     string            `json:"createdAt" format:"date" description:"Submission timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	RespondedAt   string            `json:"respondedAt,omitempty" format:"date" description:"Confirmation or dispute timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+}
+
+type SubmitMatchResultRequest struct {
+	EventId     string     `path:"eventId" validate:"required"`
+	OpponentIds []string   `json:"opponentIds,omitempty" description:"User ids of the opposing players. Can be omitted when the opposing side follows from the teams of the event or from a single other player"`
+	Sets        []SetScore `json:"sets" validate:"required,min=1"`
+}
+
+type DisputeMatchResultRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ConfirmMatchResultRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+}
+
+type MatchResultResponse struct {
+	Result *MatchResult `json:"result"`
+}
+
+// MinFeedbackRating and MaxFeedbackRating bound the rating a player gives another player of the event
+const (
+	MinFeedbackRating = 1
+	MaxFeedbackRating = 5
+)
+
+// PlayerFeedback is the rating a player of a completed event gave another player of it
+type PlayerFeedback struct {
+	EventId    string `json:"eventId"`
+	FromUserId string `json:"fromUserId"`
+	ToUserId   string `json:"toUserId"`
+	Rating     int    `json:"rating" description:"From 1 to 5"`
+	Comment    string `json:"comment,omitempty"`
+	CreatedAt  string `json:"createdAt" format:"date" description:"Submission timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+}
+
+type SubmitFeedbackRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	UserId  string `json:"userId" validate:"required" description:"User id of the player the feedback is about"`
+	Rating  int    `json:"rating" validate:"required" description:"From 1 to 5"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type FeedbackResponse struct {
+	Feedback *PlayerFeedback `json:"feedback"`
+}
+
+// NoShowReport tells that a player of a completed event did not show up, reported by another player of it
+type NoShowReport struct {
+	EventId    string `json:"eventId"`
+	ReportedBy string `json:"reportedBy"`
+	UserId     string `json:"userId" description:"User id of the player who did not show up"`
+	Comment    string `json:"comment,omitempty"`
+	CreatedAt  string `json:"createdAt" format:"date" description:"Report timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+}
+
+type ReportNoShowRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	UserId  string `json:"userId" validate:"required" description:"User id of the player who did not show up"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type NoShowReportResponse struct {
+	Report *NoShowReport `json:"report"`
+}
+
+// UpdateEventData holds the fields of an event the host can change while it is open
+type UpdateEventData struct {
+	Locations      []string   `json:"locations" validate:"required,min=1"`
+	SkillLevel     SkillLevel `json:"skillLevel" validate:"required" enum:"ANY,BEGINNER,INTERMEDIATE,ADVANCED"`
+	Description    string     `json:"description,omitempty"`
+	TimeSlots      []string   `json:"timeSlots" validate:"required,min=1" description:"Time slots in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	ExpirationTime string     `json:"expirationTime,omitempty" description:"Expiration time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ), defaults to 4 hours before the earliest time slot"`
+}
+
+type UpdateEventRequest struct {
+	EventId string          `path:"eventId" validate:"required"`
+	Event   UpdateEventData `json:"event" validate:"required"`
+}
+
+type UpdateEventResponse struct {
+	Event *Event `json:"event"`
+}
+
+// SeriesEventData holds the fields that can be changed on occurrences of a series
+type SeriesEventData struct {
+	SkillLevel      SkillLevel `json:"skillLevel" validate:"required" enum:"ANY,BEGINNER,INTERMEDIATE,ADVANCED"`
+	Description     string     `json:"description,omitempty"`
+	ExpectedPlayers int        `json:"expectedPlayers" validate:"required"`
+	SessionDuration int        `json:"sessionDuration" validate:"required" description:"Session duration in minutes"`
+}
+
+type UpdateSeriesEventRequest struct {
+	EventId string          `path:"eventId" validate:"required"`
+	Scope   SeriesScope     `json:"scope" validate:"required" enum:"THIS,SERIES" description:"THIS changes only this occurrence, SERIES changes every open occurrence of the series"`
+	Event   SeriesEventData `json:"event" validate:"required"`
+}
+
+type CancelSeriesEventRequest struct {
+	EventId string      `path:"eventId" validate:"required"`
+	Scope   SeriesScope `json:"scope" validate:"required" enum:"THIS,SERIES" description:"THIS cancels only this occurrence, SERIES cancels every open occurrence of the series"`
+	Reason  string      `json:"reason,omitempty" description:"Reason shown to the joiners"`
+}
+
+type SeriesEventsResponse struct {
+	Events []*Event `json:"events" description:"Occurrences affected by the operation"`
+}
+
 type ListEventsRequest struct {
 }
 
 type ListEventsResponse struct {
-	Events []*Event `json:"events"`
-	Total  int      `json:"total"`
+	Events     []*Event `json:"events"`
+	Total      int      `json:"total"`
+	NextCursor string   `json:"nextCursor,omitempty" description:"Opaque cursor for the next page, empty when there are no more events"`
 }
 
 // Locations
@@ -197,9 +464,18 @@ type Location struct {
 	Name        string      `json:"name"`
 	Address     string      `json:"address,omitempty"`
 	Coordinates Coordinates `json:"coordinates,omitempty"`
+	DistanceKm  *float64    `json:"distanceKm,omitempty" db:"distance_km" description:"Distance from the search center, only set when locations are searched by distance"`
 }
 
 type ListLocationsRequest struct {
+	Surfaces    []CourtSurface `query:"surface" description:"Only facilities with courts of one of these surfaces" enum:"hard,clay,artificial-grass,carpet,grass"`
+	Covered     bool           `query:"covered" description:"Only facilities with covered courts: indoor, tent or balloon"`
+	Lighting    bool           `query:"lighting" description:"Only facilities with lit courts"`
+	Latitude    float64        `query:"lat" description:"Latitude of the search center, facilities are sorted by distance from it"`
+	Longitude   float64        `query:"lng" description:"Longitude of the search center, facilities are sorted by distance from it"`
+	RadiusKm    float64        `query:"radiusKm" description:"Only facilities within this distance in kilometers from lat/lng"`
+	NearProfile bool           `query:"nearProfile" description:"Use the city of the user profile as the search center when lat/lng are not given"`
+	PartnerCard []string       `query:"partnerCard" description:"Only facilities accepting one of these partner cards"`
 }
 
 type ListLocationsResponse struct {
@@ -210,7 +486,115 @@ type GetLocationRequest struct {
 	ID string `path:"id" validate:"required"`
 }
 
+type CourtSurface string
+
+const (
+	CourtSurfaceHard            CourtSurface = "hard"
+	CourtSurfaceClay            CourtSurface = "clay"
+	CourtSurfaceArtificialGrass CourtSurface = "artificial-grass"
+	CourtSurfaceCarpet          CourtSurface = "carpet"
+	CourtSurfaceGrass           CourtSurface = "grass"
+)
+
+// CourtGroup is a group of courts of a facility sharing surface, type and prices
+type CourtGroup struct {
+	Id              int          `json:"id"`
+	Surface         CourtSurface `json:"surface"`
+	Type            string       `json:"type" description:"indoor, outdoor, tent or balloon"`
+	Covered         bool         `json:"covered" description:"Whether the courts are indoor, in a tent or a balloon"`
+	Light           bool         `json:"light"`
+	Heating         bool         `json:"heating"`
+	ReservationLink string       `json:"reservationLink,omitempty"`
+	CourtNames      []string     `json:"courtNames"`
+}
+
+// PartnerCard is a sport card accepted by a facility
+type PartnerCard struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ListPartnerCardsRequest struct {
+}
+
+type ListPartnerCardsResponse struct {
+	PartnerCards []PartnerCard `json:"partnerCards"`
+}
+
+// LocationDetails is the full profile of a facility
+type LocationDetails struct {
+	Location
+	Country        string        `json:"country"`
+	Website        string        `json:"website,omitempty"`
+	GoogleMapsLink string        `json:"googleMapsLink,omitempty"`
+	CourtGroups    []CourtGroup  `json:"courtGroups"`
+	PartnerCards   []PartnerCard `json:"partnerCards"`
+}
+
+type GetLocationResponse struct {
+	Location LocationDetails `json:"location"`
+}
+
+type CourtPriceRequest struct {
+	LocationId string `path:"id" validate:"required"`
+	DateTime   string `query:"datetime" validate:"required" description:"Session start in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Duration   int    `query:"duration" validate:"required" description:"Session duration in minutes"`
+}
+
+// PriceSegment is a part of the session charged at the same hourly price
+type PriceSegment struct {
+	Start       string  `json:"start" format:"date" description:"Segment start in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	End         string  `json:"end" format:"date" description:"Segment end in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	HourlyPrice float64 `json:"hourlyPrice"`
+	Price       float64 `json:"price"`
+}
+
+// CourtGroupPrice is the price of the session on a group of courts of the facility
+type CourtGroupPrice struct {
+	CourtGroupId int            `json:"courtGroupId"`
+	Surface      string         `json:"surface"`
+	Type         string         `json:"type"`
+	Price        float64        `json:"price"`
+	Segments     []PriceSegment `json:"segments"`
+}
+
+type CourtPriceResponse struct {
+	LocationId  string            `json:"locationId"`
+	DateTime    string            `json:"datetime" format:"date" description:"Session start in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Duration    int               `json:"duration" description:"Session duration in minutes"`
+	Price       *float64          `json:"price,omitempty" description:"Lowest court price of the session, missing when no court has a price for the whole session"`
+	CourtGroups []CourtGroupPrice `json:"courtGroups" description:"Court groups with a price for the whole session, the cheapest first"`
+}
+
+type LocationHolidaysRequest struct {
+	LocationId string `path:"id" validate:"required"`
+	From       string `query:"from" description:"First local date in YYYY-MM-DD format, today by default"`
+	To         string `query:"to" description:"Last local date in YYYY-MM-DD format, a year after from by default"`
+}
+
+// Holiday is a public holiday in the country of a facility
+type Holiday struct {
+	Date string `json:"date" description:"Local date in YYYY-MM-DD format"`
+	Name string `json:"name"`
+}
+
+type HolidaysResponse struct {
+	Country  string    `json:"country"`
+	Holidays []Holiday `json:"holidays"`
+}
+
 type ListPublicEventsRequest struct {
+	SkillLevels []SkillLevel `query:"skillLevel" description:"Only events with one of these skill levels" enum:"ANY,BEGINNER,INTERMEDIATE,ADVANCED"`
+	EventType   EventType    `query:"eventType" description:"Only events of this type" enum:"MATCH,TRAINING"`
+	FacilityIds []string     `query:"facilityId" description:"Only events offering at least one of these locations"`
+	DateFrom    string       `query:"dateFrom" description:"Only events with a time slot at or after this time, in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	DateTo      string       `query:"dateTo" description:"Only events with a time slot before this time, in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+	Latitude    float64      `query:"lat" description:"Latitude of the search center, used together with radiusKm"`
+	Longitude   float64      `query:"lng" description:"Longitude of the search center, used together with radiusKm"`
+	RadiusKm    float64      `query:"radiusKm" description:"Only events with a location within this distance in kilometers from lat/lng"`
+	Query       string       `query:"q" description:"Free text search in event description"`
+	Limit       int          `query:"limit" default:"50" description:"Maximum number of events to return, at most 100"`
+	Cursor      string       `query:"cursor" description:"Cursor returned as nextCursor by the previous page"`
 }
 
 type ListJoinedEventsRequest struct {
@@ -225,8 +609,19 @@ type GetMyProfileRequest struct {
 }
 
 type GetUserProfileResponse struct {
-	UserId  string           `json:"userId"`
-	Profile *UserProfileData `json:"profile"`
+	UserId              string           `json:"userId"`
+	Profile             *UserProfileData `json:"profile"`
+	Rating              *PlayerRating    `json:"rating,omitempty" description:"Rating computed from confirmed match results, absent until the first one"`
+	HomeFacilityId      string           `json:"homeFacilityId,omitempty"`
+	FavouriteFacilities []Location       `json:"favouriteFacilities" description:"Favourite facilities of the user, the home facility first"`
+}
+
+// PlayerRating is an Elo rating of a player computed from confirmed match results
+type PlayerRating struct {
+	Rating        int  `json:"rating"`
+	MatchesPlayed int  `json:"matchesPlayed"`
+	Provisional   bool `json:"provisional" description:"Whether the rating is based on too few matches to be reliable"`
+	Trend         int  `json:"trend" description:"Rating change over the latest matches"`
 }
 
 type CreateUserProfileRequest struct {
@@ -263,11 +658,72 @@ type UserProfileData struct {
 	City          string               `json:"city" default:"Wroclaw"`
 	Notifications NotificationSettings `json:"notification_settings"`
 	Role          string               `json:"role,omitempty"`
+	PartnerCards  []string             `json:"partnerCards" description:"Ids of the partner cards held by the user, kept unchanged when omitted in an update"`
 }
 
 type DeleteUserProfileRequest struct {
 }
 
+// Favourite facilities types
+
+type ListFavouriteFacilitiesRequest struct {
+}
+
+type FavouriteFacilityRequest struct {
+	FacilityId string `path:"facilityId" validate:"required"`
+}
+
+type SetHomeFacilityRequest struct {
+	FacilityId string `json:"facilityId" description:"Id of the home facility, it is added to the favourites. Empty to clear the home facility"`
+}
+
+type FavouriteFacilitiesResponse struct {
+	HomeFacilityId string     `json:"homeFacilityId,omitempty"`
+	Favourites     []Location `json:"favourites" description:"Favourite facilities, the home facility first"`
+}
+
+// Invitation types
+
+// EventInvitation lets a user join a private event without an invite link
+type EventInvitation struct {
+	EventId   string `json:"eventId"`
+	UserId    string `json:"userId"`
+	InvitedBy string `json:"invitedBy"`
+	CreatedAt string `json:"createdAt" format:"date" description:"Invitation timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+}
+
+type CreateInviteLinkRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+}
+
+type InviteLinkResponse struct {
+	Token     string `json:"token"`
+	Path      string `json:"path" description:"Relative link to the event carrying the invite token"`
+	ExpiresAt string `json:"expiresAt" format:"date" description:"Expiration timestamp in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)"`
+}
+
+type ResolveInviteRequest struct {
+	Token string `path:"token" validate:"required"`
+}
+
+type InviteUsersRequest struct {
+	EventId string   `path:"eventId" validate:"required"`
+	UserIds []string `json:"userIds" validate:"required,min=1"`
+}
+
+type ListInvitationsRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+}
+
+type ListInvitationsResponse struct {
+	Invitations []*EventInvitation `json:"invitations"`
+}
+
+type DeleteInvitationRequest struct {
+	EventId string `path:"eventId" validate:"required"`
+	UserId  string `path:"userId" validate:"required"`
+}
+
 // Chat message types
 
 type EventMessage struct {
@@ -331,10 +787,12 @@ type AddPlaceResponse struct {
 
 type AdminFacility struct {
 	Location
-	Status        string `json:"status"`
-	Source        string `json:"source"`
-	AddedBy       string `json:"addedBy,omitempty"`
-	GooglePlaceID string `json:"googlePlaceId,omitempty"`
+	Status  string `json:"status"`
+	Source  string `json:"source"`
+	AddedBy string `json:"addedBy,omitempty"`
+	// PlaceProvider is google or osm for facilities found through place search or imported with a place id
+	PlaceProvider string `json:"placeProvider,omitempty"`
+	PlaceID       string `json:"placeId,omitempty"`
 	CreatedAt     string `json:"createdAt,omitempty"`
 }
 
@@ -346,3 +804,146 @@ type AdminUpdateFacilityRequest struct {
 	FacilityID string `path:"facilityId" validate:"required"`
 	Status     string `json:"status" validate:"required"`
 }
+
+// AdminFacilityData is the profile of a facility editable by admins
+type AdminFacilityData struct {
+	Name           string      `json:"name" validate:"required"`
+	Address        string      `json:"address" validate:"required"`
+	Country        string      `json:"country" validate:"required" description:"Two or three letter country code"`
+	Coordinates    Coordinates `json:"coordinates"`
+	Website        string      `json:"website,omitempty"`
+	GoogleMapsLink string      `json:"googleMapsLink,omitempty"`
+}
+
+type AdminCreateFacilityRequest struct {
+	AdminFacilityData
+}
+
+type AdminUpdateFacilityDetailsRequest struct {
+	FacilityID string `path:"facilityId" validate:"required"`
+	AdminFacilityData
+}
+
+type AdminGetFacilityRequest struct {
+	FacilityID string `path:"facilityId" validate:"required"`
+}
+
+// AdminPriceRule is the hourly price of a court between two clock times. A rule ending before it starts spans
+// midnight, a rule ending when it starts lasts the whole day.
+type AdminPriceRule struct {
+	DayPattern string  `json:"dayPattern" validate:"required" enum:"*,st,su,hl,!" description:"* everyday without rules of its own, st Saturday, su Sunday, hl public holiday, ! everyday winning over other rules"`
+	Start      string  `json:"start" validate:"required" description:"Local time in HH:MM format"`
+	End        string  `json:"end" validate:"required" description:"Local time in HH:MM format"`
+	Price      float64 `json:"price"`
+}
+
+// AdminPricePeriod is a price list of a court group valid between two dates
+type AdminPricePeriod struct {
+	Id           int              `json:"id"`
+	CourtGroupId int              `json:"courtGroupId"`
+	ValidFrom    string           `json:"validFrom" description:"First day in YYYY-MM-DD format"`
+	ValidTo      string           `json:"validTo" description:"Last day in YYYY-MM-DD format, inclusive"`
+	Rules        []AdminPriceRule `json:"rules"`
+}
+
+type AdminFacilityDetails struct {
+	Location     LocationDetails    `json:"location"`
+	Status       string             `json:"status"`
+	Source       string             `json:"source"`
+	PricePeriods []AdminPricePeriod `json:"pricePeriods"`
+}
+
+// AdminCourtGroupData describes a group of courts sharing surface, type and prices
+type AdminCourtGroupData struct {
+	Surface         CourtSurface `json:"surface" validate:"required" enum:"hard,clay,artificial-grass,carpet,grass"`
+	Type            string       `json:"type" validate:"required" enum:"indoor,outdoor,tent,balloon"`
+	Light           bool         `json:"light"`
+	Heating         bool         `json:"heating"`
+	ReservationLink string       `json:"reservationLink,omitempty"`
+	CourtNames      []string     `json:"courtNames"`
+}
+
+type AdminCreateCourtGroupRequest struct {
+	FacilityID string `path:"facilityId" validate:"required"`
+	AdminCourtGroupData
+}
+
+type AdminUpdateCourtGroupRequest struct {
+	CourtGroupId int `path:"courtGroupId" validate:"required"`
+	AdminCourtGroupData
+}
+
+type AdminDeleteCourtGroupRequest struct {
+	CourtGroupId int `path:"courtGroupId" validate:"required"`
+}
+
+type AdminPricePeriodData struct {
+	ValidFrom string           `json:"validFrom" validate:"required" description:"First day in YYYY-MM-DD format"`
+	ValidTo   string           `json:"validTo" validate:"required" description:"Last day in YYYY-MM-DD format, inclusive"`
+	Rules     []AdminPriceRule `json:"rules" validate:"required"`
+}
+
+type AdminCreatePricePeriodRequest struct {
+	CourtGroupId int `path:"courtGroupId" validate:"required"`
+	AdminPricePeriodData
+}
+
+type AdminUpdatePricePeriodRequest struct {
+	PricePeriodId int `path:"pricePeriodId" validate:"required"`
+	AdminPricePeriodData
+}
+
+type AdminDeletePricePeriodRequest struct {
+	PricePeriodId int `path:"pricePeriodId" validate:"required"`
+}
+
+type AdminListFacilityDuplicatesRequest struct {
+	RadiusM       float64 `query:"radiusM" default:"300" description:"Maximum distance in meters between duplicates"`
+	MinSimilarity float64 `query:"minSimilarity" default:"0.5" description:"Minimum name similarity from 0 to 1, facilities at the same spot are listed regardless of their names"`
+}
+
+type AdminFacilityDuplicate struct {
+	Facility       AdminFacility `json:"facility" description:"Facility suggested to keep: seeded or admin created, with a Google Place ID, added first"`
+	Duplicate      AdminFacility `json:"duplicate"`
+	DistanceM      float64       `json:"distanceM"`
+	NameSimilarity float64       `json:"nameSimilarity" description:"From 0 for unrelated names to 1 for the same name"`
+}
+
+type AdminListFacilityDuplicatesResponse struct {
+	Duplicates []AdminFacilityDuplicate `json:"duplicates"`
+}
+
+type AdminMergeFacilityRequest struct {
+	FacilityID  string `path:"facilityId" validate:"required" description:"Facility to keep"`
+	DuplicateID string `json:"duplicateId" validate:"required" description:"Facility merged into the kept one and deleted"`
+}
+
+type AdminListHolidaysRequest struct {
+	Country string `path:"country" validate:"required" description:"ISO 3166-1 alpha-2 country code"`
+	Year    int    `query:"year" description:"Year of the holidays, the current year by default"`
+}
+
+// HolidayOverride adds a holiday missing from the embedded data or, when isHoliday is false, removes one
+type HolidayOverride struct {
+	Date      string `json:"date" description:"Local date in YYYY-MM-DD format"`
+	Name      string `json:"name"`
+	IsHoliday bool   `json:"isHoliday"`
+}
+
+type AdminListHolidaysResponse struct {
+	Country   string            `json:"country"`
+	Holidays  []Holiday         `json:"holidays" description:"Holidays of the year with the overrides applied"`
+	Overrides []HolidayOverride `json:"overrides" description:"All overrides of the country"`
+}
+
+type AdminSetHolidayOverrideRequest struct {
+	Country   string `path:"country" validate:"required" description:"ISO 3166-1 alpha-2 country code"`
+	Date      string `path:"date" validate:"required" description:"Local date in YYYY-MM-DD format"`
+	Name      string `json:"name" description:"Holiday name, required when isHoliday is true"`
+	IsHoliday bool   `json:"isHoliday"`
+}
+
+type AdminDeleteHolidayOverrideRequest struct {
+	Country string `path:"country" validate:"required" description:"ISO 3166-1 alpha-2 country code"`
+	Date    string `path:"date" validate:"required" description:"Local date in YYYY-MM-DD format"`
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CourtTypes are the court group types accepted from admins and imports
+var CourtTypes = []string{"indoor", "outdoor", "tent", "balloon"}
+
+// NormalizeFacilityData trims the profile of the facility and checks that it is complete
+func NormalizeFacilityData(data *AdminFacilityData) error {
+	data.Name = strings.TrimSpace(data.Name)
+	data.Address = strings.TrimSpace(data.Address)
+	data.Country = strings.ToUpper(strings.TrimSpace(data.Country))
+	data.Website = strings.TrimSpace(data.Website)
+	data.GoogleMapsLink = strings.TrimSpace(data.GoogleMapsLink)
+
+	if data.Name == "" || data.Address == "" {
+		return fmt.Errorf("name and address are required")
+	}
+	if len(data.Country) < 2 || len(data.Country) > 3 {
+		return fmt.Errorf("country must be a two or three letter code")
+	}
+	c := data.Coordinates
+	if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 || (c.Latitude == 0 && c.Longitude == 0) {
+		return fmt.Errorf("invalid coordinates")
+	}
+	for _, link := range []string{data.Website, data.GoogleMapsLink} {
+		if !isHttpLink(link) {
+			return fmt.Errorf("invalid link: %s", link)
+		}
+	}
+	return nil
+}
+
+// NormalizeCourtGroupData trims the description of the court group and checks its surface, type and court names
+func NormalizeCourtGroupData(data *AdminCourtGroupData) error {
+	switch data.Surface {
+	case CourtSurfaceHard, CourtSurfaceClay, CourtSurfaceArtificialGrass, CourtSurfaceCarpet, CourtSurfaceGrass:
+	default:
+		return fmt.Errorf("invalid surface: %s", data.Surface)
+	}
+
+	validType := false
+	for _, t := range CourtTypes {
+		validType = validType || data.Type == t
+	}
+	if !validType {
+		return fmt.Errorf("invalid court type: %s", data.Type)
+	}
+
+	data.ReservationLink = strings.TrimSpace(data.ReservationLink)
+	if !isHttpLink(data.ReservationLink) {
+		return fmt.Errorf("invalid reservation link")
+	}
+
+	// court names are stored comma separated
+	names := make([]string, 0, len(data.CourtNames))
+	for _, name := range data.CourtNames {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.Contains(name, ",") {
+			return fmt.Errorf("invalid court name: %q", name)
+		}
+		names = append(names, name)
+	}
+	data.CourtNames = names
+	return nil
+}
+
+// isHttpLink tells whether the optional link is empty or a http(s) URL
+func isHttpLink(link string) bool {
+	return link == "" || strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://")
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxSetsInMatch is the number of sets of a best-of-five match
+const MaxSetsInMatch = 5
+
+// ValidateSets checks that sets form a finished best-of-three or best-of-five match.
+// A deciding set may be played as a match tie-break to 10.
+func ValidateSets(sets []SetScore) error {
+	if len(sets) < 2 || len(sets) > MaxSetsInMatch {
+		return fmt.Errorf("a match has between 2 and %d sets", MaxSetsInMatch)
+	}
+
+	playerSets, opponentSets := 0, 0
+	for i, set := range sets {
+		deciding := i == len(sets)-1 && playerSets == opponentSets
+		if err := validateSet(set, deciding); err != nil {
+			return fmt.Errorf("set %d: %w", i+1, err)
+		}
+
+		if set.Player > set.Opponent {
+			playerSets++
+		} else {
+			opponentSets++
+		}
+	}
+
+	winnerSets := max(playerSets, opponentSets)
+	loserSets := min(playerSets, opponentSets)
+	if winnerSets != 2 && winnerSets != 3 || loserSets >= winnerSets {
+		return fmt.Errorf("%d:%d in sets is not a finished match", playerSets, opponentSets)
+	}
+
+	// the winner takes the last set, otherwise the match was decided before it
+	last := sets[len(sets)-1]
+	if (last.Player > last.Opponent) != (playerSets > opponentSets) {
+		return fmt.Errorf("sets played after the match was decided")
+	}
+
+	return nil
+}
+
+func validateSet(set SetScore, deciding bool) error {
+	if set.Player < 0 || set.Opponent < 0 {
+		return fmt.Errorf("games cannot be negative")
+	}
+
+	winner := max(set.Player, set.Opponent)
+	loser := min(set.Player, set.Opponent)
+
+	switch {
+	case winner == 6 && loser <= 4:
+		return nil
+	case winner == 7 && (loser == 5 || loser == 6):
+		return nil
+	case deciding && (winner == 10 && loser <= 8 || winner > 10 && winner-loser == 2):
+		// match tie-break played instead of the deciding set
+		return nil
+	default:
+		return fmt.Errorf("%d:%d is not a valid set score", set.Player, set.Opponent)
+	}
+}
+
+// PlayerWon tells whether the side of the player who submitted the result won the match
+func (r *MatchResult) PlayerWon() bool {
+	won := 0
+	for _, set := range r.Sets {
+		if set.Player > set.Opponent {
+			won++
+		}
+	}
+	return won*2 > len(r.Sets)
+}
+
+// IsPostMatchOpen tells whether results, feedback and no-show reports can still be submitted for the event
+func (e *Event) IsPostMatchOpen(now time.Time) bool {
+	if e.Status != EventStatusCompleted || e.PostMatchUntil == "" {
+		return false
+	}
+	until, err := time.Parse(time.RFC3339, e.PostMatchUntil)
+	if err != nil {
+		return false
+	}
+	return now.Before(until)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateSets_Valid(t *testing.T) {
+	cases := map[string][]SetScore{
+		"straight sets":         {{6, 4}, {6, 3}},
+		"three sets":            {{6, 4}, {3, 6}, {7, 5}},
+		"tie-breaks":            {{7, 6}, {6, 7}, {7, 6}},
+		"match tie-break":       {{6, 4}, {4, 6}, {10, 8}},
+		"long match tie-break":  {{4, 6}, {6, 4}, {12, 14}},
+		"best of five":          {{6, 4}, {4, 6}, {6, 3}, {3, 6}, {7, 5}},
+		"best of five in four":  {{6, 4}, {4, 6}, {6, 3}, {6, 2}},
+		"opponent wins 6:0 6:0": {{0, 6}, {0, 6}},
+	}
+
+	for name, sets := range cases {
+		assert.NoError(t, ValidateSets(sets), name)
+	}
+}
+
+func Test_ValidateSets_Invalid(t *testing.T) {
+	cases := map[string][]SetScore{
+		"single set":                 {{6, 4}},
+		"too many sets":              {{6, 4}, {4, 6}, {6, 4}, {4, 6}, {6, 4}, {6, 4}},
+		"unfinished set":             {{6, 4}, {5, 4}},
+		"tie":                        {{6, 4}, {4, 6}},
+		"too close":                  {{6, 5}, {6, 4}},
+		"negative":                   {{6, -1}, {6, 4}},
+		"set after decided":          {{6, 4}, {6, 4}, {4, 6}},
+		"match tie-break not last":   {{10, 8}, {6, 4}},
+		"match tie-break too close":  {{6, 4}, {4, 6}, {11, 10}},
+		"match tie-break not needed": {{6, 4}, {6, 4}, {10, 5}},
+	}
+
+	for name, sets := range cases {
+		assert.Error(t, ValidateSets(sets), name)
+	}
+}
+
+func Test_MatchResult_PlayerWon(t *testing.T) {
+	assert.True(t, (&MatchResult{Sets: []SetScore{{6, 4}, {3, 6}, {7, 5}}}).PlayerWon())
+	assert.False(t, (&MatchResult{Sets: []SetScore{{6, 4}, {3, 6}, {5, 7}}}).PlayerWon())
+}
+
+func Test_Event_IsPostMatchOpen(t *testing.T) {
+	now := time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC)
+	event := &Event{Status: EventStatusCompleted, PostMatchUntil: "2025-06-10T12:00:00Z"}
+
+	assert.True(t, event.IsPostMatchOpen(now))
+	assert.False(t, event.IsPostMatchOpen(now.AddDate(0, 0, 6)))
+
+	event.Status = EventStatusConfirmed
+	assert.False(t, event.IsPostMatchOpen(now))
+}
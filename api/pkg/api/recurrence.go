@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxSeriesOccurrences limits how many events a single recurrence rule may create
+const MaxSeriesOccurrences = 52
+
+// Interval returns the number of days between two consecutive occurrences
+func (f RecurrenceFrequency) Interval() (int, error) {
+	switch f {
+	case RecurrenceFrequencyWeekly:
+		return 7, nil
+	case RecurrenceFrequencyBiweekly:
+		return 14, nil
+	default:
+		return 0, fmt.Errorf("unsupported recurrence frequency: %s", f)
+	}
+}
+
+// Occurrences returns time slots of every occurrence of the series, the first one being timeSlots itself.
+// Time slots are shifted in UTC, so the local time of an occurrence may move by an hour across DST changes.
+func (r *Recurrence) Occurrences(timeSlots []time.Time) ([][]time.Time, error) {
+	days, err := r.Frequency.Interval()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(timeSlots) == 0 {
+		return nil, fmt.Errorf("at least one time slot is required")
+	}
+
+	if (r.Count > 0) == (r.Until != "") {
+		return nil, fmt.Errorf("exactly one of count and until must be set")
+	}
+
+	earliest := timeSlots[0]
+	for _, ts := range timeSlots[1:] {
+		if ts.Before(earliest) {
+			earliest = ts
+		}
+	}
+
+	count := r.Count
+	if r.Until != "" {
+		until, err := time.Parse(time.RFC3339, r.Until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until, expected ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)")
+		}
+
+		count = 0
+		for i := 0; i <= MaxSeriesOccurrences && !earliest.AddDate(0, 0, i*days).After(until); i++ {
+			count++
+		}
+	}
+
+	if count < 2 {
+		return nil, fmt.Errorf("recurrence must produce at least 2 occurrences")
+	}
+	if count > MaxSeriesOccurrences {
+		return nil, fmt.Errorf("recurrence must not produce more than %d occurrences", MaxSeriesOccurrences)
+	}
+
+	occurrences := make([][]time.Time, count)
+	for i := range occurrences {
+		occurrences[i] = make([]time.Time, len(timeSlots))
+		for j, ts := range timeSlots {
+			occurrences[i][j] = ts.AddDate(0, 0, i*days)
+		}
+	}
+
+	return occurrences, nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Recurrence_WeeklyByCount(t *testing.T) {
+	r := Recurrence{Frequency: RecurrenceFrequencyWeekly, Count: 3}
+	slots := DtFromIsoArray([]string{"2025-06-03T18:00:00Z", "2025-06-05T18:00:00Z"})
+
+	occurrences, err := r.Occurrences(slots)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 3)
+
+	assert.Equal(t, []string{"2025-06-03T18:00:00Z", "2025-06-05T18:00:00Z"}, DtToIsoArray(occurrences[0]))
+	assert.Equal(t, []string{"2025-06-10T18:00:00Z", "2025-06-12T18:00:00Z"}, DtToIsoArray(occurrences[1]))
+	assert.Equal(t, []string{"2025-06-17T18:00:00Z", "2025-06-19T18:00:00Z"}, DtToIsoArray(occurrences[2]))
+}
+
+func Test_Recurrence_BiweeklyUntilIsInclusive(t *testing.T) {
+	r := Recurrence{Frequency: RecurrenceFrequencyBiweekly, Until: "2025-07-01T18:00:00Z"}
+	slots := DtFromIsoArray([]string{"2025-06-03T18:00:00Z"})
+
+	occurrences, err := r.Occurrences(slots)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 3)
+	assert.Equal(t, "2025-07-01T18:00:00Z", DtToIso(occurrences[2][0]))
+}
+
+func Test_Recurrence_Invalid(t *testing.T) {
+	slots := DtFromIsoArray([]string{"2025-06-03T18:00:00Z"})
+
+	cases := map[string]Recurrence{
+		"unknown frequency":  {Frequency: "DAILY", Count: 3},
+		"count and until":    {Frequency: RecurrenceFrequencyWeekly, Count: 3, Until: "2025-07-01T18:00:00Z"},
+		"neither":            {Frequency: RecurrenceFrequencyWeekly},
+		"single occurrence":  {Frequency: RecurrenceFrequencyWeekly, Count: 1},
+		"until before start": {Frequency: RecurrenceFrequencyWeekly, Until: "2025-06-01T00:00:00Z"},
+		"too many":           {Frequency: RecurrenceFrequencyWeekly, Count: MaxSeriesOccurrences + 1},
+		"bad until":          {Frequency: RecurrenceFrequencyWeekly, Until: "next month"},
+	}
+
+	for name, r := range cases {
+		_, err := r.Occurrences(slots)
+		assert.Error(t, err, name)
+	}
+}
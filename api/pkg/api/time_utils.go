@@ -2,8 +2,13 @@ package api
 
 import "time"
 
+// TryParseDt parses a time in UTC in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ), used to validate request fields
+func TryParseDt(iso string) (time.Time, error) {
+	return time.Parse("2006-01-02T15:04:05Z", iso)
+}
+
 func ParseDt(iso string) time.Time {
-	t, err := time.Parse("2006-01-02T15:04:05Z", iso)
+	t, err := TryParseDt(iso)
 	if err != nil {
 		panic(err)
 	}
@@ -16,6 +16,8 @@ type Config struct {
 	Db            DbConfig
 	Notifications NotificationConfig
 	Expiration    JobsConfig
+	Completion    CompletionConfig
+	Reminders     ReminderConfig
 	Features      FeatureToggles
 }
 
@@ -44,16 +46,57 @@ type JobsConfig struct {
 	Interval time.Duration `default:"1m" envvar:"JOBS_INTERVAL"`
 }
 
+type CompletionConfig struct {
+	Interval time.Duration `default:"5m" envvar:"COMPLETION_JOBS_INTERVAL"`
+	// PostMatchWindow is how long players can report results, leave feedback and report no-shows after an event is completed
+	PostMatchWindow time.Duration `default:"168h" envvar:"POST_MATCH_WINDOW"`
+}
+
+type ReminderConfig struct {
+	Interval time.Duration `default:"15m" envvar:"REMINDER_JOBS_INTERVAL"`
+	// RescheduleLead is how long before a rescheduled session players who have not acknowledged it are reminded
+	RescheduleLead time.Duration `default:"24h" envvar:"RESCHEDULE_REMINDER_LEAD"`
+}
+
 type HttpConfig struct {
 	Port           int          `default:"8080" envvar:"SERVICE_PORT"`
 	Cors           *cors.Config `default:"{\"AllowOrigins\":[\"http://localhost\"],\"AllowMethods\":[\"GET\",\"POST\",\"PUT\",\"DELETE\",\"OPTIONS\"],\"AllowHeaders\":[\"Origin\",\"Content-Length\",\"Content-Type\",\"Authorization\"],\"ExposeHeaders\":[\"Content-Length\"],\"AllowCredentials\":true,\"MaxAge\":43200000000000}"`
 	AuthConfig     AuthConfig
 	GoogleCalendar GoogleCalendarConfig
-	GooglePlaces   GooglePlacesConfig
+	// GooglePlaces keeps its name so that existing config files still apply
+	GooglePlaces PlacesConfig
+	Invites      InviteConfig
+	Reservations ReservationConfig
+}
+
+type InviteConfig struct {
+	// Secret signs invite links, it is required unless the service runs in debug mode or with debug auth.
+	// When empty a random secret is generated and links stop working after a restart
+	Secret   string        `envvar:"INVITE_SECRET"`
+	TokenTtl time.Duration `default:"168h" envvar:"INVITE_TOKEN_TTL"`
+}
+
+type ReservationConfig struct {
+	// FakeFacilities are comma separated ids of facilities booked by the in-process fake provider,
+	// meant for local development and service tests
+	FakeFacilities string `envvar:"RESERVATION_FAKE_FACILITIES"`
+	FakeCourts     int    `default:"1" envvar:"RESERVATION_FAKE_COURTS"`
 }
 
-type GooglePlacesConfig struct {
+// PlacesConfig selects the provider of place search
+type PlacesConfig struct {
 	APIKey string `envvar:"GOOGLE_PLACES_API_KEY"`
+	// Provider is google, which needs the API key, or osm to search an OpenStreetMap extract
+	Provider string `default:"google" envvar:"PLACES_PROVIDER"`
+	// OsmFile is an Overpass JSON dump of tennis courts searched by the osm provider
+	OsmFile string `envvar:"PLACES_OSM_FILE"`
+	// OsmCountry is the country of OpenStreetMap places without addr:country
+	OsmCountry string `default:"PL" envvar:"PLACES_OSM_COUNTRY"`
+	// CacheTtl and CacheSize apply to the osm provider, the Google Maps Platform terms do not allow caching the
+	// content of Google places
+	CacheTtl time.Duration `default:"24h" envvar:"PLACES_CACHE_TTL"`
+	// CacheSize is the number of cached responses, 0 disables the cache
+	CacheSize int `default:"1000" envvar:"PLACES_CACHE_SIZE"`
 }
 
 type AuthConfig struct {
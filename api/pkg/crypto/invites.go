@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidInviteToken is returned for malformed tokens and tokens with a wrong signature
+	ErrInvalidInviteToken = errors.New("invalid invite token")
+	// ErrExpiredInviteToken is returned for correctly signed tokens past their expiration time
+	ErrExpiredInviteToken = errors.New("invite token has expired")
+)
+
+// InviteSigner issues and verifies signed, expiring invite tokens of private events
+type InviteSigner struct {
+	secret []byte
+}
+
+// NewInviteSigner creates a signer with the given secret. When the secret is empty a random one is generated,
+// tokens then stop being valid after a restart.
+func NewInviteSigner(secret string) (*InviteSigner, error) {
+	if secret != "" {
+		return &InviteSigner{secret: []byte(secret)}, nil
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate invite secret: %w", err)
+	}
+	return &InviteSigner{secret: random}, nil
+}
+
+// Sign returns a token granting access to the event until the expiration time
+func (s *InviteSigner) Sign(eventId string, expiresAt time.Time) string {
+	payload := eventId + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify checks the token signature and expiration and returns the event the token grants access to
+func (s *InviteSigner) Verify(token string, now time.Time) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidInviteToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return "", ErrInvalidInviteToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidInviteToken
+	}
+
+	eventId, expires, ok := strings.Cut(string(payload), "|")
+	if !ok || eventId == "" {
+		return "", ErrInvalidInviteToken
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", ErrInvalidInviteToken
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return "", ErrExpiredInviteToken
+	}
+
+	return eventId, nil
+}
+
+func (s *InviteSigner) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteSigner_RoundTrip(t *testing.T) {
+	signer, err := NewInviteSigner("secret")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := signer.Sign("event-1", now.Add(time.Hour))
+
+	eventId, err := signer.Verify(token, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "event-1", eventId)
+}
+
+func TestInviteSigner_Expired(t *testing.T) {
+	signer, err := NewInviteSigner("secret")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := signer.Sign("event-1", now)
+
+	_, err = signer.Verify(token, now)
+	assert.ErrorIs(t, err, ErrExpiredInviteToken)
+}
+
+func TestInviteSigner_TamperedToken(t *testing.T) {
+	signer, err := NewInviteSigner("secret")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := signer.Sign("event-1", now.Add(time.Hour))
+	forged := signer.Sign("event-2", now.Add(time.Hour))
+
+	// payload of one token with the signature of another
+	payload, _, _ := strings.Cut(forged, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	_, err = signer.Verify(payload+"."+signature, now)
+	assert.ErrorIs(t, err, ErrInvalidInviteToken)
+
+	_, err = signer.Verify("not-a-token", now)
+	assert.ErrorIs(t, err, ErrInvalidInviteToken)
+}
+
+func TestInviteSigner_DifferentSecret(t *testing.T) {
+	signer, err := NewInviteSigner("secret")
+	require.NoError(t, err)
+	other, err := NewInviteSigner("")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	_, err = other.Verify(signer.Sign("event-1", now.Add(time.Hour)), now)
+	assert.ErrorIs(t, err, ErrInvalidInviteToken)
+}
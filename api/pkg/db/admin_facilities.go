@@ -0,0 +1,365 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+type pricePeriodRow struct {
+	Id           int             `db:"id"`
+	CourtGroupId int             `db:"court_group_id"`
+	ValidFrom    time.Time       `db:"valid_from"`
+	ValidTo      time.Time       `db:"valid_to"`
+	DayPattern   sql.NullString  `db:"day_pattern"`
+	TimeStart    sql.NullInt64   `db:"time_start"`
+	TimeEnd      sql.NullInt64   `db:"time_end"`
+	Price        sql.NullFloat64 `db:"price"`
+}
+
+// CreateFacility adds a facility created by an admin
+func (db *Db) CreateFacility(ctx context.Context, userId string, facilityId string, data *api.AdminFacilityData) error {
+	logCtx := slog.With("method", "CreateFacility", "userId", userId, "facilityId", facilityId)
+
+	query := `INSERT INTO facilities (id, name, address, google_maps_link, website, country, location, added_by, source, status)
+		VALUES (?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ST_GeomFromText(?), ?, 'admin', 'active')`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err := db.conn.ExecContext(ctx, query, facilityId, data.Name, data.Address, data.GoogleMapsLink, data.Website,
+		data.Country, pointWkt(data.Coordinates), userId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create facility")
+	}
+	return nil
+}
+
+// UpdateFacility replaces the profile of the facility
+func (db *Db) UpdateFacility(ctx context.Context, facilityId string, data *api.AdminFacilityData) error {
+	logCtx := slog.With("method", "UpdateFacility", "facilityId", facilityId)
+
+	if _, err := db.GetFacilityCountry(ctx, facilityId); err != nil {
+		return err
+	}
+
+	query := `UPDATE facilities SET name = ?, address = ?, google_maps_link = NULLIF(?, ''), website = NULLIF(?, ''),
+		country = ?, location = ST_GeomFromText(?) WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err := db.conn.ExecContext(ctx, query, data.Name, data.Address, data.GoogleMapsLink, data.Website,
+		data.Country, pointWkt(data.Coordinates), facilityId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update facility")
+	}
+	return nil
+}
+
+// GetFacilityAdminDetails returns the full profile of the facility, hidden ones included, with the price periods of
+// its court groups
+func (db *Db) GetFacilityAdminDetails(ctx context.Context, facilityId string) (*api.AdminFacilityDetails, error) {
+	location, err := db.getFacilityDetails(ctx, facilityId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &api.AdminFacilityDetails{Location: *location}
+	err = db.conn.QueryRowContext(ctx, `SELECT status, source FROM facilities WHERE id = ?`, facilityId).
+		Scan(&details.Status, &details.Source)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to get facility status")
+	}
+
+	details.PricePeriods, err = getPricePeriods(ctx, db.conn, "cg.facility_id = ?", facilityId)
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// GetCourtGroup returns the court group
+func (db *Db) GetCourtGroup(ctx context.Context, courtGroupId int) (*api.CourtGroup, error) {
+	query := `SELECT id, surface, type, light, heating, reservation_link, court_names FROM court_groups WHERE id = ?`
+	var row courtGroupRow
+	if err := db.conn.GetContext(ctx, &row, query, courtGroupId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Court group not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to get court group")
+	}
+	group := toApiCourtGroup(row)
+	return &group, nil
+}
+
+// CreateCourtGroup adds a group of courts to the facility and returns its id
+func (db *Db) CreateCourtGroup(ctx context.Context, facilityId string, data *api.AdminCourtGroupData) (int, error) {
+	logCtx := slog.With("method", "CreateCourtGroup", "facilityId", facilityId)
+
+	if _, err := db.GetFacilityCountry(ctx, facilityId); err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO court_groups (facility_id, surface, type, light, heating, reservation_link, court_names)
+		VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), ?)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, facilityId, data.Surface, data.Type, data.Light, data.Heating,
+		data.ReservationLink, strings.Join(data.CourtNames, ","))
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to create court group")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to get court group id")
+	}
+	return int(id), nil
+}
+
+// UpdateCourtGroup replaces the description of the court group, its prices are kept. An edited court group is no
+// longer replaced by facility imports.
+func (db *Db) UpdateCourtGroup(ctx context.Context, courtGroupId int, data *api.AdminCourtGroupData) error {
+	logCtx := slog.With("method", "UpdateCourtGroup", "courtGroupId", courtGroupId)
+
+	if _, err := db.GetCourtGroup(ctx, courtGroupId); err != nil {
+		return err
+	}
+
+	query := `UPDATE court_groups SET surface = ?, type = ?, light = ?, heating = ?, reservation_link = NULLIF(?, ''),
+		court_names = ?, imported = FALSE WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err := db.conn.ExecContext(ctx, query, data.Surface, data.Type, data.Light, data.Heating, data.ReservationLink,
+		strings.Join(data.CourtNames, ","), courtGroupId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update court group")
+	}
+	return nil
+}
+
+// DeleteCourtGroup removes the court group together with its price periods
+func (db *Db) DeleteCourtGroup(ctx context.Context, courtGroupId int) error {
+	logCtx := slog.With("method", "DeleteCourtGroup", "courtGroupId", courtGroupId)
+
+	query := `DELETE FROM court_groups WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, courtGroupId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete court group")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get affected rows")
+	}
+	if affected == 0 {
+		return DbObjectNotFoundError{Message: "Court group not found"}
+	}
+	return nil
+}
+
+// GetPricePeriod returns the price period with its rules
+func (db *Db) GetPricePeriod(ctx context.Context, pricePeriodId int) (*api.AdminPricePeriod, error) {
+	periods, err := getPricePeriods(ctx, db.conn, "pp.id = ?", pricePeriodId)
+	if err != nil {
+		return nil, err
+	}
+	if len(periods) == 0 {
+		return nil, DbObjectNotFoundError{Message: "Price period not found"}
+	}
+	return &periods[0], nil
+}
+
+// CreatePricePeriod adds the price period with its rules to the court group and returns its id. The period must not
+// overlap other periods of the group partially.
+func (db *Db) CreatePricePeriod(ctx context.Context, courtGroupId int, period *pricing.Period) (int, error) {
+	logCtx := slog.With("method", "CreatePricePeriod", "courtGroupId", courtGroupId)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	id, err := db.createPricePeriodTx(ctx, tx, logCtx, courtGroupId, period)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return id, nil
+}
+
+func (db *Db) createPricePeriodTx(ctx context.Context, tx *sqlx.Tx, logCtx *slog.Logger, courtGroupId int, period *pricing.Period) (int, error) {
+	if err := validatePricePeriodTx(ctx, tx, courtGroupId, 0, period); err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO price_periods (court_group_id, valid_from, valid_to) VALUES (?, ?, ?)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := tx.ExecContext(ctx, query, courtGroupId, period.ValidFrom.Format(time.DateOnly), period.ValidTo.Format(time.DateOnly))
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to create price period")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to get price period id")
+	}
+
+	if err := insertPriceRulesTx(ctx, tx, int(id), period.Rules); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// UpdatePricePeriod replaces the dates and rules of the price period
+func (db *Db) UpdatePricePeriod(ctx context.Context, pricePeriodId int, period *pricing.Period) error {
+	logCtx := slog.With("method", "UpdatePricePeriod", "pricePeriodId", pricePeriodId)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.updatePricePeriodTx(ctx, tx, logCtx, pricePeriodId, period); err != nil {
+		db.rollback(logCtx, tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return nil
+}
+
+func (db *Db) updatePricePeriodTx(ctx context.Context, tx *sqlx.Tx, logCtx *slog.Logger, pricePeriodId int, period *pricing.Period) error {
+	var courtGroupId int
+	err := tx.GetContext(ctx, &courtGroupId, `SELECT court_group_id FROM price_periods WHERE id = ?`, pricePeriodId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DbObjectNotFoundError{Message: "Price period not found"}
+		}
+		return errors.WithMessage(err, "Failed to get price period")
+	}
+
+	if err := validatePricePeriodTx(ctx, tx, courtGroupId, pricePeriodId, period); err != nil {
+		return err
+	}
+
+	query := `UPDATE price_periods SET valid_from = ?, valid_to = ? WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err = tx.ExecContext(ctx, query, period.ValidFrom.Format(time.DateOnly), period.ValidTo.Format(time.DateOnly), pricePeriodId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update price period")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM price_rules WHERE price_period_id = ?`, pricePeriodId); err != nil {
+		return errors.WithMessage(err, "Failed to delete price rules")
+	}
+	return insertPriceRulesTx(ctx, tx, pricePeriodId, period.Rules)
+}
+
+// DeletePricePeriod removes the price period with its rules
+func (db *Db) DeletePricePeriod(ctx context.Context, pricePeriodId int) error {
+	logCtx := slog.With("method", "DeletePricePeriod", "pricePeriodId", pricePeriodId)
+
+	query := `DELETE FROM price_periods WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, pricePeriodId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete price period")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get affected rows")
+	}
+	if affected == 0 {
+		return DbObjectNotFoundError{Message: "Price period not found"}
+	}
+	return nil
+}
+
+// validatePricePeriodTx locks the court group and checks the period against its other periods, so that concurrent
+// changes cannot create overlapping periods
+func validatePricePeriodTx(ctx context.Context, tx *sqlx.Tx, courtGroupId int, pricePeriodId int, period *pricing.Period) error {
+	var id int
+	err := tx.GetContext(ctx, &id, `SELECT id FROM court_groups WHERE id = ? FOR UPDATE`, courtGroupId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DbObjectNotFoundError{Message: "Court group not found"}
+		}
+		return errors.WithMessage(err, "Failed to lock court group")
+	}
+
+	var others []pricing.Period
+	rows, err := getPricePeriods(ctx, tx, "pp.court_group_id = ? AND pp.id <> ?", courtGroupId, pricePeriodId)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		from, _ := time.Parse(time.DateOnly, row.ValidFrom)
+		to, _ := time.Parse(time.DateOnly, row.ValidTo)
+		others = append(others, pricing.Period{ValidFrom: from, ValidTo: to})
+	}
+
+	if err := pricing.ValidatePeriod(*period, others); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	return nil
+}
+
+func insertPriceRulesTx(ctx context.Context, tx *sqlx.Tx, pricePeriodId int, rules []pricing.Rule) error {
+	for _, rule := range rules {
+		_, err := tx.ExecContext(ctx, `INSERT INTO price_rules (price_period_id, day_pattern, time_start, time_end, price) VALUES (?, ?, ?, ?, ?)`,
+			pricePeriodId, rule.DayPattern, pricing.FormatClock(rule.Start), pricing.FormatClock(rule.End), rule.Price)
+		if err != nil {
+			return errors.WithMessage(err, "Failed to create price rule")
+		}
+	}
+	return nil
+}
+
+// getPricePeriods returns the price periods matching the condition with their rules, ordered by court group and date
+func getPricePeriods(ctx context.Context, q sqlx.QueryerContext, cond string, args ...interface{}) ([]api.AdminPricePeriod, error) {
+	query := `SELECT pp.id, pp.court_group_id, pp.valid_from, pp.valid_to, pr.day_pattern,
+			TIME_TO_SEC(pr.time_start) DIV 60 AS time_start, TIME_TO_SEC(pr.time_end) DIV 60 AS time_end, pr.price
+		FROM price_periods pp
+		INNER JOIN court_groups cg ON cg.id = pp.court_group_id
+		LEFT JOIN price_rules pr ON pr.price_period_id = pp.id
+		WHERE ` + cond + `
+		ORDER BY pp.court_group_id, pp.valid_from, pp.id, pr.id`
+	var rows []pricePeriodRow
+	if err := sqlx.SelectContext(ctx, q, &rows, query, args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get price periods")
+	}
+
+	periods := []api.AdminPricePeriod{}
+	for _, row := range rows {
+		if len(periods) == 0 || periods[len(periods)-1].Id != row.Id {
+			periods = append(periods, api.AdminPricePeriod{
+				Id:           row.Id,
+				CourtGroupId: row.CourtGroupId,
+				ValidFrom:    row.ValidFrom.Format(time.DateOnly),
+				ValidTo:      row.ValidTo.Format(time.DateOnly),
+				Rules:        []api.AdminPriceRule{},
+			})
+		}
+		if !row.DayPattern.Valid {
+			continue
+		}
+		period := &periods[len(periods)-1]
+		period.Rules = append(period.Rules, api.AdminPriceRule{
+			DayPattern: normalizeDayPattern(row.DayPattern.String),
+			Start:      pricing.FormatClock(int(row.TimeStart.Int64)),
+			End:        pricing.FormatClock(int(row.TimeEnd.Int64)),
+			Price:      row.Price.Float64,
+		})
+	}
+	return periods, nil
+}
+
+func pointWkt(c api.Coordinates) string {
+	return fmt.Sprintf("POINT(%f %f)", c.Longitude, c.Latitude)
+}
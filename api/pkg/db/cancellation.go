@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// cancellableEventStatuses are the statuses an event can be cancelled from, the other ones are final
+var cancellableEventStatuses = []api.EventStatus{
+	api.EventStatusOpen,
+	api.EventStatusAccepted,
+	api.EventStatusConfirmed,
+	api.EventStatusReservationFailed,
+}
+
+// CancelledEvent describes an event moved to CANCELLED
+type CancelledEvent struct {
+	EventId string
+	// JoinerIds are the users whose active join requests were cancelled with the event,
+	// rejected players and players who left before are not among them
+	JoinerIds []string
+	// Booking is the court booked for the confirmed event, the caller releases it at the reservation system
+	Booking *CourtBooking
+}
+
+// CancelEvents moves the user's events to CANCELLED keeping them with their chat history.
+// Waiting, accepted and waitlisted join requests of the events are cancelled as well.
+// Events that are already in a final status are skipped. Returns the cancelled events in the given order
+// with the courts booked for them.
+func (db *Db) CancelEvents(ctx context.Context, userId string, eventIds []string, reason string) ([]CancelledEvent, error) {
+	logCtx := slog.With("method", "CancelEvents", "userId", userId, "eventIds", eventIds)
+	logCtx.Debug("Cancelling events")
+
+	if len(eventIds) == 0 {
+		return nil, nil
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	cancelled, err := db.cancelEventsTx(ctx, logCtx, tx, userId, eventIds, reason)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+func (db *Db) cancelEventsTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, userId string, eventIds []string, reason string) ([]CancelledEvent, error) {
+	query, args, err := sqlx.In(`SELECT id FROM events WHERE id IN (?) AND user_id = ? AND status IN (?) FOR UPDATE`,
+		eventIds, userId, cancellableEventStatuses)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+
+	var cancelled []string
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if err := tx.SelectContext(ctx, &cancelled, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to lock events")
+	}
+
+	if len(cancelled) == 0 {
+		return nil, nil
+	}
+
+	var cancelReason *string
+	if reason != "" {
+		cancelReason = &reason
+	}
+
+	query, args, err = sqlx.In(`UPDATE events SET status = ?, cancel_reason = ?, cancelled_at = ? WHERE id IN (?)`,
+		api.EventStatusCancelled, cancelReason, time.Now().UTC(), cancelled)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to cancel events")
+	}
+
+	query, args, err = sqlx.In(`SELECT event_id, user_id FROM join_requests WHERE event_id IN (?) AND status IN (?) ORDER BY created_at`,
+		cancelled, activeJoinRequestStatuses)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	var joiners []struct {
+		EventId string `db:"event_id"`
+		UserId  string `db:"user_id"`
+	}
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if err := tx.SelectContext(ctx, &joiners, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get joiners of cancelled events")
+	}
+
+	bookings, err := db.courtBookingsTx(ctx, logCtx, tx, cancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(`UPDATE join_requests SET is_accepted = false, status = ? WHERE event_id IN (?) AND status IN (?)`,
+		api.JoinRequestStatusCancelled, cancelled, activeJoinRequestStatuses)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to cancel join requests")
+	}
+
+	logCtx.Debug("Events cancelled", "cancelled", cancelled)
+
+	result := make([]CancelledEvent, 0, len(cancelled))
+	for _, eventId := range eventIds {
+		if !slices.Contains(cancelled, eventId) {
+			continue
+		}
+		event := CancelledEvent{EventId: eventId, Booking: bookings[eventId]}
+		for _, joiner := range joiners {
+			if joiner.EventId == eventId {
+				event.JoinerIds = append(event.JoinerIds, joiner.UserId)
+			}
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
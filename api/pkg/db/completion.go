@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// MarkCompletedEvents moves confirmed events whose session is over to COMPLETED and opens
+// their post-match window for postMatchWindow. Returns the completed events, at most limit of them.
+func (db *Db) MarkCompletedEvents(ctx context.Context, limit int, postMatchWindow time.Duration) ([]CompletedEventInfo, error) {
+	logCtx := slog.With("method", "MarkCompletedEvents")
+	logCtx.Debug("Marking completed events", "limit", limit)
+
+	now := time.Now().UTC()
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		logCtx.Error("Failed to begin transaction", "error", err)
+		return nil, err
+	}
+
+	// Rows are locked so that an event cancelled concurrently is not completed
+	selectQuery := `
+		SELECT e.id, e.user_id
+		FROM events e
+		INNER JOIN confirmations c ON e.id = c.event_id
+		WHERE e.status = ?
+		  AND c.dt + INTERVAL e.session_duration MINUTE < ?
+		LIMIT ?
+		FOR UPDATE
+	`
+
+	var completedEvents []CompletedEventInfo
+	err = tx.SelectContext(ctx, &completedEvents, selectQuery, api.EventStatusConfirmed, now, limit)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		logCtx.Error("Failed to select completed events", "error", err)
+		return nil, err
+	}
+
+	if len(completedEvents) == 0 {
+		db.rollback(logCtx, tx)
+		return nil, nil
+	}
+
+	postMatchUntil := now.Add(postMatchWindow)
+	eventIds := make([]string, len(completedEvents))
+	for i := range completedEvents {
+		eventIds[i] = completedEvents[i].EventId
+		completedEvents[i].PostMatchUntil = postMatchUntil
+	}
+
+	updateQuery, args, err := sqlx.In(
+		`UPDATE events SET status = ?, completed_at = ?, post_match_until = ? WHERE id IN (?)`,
+		api.EventStatusCompleted, now, postMatchUntil, eventIds)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		logCtx.Error("Failed to prepare update query", "error", err)
+		return nil, err
+	}
+	updateQuery = tx.Rebind(updateQuery)
+
+	_, err = tx.ExecContext(ctx, updateQuery, args...)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		logCtx.Error("Failed to update completed events", "error", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	logCtx.Debug("Marked events as completed", "count", len(completedEvents))
+	return completedEvents, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// SetJoinRequestPaid records whether the accepted player of the confirmed event paid their share of the court cost
+// to the host. Marking a player as paid again keeps the time of the first payment.
+func (db *Db) SetJoinRequestPaid(ctx context.Context, userId string, eventId string, joinRequestId string, paid bool) error {
+	logCtx := slog.With("method", "SetJoinRequestPaid", "userId", userId, "eventId", eventId, "joinRequestId", joinRequestId)
+	logCtx.Debug("Setting join request payment")
+
+	var row struct {
+		EventStatus string `db:"event_status"`
+		Status      string `db:"status"`
+	}
+	query := `SELECT e.status AS event_status, jr.status FROM join_requests jr
+		JOIN events e ON e.id = jr.event_id
+		WHERE jr.id = ? AND jr.event_id = ? AND e.user_id = ?`
+	if err := db.conn.GetContext(ctx, &row, query, joinRequestId, eventId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DbObjectNotFoundError{Message: "Join request not found"}
+		}
+		return errors.WithMessage(err, "Failed to get join request")
+	}
+
+	eventStatus := api.EventStatus(row.EventStatus)
+	if eventStatus != api.EventStatusConfirmed && eventStatus != api.EventStatusCompleted {
+		return &ValidationError{Message: "Only players of confirmed events can pay for the court"}
+	}
+	if api.JoinRequestStatus(row.Status) != api.JoinRequestStatusAccepted {
+		return &ValidationError{Message: "Only accepted players can pay for the court"}
+	}
+
+	query = `UPDATE join_requests SET paid_at = IF(?, COALESCE(paid_at, CURRENT_TIMESTAMP), NULL) WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := db.conn.ExecContext(ctx, query, paid, joinRequestId); err != nil {
+		return errors.WithMessage(err, "Failed to update join request payment")
+	}
+
+	return nil
+}
+
+// splitCourtCost splits the court price between the host and the accepted players. Shares are rounded up to whole
+// cents so the host is never short.
+func splitCourtCost(total float64, acceptedPlayers int) *api.CourtCost {
+	players := acceptedPlayers + 1
+	return &api.CourtCost{
+		Total:     total,
+		Players:   players,
+		PerPlayer: math.Ceil(math.Round(total*100)/float64(players)) / 100,
+	}
+}
+
+func acceptedPlayers(joinRequests []*api.JoinRequest) int {
+	accepted := 0
+	for _, jr := range joinRequests {
+		if jr.Status == api.JoinRequestStatusAccepted {
+			accepted++
+		}
+	}
+	return accepted
+}
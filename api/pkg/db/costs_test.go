@@ -0,0 +1,24 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestSplitCourtCost(t *testing.T) {
+	assert.Equal(t, &api.CourtCost{Total: 120, Players: 4, PerPlayer: 30}, splitCourtCost(120, 3))
+	assert.Equal(t, &api.CourtCost{Total: 100, Players: 3, PerPlayer: 33.34}, splitCourtCost(100, 2))
+	assert.Equal(t, &api.CourtCost{Total: 70, Players: 1, PerPlayer: 70}, splitCourtCost(70, 0))
+}
+
+func TestAcceptedPlayers(t *testing.T) {
+	joinRequests := []*api.JoinRequest{
+		{Status: api.JoinRequestStatusAccepted},
+		{Status: api.JoinRequestStatusWaitlisted},
+		{Status: api.JoinRequestStatusCancelled},
+		{Status: api.JoinRequestStatusAccepted},
+	}
+	assert.Equal(t, 2, acceptedPlayers(joinRequests))
+}
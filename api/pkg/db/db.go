@@ -67,23 +67,38 @@ func (db *Db) Ping(ctx context.Context) error {
 	return err
 }
 
-// GetAllFacilities retrieves all facilities from the database
-func (db *Db) GetAllFacilities(ctx context.Context) ([]api.Location, error) {
+// GetAllFacilities retrieves all active facilities matching the filter from the database, the nearest first
+// when the filter has a center
+func (db *Db) GetAllFacilities(ctx context.Context, filter *FacilitiesFilter) ([]api.Location, error) {
 	logCtx := slog.With("method", "GetAllFacilities")
 	logCtx.Debug("Retrieving all facilities")
 
-	query := `SELECT
-		id,
-		name,
-		address,
-		ST_Y(location) as 'coordinates.latitude',
-		ST_X(location) as 'coordinates.longitude'
-	FROM xtp_tour.facilities
-	WHERE status = 'active'`
+	columns := `f.id,
+		f.name,
+		f.address,
+		ST_Y(f.location) as 'coordinates.latitude',
+		ST_X(f.location) as 'coordinates.longitude'`
+	args := []interface{}{}
+	orderBy := ""
+	if filter.Near != nil {
+		columns += `,
+		ROUND(ST_Distance_Sphere(f.location, ST_GeomFromText(?)) / 1000, 2) as distance_km`
+		args = append(args, filter.Near.CenterWkt())
+		orderBy = " ORDER BY distance_km, f.name"
+	}
+
+	where, whereArgs := buildFacilitiesWhere(filter)
+	query, args, err := sqlx.In(`SELECT `+columns+`
+	FROM xtp_tour.facilities f
+	WHERE `+where+orderBy, append(args, whereArgs...)...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare facilities query")
+	}
+	query = db.conn.Rebind(query)
 
 	logCtx.Debug("Executing SQL query", "query", query)
 	var locations []api.Location
-	err := db.conn.SelectContext(ctx, &locations, query)
+	err = db.conn.SelectContext(ctx, &locations, query, args...)
 	if err != nil {
 		logCtx.Error("Failed to get facilities from database", "error", err, "query", query)
 		return nil, err
@@ -92,8 +107,21 @@ func (db *Db) GetAllFacilities(ctx context.Context) ([]api.Location, error) {
 	return locations, nil
 }
 
+// DefaultExpirationTime returns the expiration time of an event with the given time slots: 4 hours before the earliest one
+func DefaultExpirationTime(timeSlots []string) time.Time {
+	var earliestTime time.Time
+	for i, timeSlot := range timeSlots {
+		dt := api.ParseDt(timeSlot)
+		if i == 0 || dt.Before(earliestTime) {
+			earliestTime = dt
+		}
+	}
+
+	return earliestTime.Add(-4 * time.Hour)
+}
+
 func (db *Db) CreateEvent(ctx context.Context, event *api.EventData) error {
-	logCtx := slog.With("method", "CreateEvent", "userId", event.UserId, "eventId", event.Id)
+	logCtx := slog.With("method", "CreateEvent", "userId", event.UserId)
 	logCtx.Debug("Creating event")
 
 	tx, err := db.conn.BeginTxx(ctx, nil)
@@ -101,20 +129,27 @@ func (db *Db) CreateEvent(ctx context.Context, event *api.EventData) error {
 		slog.Error("Failed to begin transaction", "error", err)
 		return err
 	}
-	event.Id = uuid.New().String()
 
-	// Find the earliest time slot
-	var earliestTime time.Time
-	for i, timeSlot := range event.TimeSlots {
-		dt := api.ParseDt(timeSlot)
-		if i == 0 || dt.Before(earliestTime) {
-			earliestTime = dt
-		}
+	if err := db.insertEvent(ctx, logCtx, tx, event, nil, nil); err != nil {
+		db.rollback(logCtx, tx)
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return err
 	}
+	return nil
+}
 
-	// Set expiration time to 4 hours before the earliest time slot
-	expirationTime := earliestTime.Add(-4 * time.Hour)
-	event.ExpirationTime = api.DtToIso(expirationTime)
+// insertEvent inserts the event with its locations and time slots, and sets its id and expiration time.
+// seriesId and seriesIndex are nil for events which are not part of a series.
+func (db *Db) insertEvent(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, event *api.EventData, seriesId *string, seriesIndex *int) error {
+	event.Id = uuid.New().String()
+	logCtx = logCtx.With("eventId", event.Id)
+
+	event.ExpirationTime = api.DtToIso(DefaultExpirationTime(event.TimeSlots))
 
 	// Create EventRow from api.EventData
 	eventRow := EventRow{
@@ -123,20 +158,22 @@ func (db *Db) CreateEvent(ctx context.Context, event *api.EventData) error {
 		SkillLevel:      string(event.SkillLevel),
 		Description:     event.Description,
 		EventType:       string(event.EventType),
+		Format:          string(event.Format),
 		ExpectedPlayers: event.ExpectedPlayers,
 		SessionDuration: event.SessionDuration,
 		Visibility:      string(event.Visibility),
 		ExpirationTime:  api.ParseDt(event.ExpirationTime),
 		Status:          string(api.EventStatusOpen),
 		CreatedAt:       time.Now(),
+		SeriesId:        seriesId,
+		SeriesIndex:     seriesIndex,
 	}
 
-	query := `INSERT INTO events (id, user_id, skill_level, description, event_type, expected_players, session_duration, visibility, expiration_time, status, created_at)
-		VALUES (:id, :user_id, :skill_level, :description, :event_type, :expected_players, :session_duration, :visibility, :expiration_time, :status, :created_at)`
+	query := `INSERT INTO events (id, user_id, skill_level, description, event_type, format, expected_players, session_duration, visibility, expiration_time, status, created_at, series_id, series_index)
+		VALUES (:id, :user_id, :skill_level, :description, :event_type, :format, :expected_players, :session_duration, :visibility, :expiration_time, :status, :created_at, :series_id, :series_index)`
 	slog.Debug("Executing SQL query", "query", query, "params", eventRow)
-	_, err = tx.NamedExecContext(ctx, query, eventRow)
+	_, err := tx.NamedExecContext(ctx, query, eventRow)
 	if err != nil {
-		db.rollback(logCtx, tx)
 		logCtx.Error("Failed to insert event", "error", err)
 		return err
 	}
@@ -153,7 +190,6 @@ func (db *Db) CreateEvent(ctx context.Context, event *api.EventData) error {
 	slog.Debug("Executing SQL query", "query", query, "params", locations)
 	_, err = tx.NamedExecContext(ctx, query, locations)
 	if err != nil {
-		db.rollback(logCtx, tx)
 		logCtx.Error("Failed to insert event locations", "error", err)
 		return err
 	}
@@ -170,16 +206,10 @@ func (db *Db) CreateEvent(ctx context.Context, event *api.EventData) error {
 	slog.Debug("Executing SQL query", "query", query, "params", timeSlots)
 	_, err = tx.NamedExecContext(ctx, query, timeSlots)
 	if err != nil {
-		db.rollback(logCtx, tx)
 		logCtx.Error("Failed to insert event time slot", "error", err)
 		return err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		logCtx.Error("Failed to commit transaction", "error", err)
-		return err
-	}
 	return nil
 }
 
@@ -191,13 +221,6 @@ func (db *Db) GetEventsOfUser(ctx context.Context, userId string) ([]*api.Event,
 	return db.getEventsInternal(ctx, "user_id = ?", userId)
 }
 
-func (db *Db) GetPublicEvents(ctx context.Context, userId string) ([]*api.Event, error) {
-	logCtx := slog.With("method", "GetPublicEvents", "userId", userId)
-	logCtx.Debug("Getting public events")
-
-	return db.getEventsInternal(ctx, "user_id <> ? AND visibility = ? AND status = ? AND expiration_time > ?", userId, api.EventVisibilityPublic, api.EventStatusOpen, time.Now().UTC())
-}
-
 func (db *Db) GetJoinedEvents(ctx context.Context, userId string) ([]*api.Event, error) {
 	logCtx := slog.With("method", "GetJoinedEvents", "userId", userId)
 	logCtx.Debug("Getting joined events for user")
@@ -244,23 +267,36 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 				e.skill_level,
 				e.description,
 				e.event_type,
+				e.format,
 				e.expected_players,
 				e.session_duration,
 				e.visibility,
 				e.status,
 				e.created_at,
 				e.expiration_time,
+				e.series_id,
+				e.series_index,
+				e.cancel_reason,
+				e.cancelled_at,
+				e.completed_at,
+				e.post_match_until,
 				GROUP_CONCAT(DISTINCT el.location_id) as locations,
 				GROUP_CONCAT(DISTINCT ets.dt) as time_slots,
 				c.location_id as confirmed_location,
-				c.dt as confirmed_dt
+				c.dt as confirmed_dt,
+				c.version as confirmed_version,
+				c.reservation_status,
+				c.court_number,
+				c.court_price
 			FROM events e
 			LEFT JOIN event_locations el ON e.id = el.event_id
 			LEFT JOIN event_time_slots ets ON e.id = ets.event_id
 			LEFT JOIN confirmations c ON e.id = c.event_id
-			GROUP BY e.id, e.user_id, e.skill_level, e.description, e.event_type,
+			GROUP BY e.id, e.user_id, e.skill_level, e.description, e.event_type, e.format,
 				e.expected_players, e.session_duration, e.visibility, e.status, e.created_at,
-				e.expiration_time, c.location_id, c.dt
+				e.expiration_time, e.series_id, e.series_index, e.cancel_reason, e.cancelled_at,
+				e.completed_at, e.post_match_until, c.location_id, c.dt, c.version,
+				c.reservation_status, c.court_number, c.court_price
 		)
 		SELECT * FROM event_data
 	`
@@ -304,23 +340,36 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 			skillLevel      string
 			description     string
 			eventType       string
+			format          string
 			expectedPlayers int
 			sessionDuration int
 			visibility      string
 			status          string
 			createdAt       time.Time
 			expirationTime  time.Time
+			seriesId        sql.NullString
+			seriesIndex     sql.NullInt32
+			cancelReason    sql.NullString
+			cancelledAt     sql.NullTime
+			completedAt     sql.NullTime
+			postMatchUntil  sql.NullTime
 			locationsStr    sql.NullString
 			timeSlotsStr    sql.NullString
 			confirmedLoc    sql.NullString
 			confirmedDt     sql.NullTime
+			confirmedVer    sql.NullInt32
+			reservation     sql.NullString
+			courtNumber     sql.NullString
+			courtPrice      sql.NullFloat64
 		)
 
 		err := rows.Scan(
-			&eventId, &userId, &skillLevel, &description, &eventType,
+			&eventId, &userId, &skillLevel, &description, &eventType, &format,
 			&expectedPlayers, &sessionDuration, &visibility, &status,
-			&createdAt, &expirationTime, &locationsStr, &timeSlotsStr,
-			&confirmedLoc, &confirmedDt,
+			&createdAt, &expirationTime, &seriesId, &seriesIndex, &cancelReason, &cancelledAt,
+			&completedAt, &postMatchUntil, &locationsStr, &timeSlotsStr,
+			&confirmedLoc, &confirmedDt, &confirmedVer,
+			&reservation, &courtNumber, &courtPrice,
 		)
 		if err != nil {
 			logCtx.Error("Failed to scan event row", "error", err)
@@ -352,7 +401,14 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 				EventId:    eventId,
 				LocationId: confirmedLoc.String,
 				Datetime:   api.DtToIso(confirmedDt.Time),
+				Version:    int(confirmedVer.Int32),
 				CreatedAt:  api.DtToIso(time.Now()),
+
+				ReservationStatus: api.ReservationStatus(reservation.String),
+				CourtNumber:       courtNumber.String,
+			}
+			if courtPrice.Valid {
+				confirmation.CourtCost = &api.CourtCost{Total: courtPrice.Float64}
 			}
 		}
 
@@ -364,6 +420,7 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 				SkillLevel:      api.SkillLevel(skillLevel),
 				Description:     description,
 				EventType:       api.EventType(eventType),
+				Format:          api.SingleDoubleType(format),
 				ExpectedPlayers: expectedPlayers,
 				SessionDuration: sessionDuration,
 				TimeSlots:       api.DtToIsoArray(timeSlots),
@@ -373,6 +430,18 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 			Status:       api.EventStatus(status),
 			CreatedAt:    api.DtToIso(createdAt),
 			Confirmation: confirmation,
+			SeriesId:     seriesId.String,
+			SeriesIndex:  int(seriesIndex.Int32),
+			CancelReason: cancelReason.String,
+		}
+		if cancelledAt.Valid {
+			event.CancelledAt = api.DtToIso(cancelledAt.Time)
+		}
+		if completedAt.Valid {
+			event.CompletedAt = api.DtToIso(completedAt.Time)
+		}
+		if postMatchUntil.Valid {
+			event.PostMatchUntil = api.DtToIso(postMatchUntil.Time)
 		}
 
 		eventMap[eventId] = event
@@ -388,8 +457,30 @@ func (db *Db) getEventsInternal(ctx context.Context, filter string, filterVals .
 		return nil, err
 	}
 
+	results, err := db.GetMatchResults(ctx, eventIds...)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := db.GetEventTeams(ctx, eventIds...)
+	if err != nil {
+		return nil, err
+	}
+
 	for k, v := range eventMap {
 		v.JoinRequests = joinRequests[k]
+		v.Result = results[k]
+		v.Teams = teams[k]
+		if v.Confirmation != nil {
+			v.Confirmation.AwaitingAcknowledgement = awaitingAcknowledgement(v.Confirmation, v.JoinRequests)
+			if v.Confirmation.CourtCost != nil {
+				v.Confirmation.CourtCost = splitCourtCost(v.Confirmation.CourtCost.Total, acceptedPlayers(v.JoinRequests))
+			}
+		}
+	}
+
+	if err := db.setPartnerCardLocations(ctx, eventMap); err != nil {
+		return nil, err
 	}
 
 	events := make([]*api.Event, 0, len(eventMap))
@@ -505,25 +596,32 @@ func (db *Db) DeleteEvent(ctx context.Context, userId string, eventId string) er
 	return nil
 }
 
-func (db *Db) CreateJoinRequest(ctx context.Context, eventId string, userId string, req *api.JoinRequestData) (joinRequestId string, err error) {
+// CreateJoinRequest adds the user's join request to the event, the request is waitlisted when the event is full
+func (db *Db) CreateJoinRequest(ctx context.Context, eventId string, userId string, req *api.JoinRequestData) (joinRequestId string, status api.JoinRequestStatus, err error) {
 	logCtx := slog.With("method", "CreateJoinRequest", "eventId", eventId, "userId", userId)
 	logCtx.Debug("Creating join request")
 
 	tx, err := db.conn.BeginTxx(ctx, nil)
 	if err != nil {
 		logCtx.Error("Failed to begin transaction", "error", err)
-		return "", err
+		return "", "", err
+	}
+
+	status, err = db.newJoinRequestStatusTx(ctx, tx, eventId)
+	if err != nil {
+		_ = tx.Rollback()
+		return "", "", err
 	}
 
 	// Create join request
 	joinRequestId = uuid.New().String()
-	query := `INSERT INTO join_requests (id, event_id, user_id, comment) VALUES (?, ?, ?, ?)`
-	logCtx.Debug("Executing SQL query", "query", query, "params", []interface{}{joinRequestId, eventId, userId, req.Comment})
-	_, err = tx.ExecContext(ctx, query, joinRequestId, eventId, userId, req.Comment)
+	query := `INSERT INTO join_requests (id, event_id, user_id, comment, status) VALUES (?, ?, ?, ?, ?)`
+	logCtx.Debug("Executing SQL query", "query", query, "params", []interface{}{joinRequestId, eventId, userId, req.Comment, status})
+	_, err = tx.ExecContext(ctx, query, joinRequestId, eventId, userId, req.Comment, status)
 	if err != nil {
 		_ = tx.Rollback()
 		logCtx.Error("Failed to insert join request", "error", err)
-		return "", err
+		return "", "", err
 	}
 
 	// Insert locations
@@ -535,7 +633,7 @@ func (db *Db) CreateJoinRequest(ctx context.Context, eventId string, userId stri
 			if err != nil {
 				_ = tx.Rollback()
 				logCtx.Error("Failed to insert join request location", "error", err)
-				return "", err
+				return "", "", err
 			}
 		}
 	}
@@ -549,7 +647,7 @@ func (db *Db) CreateJoinRequest(ctx context.Context, eventId string, userId stri
 			if err != nil {
 				_ = tx.Rollback()
 				logCtx.Error("Failed to insert join request time slot", "error", err)
-				return "", err
+				return "", "", err
 			}
 		}
 	}
@@ -557,10 +655,10 @@ func (db *Db) CreateJoinRequest(ctx context.Context, eventId string, userId stri
 	err = tx.Commit()
 	if err != nil {
 		logCtx.Error("Failed to commit transaction", "error", err)
-		return "", err
+		return "", "", err
 	}
 
-	return joinRequestId, nil
+	return joinRequestId, status, nil
 }
 
 type ValidationError struct {
@@ -571,7 +669,7 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
-func (db *Db) ConfirmEvent(ctx context.Context, userId string, eventId string, req *api.EventConfirmationRequest) (*api.Confirmation, error) {
+func (db *Db) ConfirmEvent(ctx context.Context, userId string, eventId string, req *api.EventConfirmationRequest, courtPrice *float64) (*api.Confirmation, error) {
 	logCtx := slog.With("method", "ConfirmEvent", "userId", userId, "eventId", eventId)
 	logCtx.Debug("Confirming event")
 
@@ -590,36 +688,62 @@ func (db *Db) ConfirmEvent(ctx context.Context, userId string, eventId string, r
 
 	// Create confirmation
 	confirmationId := uuid.New().String()
-	query := `INSERT INTO confirmations (id, event_id, location_id, dt) VALUES (?, ?, ?, ?)`
-	_, err = tx.ExecContext(ctx, query, confirmationId, eventId, req.LocationId, api.ParseDt(req.DateTime))
+	query := `INSERT INTO confirmations (id, event_id, location_id, dt, court_price) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, query, confirmationId, eventId, req.LocationId, api.ParseDt(req.DateTime), courtPrice)
 	if err != nil {
 		db.rollback(logCtx, tx)
 		return nil, errors.WithMessage(err, "Failed to create confirmation")
 	}
 
+	// Only join requests of the event which were not rejected or cancelled can be confirmed
+	query, args, err := sqlx.In(`SELECT COUNT(*) FROM join_requests WHERE id IN (?) AND event_id = ? AND status IN (?)`,
+		req.JoinRequestsIds, eventId, confirmableJoinRequestStatuses)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	var found int
+	if err = tx.GetContext(ctx, &found, tx.Rebind(query), args...); err != nil {
+		db.rollback(logCtx, tx)
+		return nil, errors.WithMessage(err, "Failed to count join requests")
+	}
+	if found != len(req.JoinRequestsIds) {
+		db.rollback(logCtx, tx)
+		return nil, &ValidationError{Message: "Some join requests were not found for this event"}
+	}
+
 	// Update join requests - also filter by event_id for safety
-	query = `UPDATE join_requests SET is_accepted = true WHERE id in (?) AND event_id = ?`
-	query, args, err := sqlx.In(query, req.JoinRequestsIds, eventId)
+	query = `UPDATE join_requests SET is_accepted = true, status = ? WHERE id in (?) AND event_id = ?`
+	query, args, err = sqlx.In(query, api.JoinRequestStatusAccepted, req.JoinRequestsIds, eventId)
 	if err != nil {
 		db.rollback(logCtx, tx)
 		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
 	}
 	query = db.conn.Rebind(query)
 	logCtx.Debug("Executing SQL query", "query", query, "params", args)
-	result, err := tx.ExecContext(ctx, query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		db.rollback(logCtx, tx)
 		return nil, errors.WithMessage(err, "Failed to update join requests")
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	// Joiners who were not selected stay on the waitlist in case an accepted player cancels
+	query, args, err = sqlx.In(`UPDATE join_requests SET is_accepted = NULL, status = ? WHERE event_id = ? AND status IN (?) AND id NOT IN (?)`,
+		api.JoinRequestStatusWaitlisted, eventId,
+		[]api.JoinRequestStatus{api.JoinRequestStatusWaiting, api.JoinRequestStatusAccepted}, req.JoinRequestsIds)
 	if err != nil {
 		db.rollback(logCtx, tx)
-		return nil, errors.WithMessage(err, "Failed to get rows affected")
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, errors.WithMessage(err, "Failed to waitlist join requests")
 	}
-	if int(rowsAffected) != len(req.JoinRequestsIds) {
+
+	if err = db.insertEventTeamsTx(ctx, logCtx, tx, eventId, req.Teams); err != nil {
 		db.rollback(logCtx, tx)
-		return nil, &ValidationError{Message: "Some join requests were not found for this event"}
+		return nil, err
 	}
 
 	// Update event status
@@ -634,7 +758,13 @@ func (db *Db) ConfirmEvent(ctx context.Context, userId string, eventId string, r
 		EventId:    eventId,
 		LocationId: req.LocationId,
 		Datetime:   req.DateTime,
+		Version:    1,
 		CreatedAt:  api.DtToIso(time.Now()),
+
+		ReservationStatus: api.ReservationStatusNotRequired,
+	}
+	if courtPrice != nil {
+		confirmation.CourtCost = splitCourtCost(*courtPrice, len(req.JoinRequestsIds))
 	}
 
 	return confirmation, nil
@@ -657,31 +787,6 @@ func (db *Db) GetEventOwner(ctx context.Context, eventId string) (string, error)
 	return userId, nil
 }
 
-func (db *Db) DeleteJoinRequest(ctx context.Context, userId string, joinRequestId string) error {
-	logCtx := slog.With("method", "DeleteJoinRequest", "userId", userId, "joinRequestId", joinRequestId)
-	logCtx.Debug("Deleting join request")
-
-	query := `DELETE FROM join_requests WHERE id = ? AND user_id = ?`
-	logCtx.Debug("Executing SQL query", "query", query, "params", []interface{}{joinRequestId, userId})
-	result, err := db.conn.ExecContext(ctx, query, joinRequestId, userId)
-	if err != nil {
-		logCtx.Error("Failed to delete join request", "error", err)
-		return errors.WithMessage(err, "Failed to delete join request")
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		logCtx.Error("Failed to get rows affected", "error", err)
-		return errors.WithMessage(err, "Failed to get rows affected")
-	}
-
-	if rowsAffected == 0 {
-		return DbObjectNotFoundError{Message: "Join request not found"}
-	}
-
-	return nil
-}
-
 // joinRequestBaseQuery is the common base SQL query for join request operations
 const joinRequestBaseQuery = `
 	SELECT
@@ -691,9 +796,13 @@ const joinRequestBaseQuery = `
 		jr.comment,
 		jr.created_at,
 		jr.is_accepted,
+		jr.status,
+		jr.host_message,
 		GROUP_CONCAT(DISTINCT jrl.location_id) as locations,
 		GROUP_CONCAT(DISTINCT jrts.dt) as time_slots,
-		jr.confirmation_id
+		jr.confirmation_id,
+		jr.acknowledged_version,
+		jr.paid_at
 	FROM join_requests jr
 	LEFT JOIN join_request_locations jrl ON jr.id = jrl.join_request_id
 	LEFT JOIN join_request_time_slots jrts ON jr.id = jrts.join_request_id`
@@ -704,7 +813,7 @@ func (db *Db) GetJoinRequest(ctx context.Context, joinRequestId string) (*api.Jo
 
 	query := joinRequestBaseQuery + `
 		WHERE jr.id = ?
-		GROUP BY jr.id, jr.event_id, jr.user_id, jr.comment, jr.created_at, jr.is_accepted`
+		GROUP BY jr.id, jr.event_id, jr.user_id, jr.comment, jr.created_at, jr.is_accepted, jr.status, jr.host_message`
 
 	logCtx.Debug("Executing SQL query", "query", query, "params", joinRequestId)
 
@@ -743,7 +852,7 @@ func (db *Db) GetJoinRequests(ctx context.Context, eventIds ...string) (map[stri
 	query, args, err := sqlx.In(
 		joinRequestBaseQuery+`
 		WHERE jr.event_id IN (?)
-		GROUP BY jr.id, jr.event_id, jr.user_id, jr.comment, jr.created_at, jr.is_accepted
+		GROUP BY jr.id, jr.event_id, jr.user_id, jr.comment, jr.created_at, jr.is_accepted, jr.status, jr.host_message
 		ORDER BY jr.created_at`,
 		eventIds,
 	)
@@ -861,12 +970,16 @@ func scanJoinRequest(rows *sql.Rows) (*api.JoinRequest, error) {
 		comment        string
 		createdAt      time.Time
 		isAccepted     sql.NullBool
+		status         string
+		hostMessage    sql.NullString
 		locations      sql.NullString
 		timeSlots      sql.NullString
 		confirmationId sql.NullString
+		acknowledged   sql.NullInt32
+		paidAt         sql.NullTime
 	)
 
-	err := rows.Scan(&id, &eventID, &userID, &comment, &createdAt, &isAccepted, &locations, &timeSlots, &confirmationId)
+	err := rows.Scan(&id, &eventID, &userID, &comment, &createdAt, &isAccepted, &status, &hostMessage, &locations, &timeSlots, &confirmationId, &acknowledged, &paidAt)
 	if err != nil {
 		return nil, err
 	}
@@ -877,14 +990,20 @@ func scanJoinRequest(rows *sql.Rows) (*api.JoinRequest, error) {
 			Comment: comment,
 			EventId: eventID,
 		},
-		UserId:    userID,
-		CreatedAt: api.DtToIso(createdAt),
+		UserId:              userID,
+		CreatedAt:           api.DtToIso(createdAt),
+		Status:              api.JoinRequestStatus(status),
+		HostMessage:         hostMessage.String,
+		AcknowledgedVersion: int(acknowledged.Int32),
 	}
 
 	if isAccepted.Valid {
 		isRejected := !isAccepted.Bool
 		joinRequest.IsRejected = &isRejected
 	}
+	if paidAt.Valid {
+		joinRequest.PaidAt = api.DtToIso(paidAt.Time)
+	}
 
 	// Parse locations
 	if locations.Valid {
@@ -946,6 +1065,11 @@ func (db *Db) GetUserProfile(ctx context.Context, userId string) (*api.UserProfi
 		DebugAddress: dbNotificationSettings.DebugAddress,
 		Channels:     dbNotificationSettings.Channels,
 	}
+
+	profile.PartnerCards, err = db.getUserPartnerCards(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
 	return &profile, nil
 }
 
@@ -984,6 +1108,11 @@ func (db *Db) CreateUserProfile(ctx context.Context, userId string, profile *api
 		return "", nil, errors.WithMessage(err, "Failed to create user profile")
 	}
 
+	if err := db.setUserPartnerCardsTx(ctx, tx, logCtx, userId, profile.PartnerCards); err != nil {
+		db.rollback(logCtx, tx)
+		return "", nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		db.rollback(logCtx, tx)
@@ -992,12 +1121,13 @@ func (db *Db) CreateUserProfile(ctx context.Context, userId string, profile *api
 
 	// Set the userId and return the profile
 	return userId, &api.UserProfileData{
-		FirstName: profile.FirstName,
-		LastName:  profile.LastName,
-		NTRPLevel: profile.NTRPLevel,
-		Language:  profile.Language,
-		Country:   profile.Country,
-		City:      profile.City,
+		FirstName:    profile.FirstName,
+		LastName:     profile.LastName,
+		NTRPLevel:    profile.NTRPLevel,
+		Language:     profile.Language,
+		Country:      profile.Country,
+		City:         profile.City,
+		PartnerCards: compactIds(profile.PartnerCards),
 	}, nil
 }
 
@@ -1045,6 +1175,14 @@ func (db *Db) UpdateUserProfile(ctx context.Context, userId string, profile *api
 		return nil, errors.WithMessage(err, "Failed to update user profile")
 	}
 
+	// clients unaware of partner cards do not send them, the cards are kept then
+	if profile.PartnerCards != nil {
+		if err := db.setUserPartnerCardsTx(ctx, tx, logCtx, userId, profile.PartnerCards); err != nil {
+			db.rollback(logCtx, tx)
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		db.rollback(logCtx, tx)
@@ -1058,6 +1196,11 @@ func (db *Db) UpdateUserProfile(ctx context.Context, userId string, profile *api
 		profile.Role = role
 	}
 
+	profile.PartnerCards, err = db.getUserPartnerCards(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
 	return profile, nil
 }
 
@@ -1329,7 +1472,7 @@ func (db *Db) MarkExpiredEvents(ctx context.Context, limit int) ([]ExpiredEventI
 	logCtx.Debug("Marking expired events", "limit", limit)
 
 	selectQuery := `
-		SELECT e.id, e.user_id
+		SELECT e.id, e.user_id, COALESCE(e.series_id, '') AS series_id
 		FROM events e
 		LEFT JOIN join_requests jr ON e.id = jr.event_id
 		WHERE e.status = ?
@@ -1438,20 +1581,20 @@ type FacilityLookupResult struct {
 	Status string `db:"status"`
 }
 
-// GetFacilityByGooglePlaceID checks if a facility with the given Google Place ID already exists
-func (db *Db) GetFacilityByGooglePlaceID(ctx context.Context, googlePlaceID string) (*FacilityLookupResult, error) {
-	logCtx := slog.With("method", "GetFacilityByGooglePlaceID", "googlePlaceID", googlePlaceID)
+// GetFacilityByPlaceID checks if a facility with the given place of the provider already exists
+func (db *Db) GetFacilityByPlaceID(ctx context.Context, provider, placeID string) (*FacilityLookupResult, error) {
+	logCtx := slog.With("method", "GetFacilityByPlaceID", "provider", provider, "placeID", placeID)
 
 	query := `SELECT id, name, address, ST_Y(location) as 'coordinates.latitude', ST_X(location) as 'coordinates.longitude', status
-		FROM facilities WHERE google_place_id = ?`
+		FROM facilities WHERE place_provider = ? AND place_id = ?`
 
 	var result FacilityLookupResult
-	err := db.conn.GetContext(ctx, &result, query, googlePlaceID)
+	err := db.conn.GetContext(ctx, &result, query, provider, placeID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		logCtx.Error("Failed to get facility by Google Place ID", "error", err)
+		logCtx.Error("Failed to get facility by place ID", "error", err)
 		return nil, err
 	}
 	return &result, nil
@@ -1463,15 +1606,15 @@ func (db *Db) ReactivateFacility(ctx context.Context, facilityID string) error {
 	return err
 }
 
-// CreateFacilityFromPlace inserts a new facility from a Google Places result
-func (db *Db) CreateFacilityFromPlace(ctx context.Context, id, name, address, country string, lat, lng float64, googlePlaceID, addedBy string) error {
-	logCtx := slog.With("method", "CreateFacilityFromPlace", "name", name, "googlePlaceID", googlePlaceID)
+// CreateFacilityFromPlace inserts a new facility from a place found by the provider
+func (db *Db) CreateFacilityFromPlace(ctx context.Context, id, name, address, country string, lat, lng float64, provider, placeID, addedBy string) error {
+	logCtx := slog.With("method", "CreateFacilityFromPlace", "name", name, "provider", provider, "placeID", placeID)
 
-	query := `INSERT INTO facilities (id, name, address, location, country, google_place_id, added_by, source, status)
-		VALUES (?, ?, ?, ST_GeomFromText(?), ?, ?, ?, 'user', 'active')`
+	query := `INSERT INTO facilities (id, name, address, location, country, place_provider, place_id, added_by, source, status)
+		VALUES (?, ?, ?, ST_GeomFromText(?), ?, ?, ?, ?, 'user', 'active')`
 
 	point := fmt.Sprintf("POINT(%f %f)", lng, lat)
-	_, err := db.conn.ExecContext(ctx, query, id, name, address, point, country, googlePlaceID, addedBy)
+	_, err := db.conn.ExecContext(ctx, query, id, name, address, point, country, provider, placeID, addedBy)
 	if err != nil {
 		logCtx.Error("Failed to create facility from place", "error", err)
 		return err
@@ -1493,7 +1636,8 @@ func (db *Db) GetAllFacilitiesAdmin(ctx context.Context) ([]api.AdminFacility, e
 		status,
 		source,
 		COALESCE(added_by, '') as added_by,
-		COALESCE(google_place_id, '') as google_place_id,
+		COALESCE(place_provider, '') as place_provider,
+		COALESCE(place_id, '') as place_id,
 		created_at
 	FROM facilities
 	ORDER BY created_at DESC`
@@ -1512,7 +1656,7 @@ func (db *Db) GetAllFacilitiesAdmin(ctx context.Context) ([]api.AdminFacility, e
 		err := rows.Scan(
 			&f.ID, &f.Name, &f.Address,
 			&f.Coordinates.Latitude, &f.Coordinates.Longitude,
-			&f.Status, &f.Source, &f.AddedBy, &f.GooglePlaceID,
+			&f.Status, &f.Source, &f.AddedBy, &f.PlaceProvider, &f.PlaceID,
 			&createdAt,
 		)
 		if err != nil {
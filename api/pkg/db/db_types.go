@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
@@ -33,12 +34,15 @@ type EventRow struct {
 	SkillLevel      string    `db:"skill_level"`
 	Description     string    `db:"description"`
 	EventType       string    `db:"event_type"`
+	Format          string    `db:"format"`
 	ExpectedPlayers int       `db:"expected_players"`
 	SessionDuration int       `db:"session_duration"` // in minutes
 	Visibility      string    `db:"visibility"`
 	Status          string    `db:"status"`
 	CreatedAt       time.Time `db:"created_at"`
 	ExpirationTime  time.Time `db:"expiration_time"`
+	SeriesId        *string   `db:"series_id"`
+	SeriesIndex     *int      `db:"series_index"`
 }
 
 type JoinRequestRow struct {
@@ -56,7 +60,11 @@ type ConfirmationRow struct {
 	EventId    string    `db:"event_id"`
 	LocationId string    `db:"location_id"`
 	Dt         time.Time `db:"dt"`
+	Version    int       `db:"version"`
 	CreatedAt  time.Time `db:"created_at"`
+
+	ReservationStatus string         `db:"reservation_status"`
+	CourtNumber       sql.NullString `db:"court_number"`
 }
 
 func (row *ConfirmationRow) ToApi() *api.Confirmation {
@@ -64,7 +72,11 @@ func (row *ConfirmationRow) ToApi() *api.Confirmation {
 		EventId:    row.EventId,
 		LocationId: row.LocationId,
 		Datetime:   api.DtToIso(row.Dt),
+		Version:    row.Version,
 		CreatedAt:  api.DtToIso(row.CreatedAt),
+
+		ReservationStatus: api.ReservationStatus(row.ReservationStatus),
+		CourtNumber:       row.CourtNumber.String,
 	}
 }
 
@@ -257,8 +269,16 @@ type CalendarBusyTimeRow struct {
 
 // ExpiredEventInfo contains info about an expired event for notification purposes
 type ExpiredEventInfo struct {
-	EventId string `db:"id"`
-	UserId  string `db:"user_id"`
+	EventId  string `db:"id"`
+	UserId   string `db:"user_id"`
+	SeriesId string `db:"series_id"` // empty when the event is not part of a series
+}
+
+// CompletedEventInfo contains info about a completed event for notification purposes
+type CompletedEventInfo struct {
+	EventId        string    `db:"id"`
+	UserId         string    `db:"user_id"`
+	PostMatchUntil time.Time `db:"post_match_until"`
 }
 
 // EventMessageRow represents a chat message in an event
@@ -270,3 +290,140 @@ type EventMessageRow struct {
 	MessageText     string    `db:"message_text"`
 	CreatedAt       time.Time `db:"created_at"`
 }
+
+// MatchSets is a set-by-set score stored as JSON
+type MatchSets []api.SetScore
+
+func (m MatchSets) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+func (m *MatchSets) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return json.Unmarshal([]byte(value.(string)), m)
+	}
+	return json.Unmarshal(bytes, m)
+}
+
+// UserIds is a list of user ids stored as JSON
+type UserIds []string
+
+func (u UserIds) Value() (driver.Value, error) {
+	return json.Marshal(u)
+}
+
+func (u *UserIds) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return json.Unmarshal([]byte(value.(string)), u)
+	}
+	return json.Unmarshal(bytes, u)
+}
+
+type MatchResultRow struct {
+	Id            string     `db:"id"`
+	EventId       string     `db:"event_id"`
+	SubmittedBy   string     `db:"submitted_by"`
+	Team          UserIds    `db:"team"`
+	Opponents     UserIds    `db:"opponents"`
+	SubmitterWon  bool       `db:"submitter_won"`
+	Sets          MatchSets  `db:"sets"`
+	Status        string     `db:"status"`
+	DisputeReason *string    `db:"dispute_reason"`
+	RespondedBy   *string    `db:"responded_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+	RespondedAt   *time.Time `db:"responded_at"`
+}
+
+// Winners returns the players of the winning side
+func (row *MatchResultRow) Winners() []string {
+	if row.SubmitterWon {
+		return row.Team
+	}
+	return row.Opponents
+}
+
+// Losers returns the players of the losing side
+func (row *MatchResultRow) Losers() []string {
+	if row.SubmitterWon {
+		return row.Opponents
+	}
+	return row.Team
+}
+
+type PlayerFeedbackRow struct {
+	EventId    string    `db:"event_id"`
+	FromUserId string    `db:"from_user_id"`
+	ToUserId   string    `db:"to_user_id"`
+	Rating     int       `db:"rating"`
+	Comment    *string   `db:"comment"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (row *PlayerFeedbackRow) ToApi() *api.PlayerFeedback {
+	feedback := &api.PlayerFeedback{
+		EventId:    row.EventId,
+		FromUserId: row.FromUserId,
+		ToUserId:   row.ToUserId,
+		Rating:     row.Rating,
+		CreatedAt:  api.DtToIso(row.CreatedAt),
+	}
+	if row.Comment != nil {
+		feedback.Comment = *row.Comment
+	}
+	return feedback
+}
+
+type NoShowReportRow struct {
+	EventId    string    `db:"event_id"`
+	ReportedBy string    `db:"reported_by"`
+	UserId     string    `db:"user_id"`
+	Comment    *string   `db:"comment"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (row *NoShowReportRow) ToApi() *api.NoShowReport {
+	report := &api.NoShowReport{
+		EventId:    row.EventId,
+		ReportedBy: row.ReportedBy,
+		UserId:     row.UserId,
+		CreatedAt:  api.DtToIso(row.CreatedAt),
+	}
+	if row.Comment != nil {
+		report.Comment = *row.Comment
+	}
+	return report
+}
+
+func (row *MatchResultRow) ToApi() *api.MatchResult {
+	result := &api.MatchResult{
+		Id:          row.Id,
+		EventId:     row.EventId,
+		SubmittedBy: row.SubmittedBy,
+		Team:        row.Team,
+		Opponents:   row.Opponents,
+		Winners:     row.Winners(),
+		Sets:        row.Sets,
+		Status:      api.MatchResultStatus(row.Status),
+		CreatedAt:   api.DtToIso(row.CreatedAt),
+	}
+	if row.DisputeReason != nil {
+		result.DisputeReason = *row.DisputeReason
+	}
+	if row.RespondedBy != nil {
+		result.RespondedBy = *row.RespondedBy
+	}
+	if row.RespondedAt != nil {
+		result.RespondedAt = api.DtToIso(*row.RespondedAt)
+	}
+	return result
+}
@@ -0,0 +1,271 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// activeJoinRequestStatuses are the statuses of join requests still taking part in an open event, rejected and
+// cancelled requests are kept as they were
+var activeJoinRequestStatuses = []api.JoinRequestStatus{
+	api.JoinRequestStatusWaiting,
+	api.JoinRequestStatusAccepted,
+	api.JoinRequestStatusWaitlisted,
+}
+
+// JoinRequestUpdate describes how an event update affected a join request
+type JoinRequestUpdate struct {
+	JoinRequestId    string
+	UserId           string
+	RemovedLocations []string
+	RemovedTimeSlots []time.Time
+	// Removed is true when none of the chosen locations or time slots is left, the join request is cancelled then
+	Removed bool
+	// Status is the status of the join request before the update
+	Status api.JoinRequestStatus
+}
+
+// freesPlace tells whether the update cancels a join request which held one of the places of the event
+func (u *JoinRequestUpdate) freesPlace() bool {
+	return u.Removed && (u.Status == api.JoinRequestStatusWaiting || u.Status == api.JoinRequestStatusAccepted)
+}
+
+type joinRequestChoiceRow struct {
+	JoinRequestId string                `db:"join_request_id"`
+	UserId        string                `db:"user_id"`
+	Status        api.JoinRequestStatus `db:"status"`
+	LocationId    string                `db:"location_id"`
+	Dt            time.Time             `db:"dt"`
+}
+
+// UpdateEvent changes the user's open event and reconciles its active join requests: locations and time slots
+// which are no longer offered by the event are removed from the join requests, requests left without any of them are
+// cancelled and the places they held are passed on to the waitlist. Returns the affected join requests and the
+// promotions from the waitlist.
+func (db *Db) UpdateEvent(ctx context.Context, userId string, eventId string, data *api.UpdateEventData) ([]JoinRequestUpdate, []*WaitlistPromotion, error) {
+	logCtx := slog.With("method", "UpdateEvent", "userId", userId, "eventId", eventId)
+	logCtx.Debug("Updating event")
+
+	expirationTime := DefaultExpirationTime(data.TimeSlots)
+	if data.ExpirationTime != "" {
+		expirationTime = api.ParseDt(data.ExpirationTime)
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	updates, err := db.updateEventTx(ctx, logCtx, tx, userId, eventId, data, expirationTime)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, nil, err
+	}
+
+	promotions, err := db.promoteForUpdatesTx(ctx, logCtx, tx, eventId, updates)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, nil, err
+	}
+
+	return updates, promotions, nil
+}
+
+// promoteForUpdatesTx promotes a waitlisted join request for every place freed by the update. Waitlisted requests
+// were reconciled with the updated event before, so the promoted ones still match it.
+func (db *Db) promoteForUpdatesTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, eventId string, updates []JoinRequestUpdate) ([]*WaitlistPromotion, error) {
+	freed := 0
+	for i := range updates {
+		if updates[i].freesPlace() {
+			freed++
+		}
+	}
+	if freed == 0 {
+		return nil, nil
+	}
+
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	var promotions []*WaitlistPromotion
+	for range freed {
+		promotion, err := db.promoteFromWaitlistTx(ctx, logCtx, tx, event, nil)
+		if err != nil {
+			return nil, err
+		}
+		if promotion == nil {
+			break
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+func (db *Db) updateEventTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, userId string, eventId string, data *api.UpdateEventData, expirationTime time.Time) ([]JoinRequestUpdate, error) {
+	var status string
+	err := tx.GetContext(ctx, &status, `SELECT status FROM events WHERE id = ? AND user_id = ? FOR UPDATE`, eventId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Event not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to lock event")
+	}
+
+	if api.EventStatus(status) != api.EventStatusOpen {
+		return nil, &ValidationError{Message: "Only open events can be edited"}
+	}
+
+	query := `UPDATE events SET skill_level = ?, description = ?, expiration_time = ? WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, data.SkillLevel, data.Description, expirationTime, eventId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to update event")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM event_locations WHERE event_id = ?`, eventId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to delete event locations")
+	}
+	locations := make([]EventLocationRow, len(data.Locations))
+	for i, location := range data.Locations {
+		locations[i] = EventLocationRow{EventId: eventId, LocationId: location}
+	}
+	if _, err := tx.NamedExecContext(ctx, `INSERT INTO event_locations (event_id, location_id) VALUES (:event_id, :location_id)`, locations); err != nil {
+		return nil, errors.WithMessage(err, "Failed to insert event locations")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM event_time_slots WHERE event_id = ?`, eventId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to delete event time slots")
+	}
+	timeSlots := make([]EventTimeSlotRow, len(data.TimeSlots))
+	for i, timeSlot := range data.TimeSlots {
+		timeSlots[i] = EventTimeSlotRow{EventId: eventId, Dt: api.ParseDt(timeSlot)}
+	}
+	if _, err := tx.NamedExecContext(ctx, `INSERT INTO event_time_slots (event_id, dt) VALUES (:event_id, :dt)`, timeSlots); err != nil {
+		return nil, errors.WithMessage(err, "Failed to insert event time slots")
+	}
+
+	var chosenLocations []joinRequestChoiceRow
+	query, args, err := sqlx.In(`SELECT jr.id AS join_request_id, jr.user_id, jr.status, jrl.location_id
+		FROM join_requests jr
+			INNER JOIN join_request_locations jrl ON jrl.join_request_id = jr.id
+		WHERE jr.event_id = ? AND jr.status IN (?)`, eventId, activeJoinRequestStatuses)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	if err := tx.SelectContext(ctx, &chosenLocations, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get join request locations")
+	}
+
+	var chosenTimeSlots []joinRequestChoiceRow
+	query, args, err = sqlx.In(`SELECT jr.id AS join_request_id, jr.user_id, jr.status, jrts.dt
+		FROM join_requests jr
+			INNER JOIN join_request_time_slots jrts ON jrts.join_request_id = jr.id
+		WHERE jr.event_id = ? AND jr.status IN (?)`, eventId, activeJoinRequestStatuses)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+	if err := tx.SelectContext(ctx, &chosenTimeSlots, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get join request time slots")
+	}
+
+	updates := reconcileJoinRequests(chosenLocations, chosenTimeSlots, data.Locations, api.DtFromIsoArray(data.TimeSlots))
+
+	for _, u := range updates {
+		if u.Removed {
+			// the request keeps its choices for the history, the status tells it is out of the event
+			query := `UPDATE join_requests SET is_accepted = false, status = ? WHERE id = ?`
+			if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusCancelled, u.JoinRequestId); err != nil {
+				return nil, errors.WithMessage(err, "Failed to cancel join request")
+			}
+			continue
+		}
+
+		if len(u.RemovedLocations) > 0 {
+			q, args, err := sqlx.In(`DELETE FROM join_request_locations WHERE join_request_id = ? AND location_id IN (?)`, u.JoinRequestId, u.RemovedLocations)
+			if err != nil {
+				return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+			}
+			if _, err := tx.ExecContext(ctx, tx.Rebind(q), args...); err != nil {
+				return nil, errors.WithMessage(err, "Failed to delete join request locations")
+			}
+		}
+
+		if len(u.RemovedTimeSlots) > 0 {
+			q, args, err := sqlx.In(`DELETE FROM join_request_time_slots WHERE join_request_id = ? AND dt IN (?)`, u.JoinRequestId, u.RemovedTimeSlots)
+			if err != nil {
+				return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+			}
+			if _, err := tx.ExecContext(ctx, tx.Rebind(q), args...); err != nil {
+				return nil, errors.WithMessage(err, "Failed to delete join request time slots")
+			}
+		}
+	}
+
+	logCtx.Debug("Event updated", "affectedJoinRequests", len(updates))
+	return updates, nil
+}
+
+// reconcileJoinRequests compares locations and time slots chosen in join requests with the ones the event offers
+// and returns join requests that lost some of their choices, in order of first appearance
+func reconcileJoinRequests(chosenLocations []joinRequestChoiceRow, chosenTimeSlots []joinRequestChoiceRow, locations []string, timeSlots []time.Time) []JoinRequestUpdate {
+	type state struct {
+		update        JoinRequestUpdate
+		keptLocations int
+		keptTimeSlots int
+	}
+
+	var order []string
+	states := map[string]*state{}
+	get := func(row joinRequestChoiceRow) *state {
+		s, ok := states[row.JoinRequestId]
+		if !ok {
+			s = &state{update: JoinRequestUpdate{JoinRequestId: row.JoinRequestId, UserId: row.UserId, Status: row.Status}}
+			states[row.JoinRequestId] = s
+			order = append(order, row.JoinRequestId)
+		}
+		return s
+	}
+
+	for _, row := range chosenLocations {
+		s := get(row)
+		if slices.Contains(locations, row.LocationId) {
+			s.keptLocations++
+		} else {
+			s.update.RemovedLocations = append(s.update.RemovedLocations, row.LocationId)
+		}
+	}
+
+	for _, row := range chosenTimeSlots {
+		s := get(row)
+		if slices.ContainsFunc(timeSlots, row.Dt.Equal) {
+			s.keptTimeSlots++
+		} else {
+			s.update.RemovedTimeSlots = append(s.update.RemovedTimeSlots, row.Dt)
+		}
+	}
+
+	var updates []JoinRequestUpdate
+	for _, id := range order {
+		s := states[id]
+		if len(s.update.RemovedLocations) == 0 && len(s.update.RemovedTimeSlots) == 0 {
+			continue
+		}
+		s.update.Removed = s.keptLocations == 0 || s.keptTimeSlots == 0
+		updates = append(updates, s.update)
+	}
+
+	return updates
+}
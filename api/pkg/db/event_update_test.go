@@ -0,0 +1,99 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestReconcileJoinRequests(t *testing.T) {
+	mon := time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+	tue := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+	wed := time.Date(2025, 6, 4, 18, 0, 0, 0, time.UTC)
+
+	chosenLocations := []joinRequestChoiceRow{
+		{JoinRequestId: "untouched", UserId: "u1", LocationId: "matchpoint"},
+		{JoinRequestId: "trimmed", UserId: "u2", LocationId: "matchpoint"},
+		{JoinRequestId: "trimmed", UserId: "u2", LocationId: "spartan"},
+		{JoinRequestId: "removed", UserId: "u3", LocationId: "spartan"},
+	}
+	chosenTimeSlots := []joinRequestChoiceRow{
+		{JoinRequestId: "untouched", UserId: "u1", Dt: tue},
+		{JoinRequestId: "trimmed", UserId: "u2", Dt: mon},
+		{JoinRequestId: "trimmed", UserId: "u2", Dt: tue},
+		{JoinRequestId: "removed", UserId: "u3", Dt: tue},
+	}
+
+	updates := reconcileJoinRequests(chosenLocations, chosenTimeSlots, []string{"matchpoint"}, []time.Time{tue, wed})
+	require.Len(t, updates, 2)
+
+	assert.Equal(t, JoinRequestUpdate{
+		JoinRequestId:    "trimmed",
+		UserId:           "u2",
+		RemovedLocations: []string{"spartan"},
+		RemovedTimeSlots: []time.Time{mon},
+	}, updates[0])
+
+	assert.Equal(t, "removed", updates[1].JoinRequestId)
+	assert.True(t, updates[1].Removed, "join request without any remaining location must be removed")
+}
+
+func TestReconcileJoinRequests_NoChanges(t *testing.T) {
+	tue := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+
+	updates := reconcileJoinRequests(
+		[]joinRequestChoiceRow{{JoinRequestId: "jr", UserId: "u1", LocationId: "matchpoint"}},
+		[]joinRequestChoiceRow{{JoinRequestId: "jr", UserId: "u1", Dt: tue}},
+		[]string{"matchpoint", "spartan"},
+		[]time.Time{tue.In(time.FixedZone("CEST", 2*3600))},
+	)
+	assert.Empty(t, updates)
+}
+
+func TestReconcileJoinRequests_FreedPlaces(t *testing.T) {
+	tue := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+	wed := time.Date(2025, 6, 4, 18, 0, 0, 0, time.UTC)
+
+	chosenLocations := []joinRequestChoiceRow{
+		{JoinRequestId: "accepted", UserId: "u1", Status: api.JoinRequestStatusAccepted, LocationId: "matchpoint"},
+		{JoinRequestId: "waitlisted", UserId: "u2", Status: api.JoinRequestStatusWaitlisted, LocationId: "matchpoint"},
+		{JoinRequestId: "waitlisted", UserId: "u2", Status: api.JoinRequestStatusWaitlisted, LocationId: "spartan"},
+	}
+	chosenTimeSlots := []joinRequestChoiceRow{
+		{JoinRequestId: "accepted", UserId: "u1", Status: api.JoinRequestStatusAccepted, Dt: tue},
+		{JoinRequestId: "waitlisted", UserId: "u2", Status: api.JoinRequestStatusWaitlisted, Dt: tue},
+		{JoinRequestId: "waitlisted", UserId: "u2", Status: api.JoinRequestStatusWaitlisted, Dt: wed},
+	}
+
+	// the accepted joiner only chose tuesday, the waitlisted one can still play on wednesday and takes the place
+	updates := reconcileJoinRequests(chosenLocations, chosenTimeSlots, []string{"matchpoint", "spartan"}, []time.Time{wed})
+	require.Len(t, updates, 2)
+
+	assert.Equal(t, "accepted", updates[0].JoinRequestId)
+	assert.Equal(t, api.JoinRequestStatusAccepted, updates[0].Status)
+	assert.True(t, updates[0].freesPlace(), "cancelled accepted join request must free its place")
+
+	assert.Equal(t, "waitlisted", updates[1].JoinRequestId)
+	assert.False(t, updates[1].Removed)
+	assert.False(t, updates[1].freesPlace(), "waitlisted join request holds no place")
+}
+
+func TestJoinRequestUpdate_FreesPlace(t *testing.T) {
+	tests := []struct {
+		status  api.JoinRequestStatus
+		removed bool
+		want    bool
+	}{
+		{api.JoinRequestStatusWaiting, true, true},
+		{api.JoinRequestStatusAccepted, true, true},
+		{api.JoinRequestStatusWaitlisted, true, false},
+		{api.JoinRequestStatusAccepted, false, false},
+	}
+	for _, tt := range tests {
+		u := JoinRequestUpdate{Status: tt.status, Removed: tt.removed}
+		assert.Equal(t, tt.want, u.freesPlace(), "status %s removed %v", tt.status, tt.removed)
+	}
+}
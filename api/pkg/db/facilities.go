@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// coveredCourtTypes are the court group types played under a roof, "baloon" is a legacy spelling kept by the schema
+var coveredCourtTypes = []string{"indoor", "tent", "balloon", "baloon"}
+
+// FacilitiesFilter narrows down the list of facilities returned by GetAllFacilities.
+// Zero values mean "no filter". All court filters must be met by the same court group.
+// Facilities are sorted by distance from the center of Near, its zero radius does not limit the distance.
+type FacilitiesFilter struct {
+	Surfaces     []api.CourtSurface
+	Covered      bool
+	Lighting     bool
+	PartnerCards []string
+	Near         *GeoRadius
+}
+
+// buildFacilitiesWhere returns the WHERE clause of the facilities list query
+func buildFacilitiesWhere(filter *FacilitiesFilter) (string, []interface{}) {
+	conds := []string{"f.status = 'active'"}
+	args := []interface{}{}
+
+	courtConds := []string{"cg.facility_id = f.id"}
+	if len(filter.Surfaces) > 0 {
+		courtConds = append(courtConds, "cg.surface IN (?)")
+		args = append(args, filter.Surfaces)
+	}
+	if filter.Covered {
+		courtConds = append(courtConds, "cg.type IN (?)")
+		args = append(args, coveredCourtTypes)
+	}
+	if filter.Lighting {
+		courtConds = append(courtConds, "cg.light = TRUE")
+	}
+	if len(courtConds) > 1 {
+		conds = append(conds, "EXISTS (SELECT 1 FROM court_groups cg WHERE "+strings.Join(courtConds, " AND ")+")")
+	}
+
+	if len(filter.PartnerCards) > 0 {
+		conds = append(conds, "EXISTS (SELECT 1 FROM facility_partner_cards fpc WHERE fpc.facility_id = f.id AND fpc.partner_card_id IN (?))")
+		args = append(args, filter.PartnerCards)
+	}
+
+	if filter.Near != nil && filter.Near.RadiusKm > 0 {
+		geoCond, geoArgs := geoRadiusCondition("f.location", *filter.Near)
+		conds = append(conds, geoCond)
+		args = append(args, geoArgs...)
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+type facilityDetailsRow struct {
+	Id             string         `db:"id"`
+	Name           string         `db:"name"`
+	Address        string         `db:"address"`
+	Latitude       float64        `db:"latitude"`
+	Longitude      float64        `db:"longitude"`
+	Country        string         `db:"country"`
+	Website        sql.NullString `db:"website"`
+	GoogleMapsLink sql.NullString `db:"google_maps_link"`
+}
+
+type courtGroupRow struct {
+	Id              int            `db:"id"`
+	Surface         string         `db:"surface"`
+	Type            string         `db:"type"`
+	Light           sql.NullBool   `db:"light"`
+	Heating         sql.NullBool   `db:"heating"`
+	ReservationLink sql.NullString `db:"reservation_link"`
+	CourtNames      sql.NullString `db:"court_names"`
+}
+
+// GetFacilityDetails returns the full profile of the active facility with its court groups and accepted partner cards,
+// hidden facilities are not found
+func (db *Db) GetFacilityDetails(ctx context.Context, facilityId string) (*api.LocationDetails, error) {
+	return db.getFacilityDetails(ctx, facilityId, false)
+}
+
+func (db *Db) getFacilityDetails(ctx context.Context, facilityId string, includeHidden bool) (*api.LocationDetails, error) {
+	logCtx := slog.With("method", "getFacilityDetails", "facilityId", facilityId, "includeHidden", includeHidden)
+
+	query := `SELECT id, name, address, ST_Y(location) AS latitude, ST_X(location) AS longitude, country, website, google_maps_link
+		FROM facilities WHERE id = ? AND (? OR status = 'active')`
+	logCtx.Debug("Executing SQL query", "query", query)
+	var row facilityDetailsRow
+	if err := db.conn.GetContext(ctx, &row, query, facilityId, includeHidden); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Facility not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to get facility")
+	}
+
+	details := &api.LocationDetails{
+		Location: api.Location{
+			ID:      row.Id,
+			Name:    row.Name,
+			Address: row.Address,
+			Coordinates: api.Coordinates{
+				Latitude:  row.Latitude,
+				Longitude: row.Longitude,
+			},
+		},
+		Country:        row.Country,
+		Website:        row.Website.String,
+		GoogleMapsLink: row.GoogleMapsLink.String,
+		CourtGroups:    []api.CourtGroup{},
+		PartnerCards:   []api.PartnerCard{},
+	}
+
+	query = `SELECT id, surface, type, light, heating, reservation_link, court_names
+		FROM court_groups WHERE facility_id = ? ORDER BY id`
+	logCtx.Debug("Executing SQL query", "query", query)
+	var groups []courtGroupRow
+	if err := db.conn.SelectContext(ctx, &groups, query, facilityId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get court groups")
+	}
+	for _, group := range groups {
+		details.CourtGroups = append(details.CourtGroups, toApiCourtGroup(group))
+	}
+
+	query = `SELECT pc.id, pc.name FROM facility_partner_cards fpc
+		INNER JOIN partner_cards pc ON pc.id = fpc.partner_card_id
+		WHERE fpc.facility_id = ? ORDER BY pc.name`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if err := db.conn.SelectContext(ctx, &details.PartnerCards, query, facilityId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get partner cards")
+	}
+
+	return details, nil
+}
+
+func toApiCourtGroup(row courtGroupRow) api.CourtGroup {
+	courtType := row.Type
+	if courtType == "baloon" {
+		courtType = "balloon"
+	}
+
+	courtNames := []string{}
+	for _, name := range strings.Split(row.CourtNames.String, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			courtNames = append(courtNames, name)
+		}
+	}
+
+	covered := false
+	for _, t := range coveredCourtTypes {
+		if row.Type == t {
+			covered = true
+		}
+	}
+
+	return api.CourtGroup{
+		Id:              row.Id,
+		Surface:         api.CourtSurface(row.Surface),
+		Type:            courtType,
+		Covered:         covered,
+		Light:           row.Light.Bool,
+		Heating:         row.Heating.Bool,
+		ReservationLink: row.ReservationLink.String,
+		CourtNames:      courtNames,
+	}
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestBuildFacilitiesWhere_NoFilters(t *testing.T) {
+	where, args := buildFacilitiesWhere(&FacilitiesFilter{})
+
+	assert.Equal(t, "f.status = 'active'", where)
+	assert.Empty(t, args)
+}
+
+func TestBuildFacilitiesWhere_AllFilters(t *testing.T) {
+	where, args := buildFacilitiesWhere(&FacilitiesFilter{
+		Surfaces: []api.CourtSurface{api.CourtSurfaceClay},
+		Covered:  true,
+		Lighting: true,
+	})
+
+	// all conditions apply to the same court group
+	assert.Equal(t, 1, strings.Count(where, "EXISTS"))
+	assert.Contains(t, where, "cg.surface IN (?) AND cg.type IN (?) AND cg.light = TRUE")
+	assert.Equal(t, []interface{}{[]api.CourtSurface{api.CourtSurfaceClay}, coveredCourtTypes}, args)
+}
+
+func TestBuildFacilitiesWhere_PartnerCards(t *testing.T) {
+	where, args := buildFacilitiesWhere(&FacilitiesFilter{PartnerCards: []string{"medicover", "multisport-plus"}})
+
+	assert.Contains(t, where, "fpc.partner_card_id IN (?)")
+	assert.NotContains(t, where, "court_groups")
+	assert.Equal(t, []interface{}{[]string{"medicover", "multisport-plus"}}, args)
+}
+
+func TestBuildFacilitiesWhere_Near(t *testing.T) {
+	// without a radius facilities are only sorted by distance
+	where, args := buildFacilitiesWhere(&FacilitiesFilter{Near: &GeoRadius{Latitude: 51.1, Longitude: 17.03}})
+	assert.Equal(t, "f.status = 'active'", where)
+	assert.Empty(t, args)
+
+	where, args = buildFacilitiesWhere(&FacilitiesFilter{Near: &GeoRadius{Latitude: 51.1, Longitude: 17.03, RadiusKm: 5}})
+	assert.Contains(t, where, "MBRContains(ST_GeomFromText(?), f.location)")
+	assert.Equal(t, strings.Count(where, "?"), len(args))
+}
+
+func TestToApiCourtGroup(t *testing.T) {
+	group := toApiCourtGroup(courtGroupRow{
+		Id:         1,
+		Surface:    "artificial-grass",
+		Type:       "baloon",
+		CourtNames: sql.NullString{String: "1, 2,,C", Valid: true},
+	})
+	assert.Equal(t, "balloon", group.Type)
+	assert.True(t, group.Covered)
+	assert.Equal(t, []string{"1", "2", "C"}, group.CourtNames)
+
+	group = toApiCourtGroup(courtGroupRow{Id: 2, Surface: "clay", Type: "outdoor"})
+	assert.False(t, group.Covered)
+	assert.Equal(t, []string{}, group.CourtNames)
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// metersPerDegreeLatitude is used to skip pairs of facilities that are obviously too far apart
+const metersPerDegreeLatitude = 111_320.0
+
+// FacilityPair is two facilities close to each other, possibly the same place added twice
+type FacilityPair struct {
+	First     api.AdminFacility
+	Second    api.AdminFacility
+	DistanceM float64
+}
+
+// GetNearbyFacilityPairs returns all pairs of facilities at most maxMeters apart, the closest first
+func (db *Db) GetNearbyFacilityPairs(ctx context.Context, maxMeters float64) ([]FacilityPair, error) {
+	logCtx := slog.With("method", "GetNearbyFacilityPairs", "maxMeters", maxMeters)
+
+	columns := func(t string) string {
+		return t + `.id, ` + t + `.name, ` + t + `.address, ST_Y(` + t + `.location), ST_X(` + t + `.location), ` +
+			t + `.status, ` + t + `.source, COALESCE(` + t + `.added_by, ''), COALESCE(` + t + `.place_provider, ''), COALESCE(` + t + `.place_id, ''), ` +
+			t + `.created_at`
+	}
+	query := `SELECT ` + columns("a") + `, ` + columns("b") + `, ST_Distance_Sphere(a.location, b.location) AS distance
+		FROM facilities a
+		INNER JOIN facilities b ON a.id < b.id
+			AND ABS(ST_Y(a.location) - ST_Y(b.location)) <= ?
+			AND ST_Distance_Sphere(a.location, b.location) <= ?
+		ORDER BY distance, a.name`
+	logCtx.Debug("Executing SQL query", "query", query)
+	rows, err := db.conn.QueryContext(ctx, query, maxMeters/metersPerDegreeLatitude, maxMeters)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to get nearby facilities")
+	}
+	defer func() { _ = rows.Close() }()
+
+	pairs := []FacilityPair{}
+	for rows.Next() {
+		var pair FacilityPair
+		var firstCreatedAt, secondCreatedAt time.Time
+		err := rows.Scan(
+			&pair.First.ID, &pair.First.Name, &pair.First.Address,
+			&pair.First.Coordinates.Latitude, &pair.First.Coordinates.Longitude,
+			&pair.First.Status, &pair.First.Source, &pair.First.AddedBy, &pair.First.PlaceProvider, &pair.First.PlaceID,
+			&firstCreatedAt,
+			&pair.Second.ID, &pair.Second.Name, &pair.Second.Address,
+			&pair.Second.Coordinates.Latitude, &pair.Second.Coordinates.Longitude,
+			&pair.Second.Status, &pair.Second.Source, &pair.Second.AddedBy, &pair.Second.PlaceProvider, &pair.Second.PlaceID,
+			&secondCreatedAt,
+			&pair.DistanceM,
+		)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to scan nearby facilities")
+		}
+		pair.First.CreatedAt = api.DtToIso(firstCreatedAt)
+		pair.Second.CreatedAt = api.DtToIso(secondCreatedAt)
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "Failed to read nearby facilities")
+	}
+	return pairs, nil
+}
+
+// MergeFacilities moves events, join requests, confirmations, court groups, partner cards and favourites of the
+// duplicate to the facility and deletes the duplicate. The facility takes over links and the Google Place ID it does not have.
+func (db *Db) MergeFacilities(ctx context.Context, facilityId string, duplicateId string) error {
+	logCtx := slog.With("method", "MergeFacilities", "facilityId", facilityId, "duplicateId", duplicateId)
+
+	if facilityId == duplicateId {
+		return &ValidationError{Message: "Facility cannot be merged with itself"}
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.mergeFacilitiesTx(ctx, tx, logCtx, facilityId, duplicateId); err != nil {
+		db.rollback(logCtx, tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return nil
+}
+
+func (db *Db) mergeFacilitiesTx(ctx context.Context, tx *sqlx.Tx, logCtx *slog.Logger, facilityId string, duplicateId string) error {
+	var locked []string
+	err := tx.SelectContext(ctx, &locked, `SELECT id FROM facilities WHERE id IN (?, ?) FOR UPDATE`, facilityId, duplicateId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to lock facilities")
+	}
+	if len(locked) != 2 {
+		return DbObjectNotFoundError{Message: "Facility not found"}
+	}
+
+	var duplicate struct {
+		GoogleMapsLink sql.NullString `db:"google_maps_link"`
+		Website        sql.NullString `db:"website"`
+		PlaceProvider  sql.NullString `db:"place_provider"`
+		PlaceID        sql.NullString `db:"place_id"`
+	}
+	query := `SELECT google_maps_link, website, place_provider, place_id FROM facilities WHERE id = ?`
+	err = tx.GetContext(ctx, &duplicate, query, duplicateId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get duplicate facility")
+	}
+
+	// rows the facility already has would violate the primary key, they stay with the duplicate and are removed
+	// together with it by ON DELETE CASCADE
+	statements := []struct {
+		query   string
+		message string
+	}{
+		{`UPDATE IGNORE event_locations SET location_id = ? WHERE location_id = ?`, "Failed to move event locations"},
+		{`UPDATE IGNORE join_request_locations SET location_id = ? WHERE location_id = ?`, "Failed to move join request locations"},
+		{`UPDATE confirmations SET location_id = ? WHERE location_id = ?`, "Failed to move confirmations"},
+		{`UPDATE confirmation_versions SET location_id = ? WHERE location_id = ?`, "Failed to move confirmation versions"},
+		{`UPDATE court_groups SET facility_id = ? WHERE facility_id = ?`, "Failed to move court groups"},
+		{`UPDATE IGNORE facility_partner_cards SET facility_id = ? WHERE facility_id = ?`, "Failed to move partner cards"},
+		{`UPDATE IGNORE user_favourite_facilities SET facility_id = ? WHERE facility_id = ?`, "Failed to move favourite facilities"},
+		{`UPDATE user_pref SET home_facility_id = ? WHERE home_facility_id = ?`, "Failed to move home facilities"},
+	}
+	for _, s := range statements {
+		logCtx.Debug("Executing SQL query", "query", s.query)
+		if _, err := tx.ExecContext(ctx, s.query, facilityId, duplicateId); err != nil {
+			return errors.WithMessage(err, s.message)
+		}
+	}
+
+	// the place id is unique per provider, so it is released by deleting the duplicate before the facility takes it over
+	if _, err := tx.ExecContext(ctx, `DELETE FROM facilities WHERE id = ?`, duplicateId); err != nil {
+		return errors.WithMessage(err, "Failed to delete duplicate facility")
+	}
+
+	// the provider is assigned first, while place_id still tells whether the facility has a place of its own
+	query = `UPDATE facilities SET google_maps_link = COALESCE(google_maps_link, ?), website = COALESCE(website, ?),
+		place_provider = IF(place_id IS NULL, ?, place_provider), place_id = COALESCE(place_id, ?) WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err = tx.ExecContext(ctx, query, duplicate.GoogleMapsLink, duplicate.Website, duplicate.PlaceProvider,
+		duplicate.PlaceID, facilityId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update facility")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+// importMatchMeters is how far apart a facility of the same name may be to be considered the same facility
+const importMatchMeters = 100
+
+// importPlaceProvider is the provider of the place ids in import files
+const importPlaceProvider = "google"
+
+// FacilityImport is a facility with its court groups and prices read from an import file
+type FacilityImport struct {
+	api.AdminFacilityData
+	GooglePlaceID string
+	CourtGroups   []CourtGroupImport
+}
+
+// CourtGroupImport is a court group with its price periods read from an import file
+type CourtGroupImport struct {
+	api.AdminCourtGroupData
+	Periods []pricing.Period
+}
+
+// FindFacilityForImport returns the id of the existing facility matching the imported one by Google Place ID or by
+// name and coordinates, or an empty string when there is none
+func (db *Db) FindFacilityForImport(ctx context.Context, f *FacilityImport) (string, error) {
+	return findFacilityForImport(ctx, db.conn, f)
+}
+
+// ImportFacility creates the facility or updates the matching one and returns its id. An updated facility keeps its
+// source. When the import has court groups they replace the ones of earlier imports, court groups added by admins
+// are kept.
+func (db *Db) ImportFacility(ctx context.Context, f *FacilityImport) (string, bool, error) {
+	logCtx := slog.With("method", "ImportFacility", "name", f.Name)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", false, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	facilityId, created, err := db.importFacilityTx(ctx, tx, logCtx, f)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return "", false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", false, errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return facilityId, created, nil
+}
+
+func (db *Db) importFacilityTx(ctx context.Context, tx *sqlx.Tx, logCtx *slog.Logger, f *FacilityImport) (string, bool, error) {
+	facilityId, err := findFacilityForImport(ctx, tx, f)
+	if err != nil {
+		return "", false, err
+	}
+
+	created := facilityId == ""
+	if created {
+		facilityId = uuid.New().String()
+		query := `INSERT INTO facilities (id, name, address, google_maps_link, website, country, location, place_provider,
+				place_id, source, status)
+			VALUES (?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ST_GeomFromText(?), IF(? = '', NULL, ?), NULLIF(?, ''), 'seed', 'active')`
+		logCtx.Debug("Executing SQL query", "query", query)
+		_, err = tx.ExecContext(ctx, query, facilityId, f.Name, f.Address, f.GoogleMapsLink, f.Website, f.Country,
+			pointWkt(f.Coordinates), f.GooglePlaceID, importPlaceProvider, f.GooglePlaceID)
+		if err != nil {
+			return "", false, errors.WithMessage(err, "Failed to create facility")
+		}
+	} else {
+		query := `UPDATE facilities SET name = ?, address = ?, google_maps_link = NULLIF(?, ''), website = NULLIF(?, ''),
+			country = ?, location = ST_GeomFromText(?), place_provider = IF(? = '', place_provider, ?),
+			place_id = COALESCE(NULLIF(?, ''), place_id)
+			WHERE id = ?`
+		logCtx.Debug("Executing SQL query", "query", query)
+		_, err = tx.ExecContext(ctx, query, f.Name, f.Address, f.GoogleMapsLink, f.Website, f.Country,
+			pointWkt(f.Coordinates), f.GooglePlaceID, importPlaceProvider, f.GooglePlaceID, facilityId)
+		if err != nil {
+			return "", false, errors.WithMessage(err, "Failed to update facility")
+		}
+	}
+
+	if len(f.CourtGroups) == 0 {
+		return facilityId, created, nil
+	}
+
+	// price periods and rules are removed with their court groups
+	if _, err := tx.ExecContext(ctx, `DELETE FROM court_groups WHERE facility_id = ? AND imported`, facilityId); err != nil {
+		return "", false, errors.WithMessage(err, "Failed to delete court groups")
+	}
+	for _, group := range f.CourtGroups {
+		query := `INSERT INTO court_groups (facility_id, surface, type, light, heating, reservation_link, court_names, imported)
+			VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), ?, TRUE)`
+		result, err := tx.ExecContext(ctx, query, facilityId, group.Surface, group.Type, group.Light, group.Heating,
+			group.ReservationLink, strings.Join(group.CourtNames, ","))
+		if err != nil {
+			return "", false, errors.WithMessage(err, "Failed to create court group")
+		}
+		courtGroupId, err := result.LastInsertId()
+		if err != nil {
+			return "", false, errors.WithMessage(err, "Failed to get court group id")
+		}
+
+		for _, period := range group.Periods {
+			result, err := tx.ExecContext(ctx, `INSERT INTO price_periods (court_group_id, valid_from, valid_to) VALUES (?, ?, ?)`,
+				courtGroupId, period.ValidFrom.Format(time.DateOnly), period.ValidTo.Format(time.DateOnly))
+			if err != nil {
+				return "", false, errors.WithMessage(err, "Failed to create price period")
+			}
+			pricePeriodId, err := result.LastInsertId()
+			if err != nil {
+				return "", false, errors.WithMessage(err, "Failed to get price period id")
+			}
+			if err := insertPriceRulesTx(ctx, tx, int(pricePeriodId), period.Rules); err != nil {
+				return "", false, err
+			}
+		}
+	}
+	return facilityId, created, nil
+}
+
+func findFacilityForImport(ctx context.Context, q sqlx.QueryerContext, f *FacilityImport) (string, error) {
+	var facilityId string
+	if f.GooglePlaceID != "" {
+		query := `SELECT id FROM facilities WHERE place_provider = ? AND place_id = ?`
+		err := sqlx.GetContext(ctx, q, &facilityId, query, importPlaceProvider, f.GooglePlaceID)
+		if err == nil {
+			return facilityId, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return "", errors.WithMessage(err, "Failed to find facility by Google Place ID")
+		}
+	}
+
+	query := `SELECT id FROM facilities
+		WHERE LOWER(name) = LOWER(?) AND ST_Distance_Sphere(location, ST_GeomFromText(?)) <= ?
+		ORDER BY ST_Distance_Sphere(location, ST_GeomFromText(?))
+		LIMIT 1`
+	point := pointWkt(f.Coordinates)
+	err := sqlx.GetContext(ctx, q, &facilityId, query, f.Name, point, importMatchMeters, point)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", errors.WithMessage(err, "Failed to find facility by name")
+	}
+	return facilityId, nil
+}
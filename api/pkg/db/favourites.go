@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// GetFavouriteFacilities returns the active favourite facilities of the user, the home facility first, then in the
+// order they were added. The home facility id is empty when the user has not chosen one.
+func (db *Db) GetFavouriteFacilities(ctx context.Context, userId string) (string, []api.Location, error) {
+	logCtx := slog.With("method", "GetFavouriteFacilities", "userId", userId)
+
+	var homeFacilityId sql.NullString
+	err := db.conn.GetContext(ctx, &homeFacilityId, `SELECT home_facility_id FROM user_pref WHERE uid = ?`, userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", nil, errors.WithMessage(err, "Failed to get home facility")
+	}
+
+	query := `SELECT f.id, f.name, f.address, ST_Y(f.location), ST_X(f.location)
+		FROM user_favourite_facilities uf
+		INNER JOIN facilities f ON f.id = uf.facility_id
+		WHERE uf.uid = ? AND f.status = 'active'
+		ORDER BY f.id = ? DESC, uf.created_at, f.name`
+	logCtx.Debug("Executing SQL query", "query", query)
+	rows, err := db.conn.QueryContext(ctx, query, userId, homeFacilityId.String)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "Failed to get favourite facilities")
+	}
+	defer func() { _ = rows.Close() }()
+
+	favourites := []api.Location{}
+	for rows.Next() {
+		var location api.Location
+		err := rows.Scan(&location.ID, &location.Name, &location.Address, &location.Coordinates.Latitude, &location.Coordinates.Longitude)
+		if err != nil {
+			return "", nil, errors.WithMessage(err, "Failed to scan favourite facility")
+		}
+		favourites = append(favourites, location)
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, errors.WithMessage(err, "Failed to read favourite facilities")
+	}
+	return homeFacilityId.String, favourites, nil
+}
+
+// AddFavouriteFacility adds the facility to the favourites of the user, adding it twice is not an error
+func (db *Db) AddFavouriteFacility(ctx context.Context, userId string, facilityId string) error {
+	logCtx := slog.With("method", "AddFavouriteFacility", "userId", userId, "facilityId", facilityId)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.addFavouriteFacilityTx(ctx, tx, userId, facilityId); err != nil {
+		db.rollback(logCtx, tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return nil
+}
+
+func (db *Db) addFavouriteFacilityTx(ctx context.Context, tx *sqlx.Tx, userId string, facilityId string) error {
+	var profiles int
+	err := tx.GetContext(ctx, &profiles, `SELECT COUNT(*) FROM users WHERE uid = ? AND is_deleted = false`, userId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get user")
+	}
+	if profiles == 0 {
+		return DbObjectNotFoundError{Message: "Profile not found"}
+	}
+
+	// hidden facilities are not listed, so they cannot be chosen either
+	var facilities int
+	err = tx.GetContext(ctx, &facilities, `SELECT COUNT(*) FROM facilities WHERE id = ? AND status = 'active'`, facilityId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get facility")
+	}
+	if facilities == 0 {
+		return DbObjectNotFoundError{Message: "Facility not found"}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT IGNORE INTO user_favourite_facilities (uid, facility_id) VALUES (?, ?)`, userId, facilityId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to add favourite facility")
+	}
+	return nil
+}
+
+// RemoveFavouriteFacility removes the facility from the favourites of the user, it stops being the home facility
+func (db *Db) RemoveFavouriteFacility(ctx context.Context, userId string, facilityId string) error {
+	logCtx := slog.With("method", "RemoveFavouriteFacility", "userId", userId, "facilityId", facilityId)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM user_favourite_facilities WHERE uid = ? AND facility_id = ?`, userId, facilityId)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return errors.WithMessage(err, "Failed to remove favourite facility")
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE user_pref SET home_facility_id = NULL WHERE uid = ? AND home_facility_id = ?`, userId, facilityId)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return errors.WithMessage(err, "Failed to clear home facility")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return nil
+}
+
+// SetHomeFacility makes the facility the home facility of the user and adds it to the favourites.
+// An empty facility id clears the home facility, the favourites stay unchanged.
+func (db *Db) SetHomeFacility(ctx context.Context, userId string, facilityId string) error {
+	logCtx := slog.With("method", "SetHomeFacility", "userId", userId, "facilityId", facilityId)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.setHomeFacilityTx(ctx, tx, userId, facilityId); err != nil {
+		db.rollback(logCtx, tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithMessage(err, "Failed to commit transaction")
+	}
+	return nil
+}
+
+func (db *Db) setHomeFacilityTx(ctx context.Context, tx *sqlx.Tx, userId string, facilityId string) error {
+	home := sql.NullString{String: facilityId, Valid: facilityId != ""}
+	if home.Valid {
+		if err := db.addFavouriteFacilityTx(ctx, tx, userId, facilityId); err != nil {
+			return err
+		}
+	}
+
+	// profiles created without preferences get the defaults of a new profile
+	query := `INSERT INTO user_pref (uid, language, country, city, notifications, home_facility_id) VALUES (?, 'en', '', '', '{}', ?)
+		ON DUPLICATE KEY UPDATE home_facility_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userId, home, home); err != nil {
+		return errors.WithMessage(err, "Failed to set home facility")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"fmt"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
+
+// GeoRadius describes a circle on the map used to search for nearby facilities
+type GeoRadius struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+// BoundingBoxWkt returns a WKT polygon enclosing the circle. It is used with MBRContains
+// so that MySQL can narrow down candidates with the spatial index on facilities.location
+// before the exact ST_Distance_Sphere check.
+func (g GeoRadius) BoundingBoxWkt() string {
+	latDelta := g.RadiusKm / earthRadiusKm * 180 / math.Pi
+	lngDelta := latDelta / math.Max(math.Cos(g.Latitude*math.Pi/180), 0.01)
+
+	minLat := math.Max(g.Latitude-latDelta, -90)
+	maxLat := math.Min(g.Latitude+latDelta, 90)
+	minLng := math.Max(g.Longitude-lngDelta, -180)
+	maxLng := math.Min(g.Longitude+lngDelta, 180)
+
+	return fmt.Sprintf("POLYGON((%f %f, %f %f, %f %f, %f %f, %f %f))",
+		minLng, minLat,
+		maxLng, minLat,
+		maxLng, maxLat,
+		minLng, maxLat,
+		minLng, minLat)
+}
+
+// CenterWkt returns the center of the circle as a WKT point (x = longitude, y = latitude)
+func (g GeoRadius) CenterWkt() string {
+	return fmt.Sprintf("POINT(%f %f)", g.Longitude, g.Latitude)
+}
+
+// RadiusMeters returns the radius in meters as expected by ST_Distance_Sphere
+func (g GeoRadius) RadiusMeters() float64 {
+	return g.RadiusKm * 1000
+}
+
+// geoRadiusCondition returns an SQL condition matching points of the given column within the radius
+// together with its arguments
+func geoRadiusCondition(column string, g GeoRadius) (string, []interface{}) {
+	cond := fmt.Sprintf("MBRContains(ST_GeomFromText(?), %[1]s) AND ST_Distance_Sphere(%[1]s, ST_GeomFromText(?)) <= ?", column)
+	return cond, []interface{}{g.BoundingBoxWkt(), g.CenterWkt(), g.RadiusMeters()}
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/holidays"
+)
+
+type holidayOverrideRow struct {
+	Country   string    `db:"country"`
+	Date      time.Time `db:"date"`
+	Name      string    `db:"name"`
+	IsHoliday bool      `db:"is_holiday"`
+}
+
+// GetHolidayOverrides returns the admin corrections of the holidays of the country
+func (db *Db) GetHolidayOverrides(ctx context.Context, country string) ([]holidays.Override, error) {
+	logCtx := slog.With("method", "GetHolidayOverrides", "country", country)
+
+	query := `SELECT country, date, name, is_holiday FROM holiday_overrides WHERE country = ? ORDER BY date`
+	logCtx.Debug("Executing SQL query", "query", query)
+	var rows []holidayOverrideRow
+	if err := db.conn.SelectContext(ctx, &rows, query, holidays.NormalizeCountry(country)); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get holiday overrides")
+	}
+
+	overrides := make([]holidays.Override, len(rows))
+	for i, row := range rows {
+		overrides[i] = holidays.Override{
+			Country:   row.Country,
+			Date:      row.Date.Format(time.DateOnly),
+			Name:      row.Name,
+			IsHoliday: row.IsHoliday,
+		}
+	}
+	return overrides, nil
+}
+
+// SetHolidayOverride adds or replaces the correction of the holiday of the country on the date
+func (db *Db) SetHolidayOverride(ctx context.Context, userId string, override holidays.Override) error {
+	logCtx := slog.With("method", "SetHolidayOverride", "userId", userId, "country", override.Country, "date", override.Date)
+
+	query := `INSERT INTO holiday_overrides (country, date, name, is_holiday, updated_by) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name), is_holiday = VALUES(is_holiday), updated_by = VALUES(updated_by)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err := db.conn.ExecContext(ctx, query, holidays.NormalizeCountry(override.Country), override.Date, override.Name, override.IsHoliday, userId)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to set holiday override")
+	}
+	return nil
+}
+
+// DeleteHolidayOverride removes the correction so the embedded holiday data applies again
+func (db *Db) DeleteHolidayOverride(ctx context.Context, country string, date string) error {
+	logCtx := slog.With("method", "DeleteHolidayOverride", "country", country, "date", date)
+
+	query := `DELETE FROM holiday_overrides WHERE country = ? AND date = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, holidays.NormalizeCountry(country), date)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete holiday override")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get affected rows")
+	}
+	if affected == 0 {
+		return DbObjectNotFoundError{Message: "Holiday override not found"}
+	}
+	return nil
+}
+
+// GetFacilityCountry returns the country code of the facility
+func (db *Db) GetFacilityCountry(ctx context.Context, facilityId string) (string, error) {
+	var country string
+	err := db.conn.GetContext(ctx, &country, `SELECT country FROM facilities WHERE id = ?`, facilityId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", DbObjectNotFoundError{Message: "Facility not found"}
+		}
+		return "", errors.WithMessage(err, "Failed to get facility country")
+	}
+	return country, nil
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+type EventInvitationRow struct {
+	EventId   string    `db:"event_id"`
+	UserId    string    `db:"user_id"`
+	InvitedBy string    `db:"invited_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (row *EventInvitationRow) ToApi() *api.EventInvitation {
+	return &api.EventInvitation{
+		EventId:   row.EventId,
+		UserId:    row.UserId,
+		InvitedBy: row.InvitedBy,
+		CreatedAt: api.DtToIso(row.CreatedAt),
+	}
+}
+
+// InviteUsers invites the users to the event. Returns the users who were not invited before.
+func (db *Db) InviteUsers(ctx context.Context, hostUserId string, eventId string, userIds []string) ([]string, error) {
+	logCtx := slog.With("method", "InviteUsers", "userId", hostUserId, "eventId", eventId, "userIds", userIds)
+	logCtx.Debug("Inviting users")
+
+	query, args, err := sqlx.In(`SELECT uid FROM users WHERE uid IN (?) AND is_deleted = false`, userIds)
+	if err != nil {
+		logCtx.Error("Failed to prepare query with IN clause", "error", err)
+		return nil, err
+	}
+	var existing []string
+	if err := db.conn.SelectContext(ctx, &existing, db.conn.Rebind(query), args...); err != nil {
+		logCtx.Error("Failed to get users", "error", err)
+		return nil, err
+	}
+
+	var unknown []string
+	for _, userId := range userIds {
+		if !slices.Contains(existing, userId) && !slices.Contains(unknown, userId) {
+			unknown = append(unknown, userId)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &ValidationError{Message: "Unknown users: " + strings.Join(unknown, ", ")}
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	invited, err := db.inviteUsersTx(ctx, logCtx, tx, hostUserId, eventId, existing)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	return invited, nil
+}
+
+func (db *Db) inviteUsersTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, hostUserId string, eventId string, userIds []string) ([]string, error) {
+	var invited []string
+	createdAt := time.Now().UTC()
+	query := `INSERT IGNORE INTO event_invitations (event_id, user_id, invited_by, created_at) VALUES (?, ?, ?, ?)`
+	for _, userId := range userIds {
+		res, err := tx.ExecContext(ctx, query, eventId, userId, hostUserId, createdAt)
+		if err != nil {
+			logCtx.Error("Failed to insert invitation", "error", err, "invitedUserId", userId)
+			return nil, err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to get rows affected")
+		}
+		if rowsAffected > 0 {
+			invited = append(invited, userId)
+		}
+	}
+	return invited, nil
+}
+
+// GetInvitations returns invitations of the event, oldest first
+func (db *Db) GetInvitations(ctx context.Context, eventId string) ([]*api.EventInvitation, error) {
+	logCtx := slog.With("method", "GetInvitations", "eventId", eventId)
+	logCtx.Debug("Getting invitations")
+
+	var rows []EventInvitationRow
+	query := `SELECT event_id, user_id, invited_by, created_at FROM event_invitations WHERE event_id = ? ORDER BY created_at, user_id`
+	if err := db.conn.SelectContext(ctx, &rows, query, eventId); err != nil {
+		logCtx.Error("Failed to get invitations", "error", err)
+		return nil, err
+	}
+
+	invitations := make([]*api.EventInvitation, len(rows))
+	for i := range rows {
+		invitations[i] = rows[i].ToApi()
+	}
+	return invitations, nil
+}
+
+// DeleteInvitation withdraws the user's invitation to the event, join requests already made are kept
+func (db *Db) DeleteInvitation(ctx context.Context, eventId string, userId string) error {
+	logCtx := slog.With("method", "DeleteInvitation", "eventId", eventId, "invitedUserId", userId)
+	logCtx.Debug("Deleting invitation")
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM event_invitations WHERE event_id = ? AND user_id = ?`, eventId, userId)
+	if err != nil {
+		logCtx.Error("Failed to delete invitation", "error", err)
+		return errors.WithMessage(err, "Failed to delete invitation")
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return DbObjectNotFoundError{Message: "Invitation not found"}
+	}
+	return nil
+}
+
+// IsInvited tells whether the host invited the user to the event
+func (db *Db) IsInvited(ctx context.Context, eventId string, userId string) (bool, error) {
+	var count int
+	err := db.conn.GetContext(ctx, &count, `SELECT COUNT(*) FROM event_invitations WHERE event_id = ? AND user_id = ?`, eventId, userId)
+	if err != nil {
+		slog.Error("Failed to check invitation", "method", "IsInvited", "eventId", eventId, "userId", userId, "error", err)
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// confirmableJoinRequestStatuses are the statuses of join requests the host can select when confirming an event
+var confirmableJoinRequestStatuses = []api.JoinRequestStatus{
+	api.JoinRequestStatusWaiting,
+	api.JoinRequestStatusAccepted,
+	api.JoinRequestStatusWaitlisted,
+}
+
+// JoinRequestAnswer describes the host's decision on a join request
+type JoinRequestAnswer struct {
+	JoinRequest *api.JoinRequest
+	// Promotion is set when a rejection freed a place which was taken by a waitlisted join request
+	Promotion *WaitlistPromotion
+}
+
+// RespondJoinRequest accepts or rejects a join request of the host's open event. Accepted requests count towards
+// the capacity of the event and are preselected for its confirmation, the place of a rejected one is taken by the
+// oldest waitlisted request.
+func (db *Db) RespondJoinRequest(ctx context.Context, hostUserId string, eventId string, joinRequestId string, accept bool, message string) (*JoinRequestAnswer, error) {
+	logCtx := slog.With("method", "RespondJoinRequest", "userId", hostUserId, "eventId", eventId, "joinRequestId", joinRequestId, "accept", accept)
+	logCtx.Debug("Responding to join request")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	promotion, err := db.respondJoinRequestTx(ctx, logCtx, tx, hostUserId, eventId, joinRequestId, accept, message)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	joinRequest, err := db.GetJoinRequest(ctx, joinRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JoinRequestAnswer{
+		JoinRequest: joinRequest,
+		Promotion:   promotion,
+	}, nil
+}
+
+func (db *Db) respondJoinRequestTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, hostUserId string, eventId string, joinRequestId string, accept bool, message string) (*WaitlistPromotion, error) {
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return nil, err
+	}
+	if event.UserId != hostUserId {
+		return nil, DbObjectNotFoundError{Message: "Event not found"}
+	}
+	if api.EventStatus(event.Status) != api.EventStatusOpen {
+		return nil, &ValidationError{Message: "Join requests can be accepted or rejected only before the event is confirmed"}
+	}
+
+	var status string
+	err = tx.GetContext(ctx, &status, `SELECT status FROM join_requests WHERE id = ? AND event_id = ? FOR UPDATE`, joinRequestId, eventId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Join request not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to lock join request")
+	}
+
+	current := api.JoinRequestStatus(status)
+	if !slices.Contains(confirmableJoinRequestStatuses, current) && current != api.JoinRequestStatusRejected {
+		return nil, &ValidationError{Message: "Cannot answer " + status + " join request"}
+	}
+
+	var hostMessage *string
+	if message != "" {
+		hostMessage = &message
+	}
+
+	if accept {
+		var accepted int
+		err := tx.GetContext(ctx, &accepted, `SELECT COUNT(*) FROM join_requests WHERE event_id = ? AND status = ? AND id <> ?`,
+			eventId, api.JoinRequestStatusAccepted, joinRequestId)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to count accepted join requests")
+		}
+		if accepted >= event.ExpectedPlayers-1 {
+			return nil, &ValidationError{Message: "All places of the event are already taken by accepted players"}
+		}
+
+		query := `UPDATE join_requests SET is_accepted = true, status = ?, host_message = ?, responded_at = ? WHERE id = ?`
+		logCtx.Debug("Executing SQL query", "query", query)
+		if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusAccepted, hostMessage, time.Now().UTC(), joinRequestId); err != nil {
+			return nil, errors.WithMessage(err, "Failed to accept join request")
+		}
+
+		// accepting a waitlisted request takes a place of a waiting one, which moves to the waitlist
+		if current == api.JoinRequestStatusWaitlisted || current == api.JoinRequestStatusRejected {
+			return nil, db.rebalanceWaitlistTx(ctx, logCtx, tx, event)
+		}
+		return nil, nil
+	}
+
+	query := `UPDATE join_requests SET is_accepted = false, status = ?, host_message = ?, responded_at = ? WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusRejected, hostMessage, time.Now().UTC(), joinRequestId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to reject join request")
+	}
+
+	if current == api.JoinRequestStatusWaiting || current == api.JoinRequestStatusAccepted {
+		return db.promoteFromWaitlistTx(ctx, logCtx, tx, event, nil)
+	}
+	return nil, nil
+}
+
+// rebalanceWaitlistTx moves the newest waiting join request to the waitlist when the open event has more
+// active join requests than places
+func (db *Db) rebalanceWaitlistTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, event *waitlistEventRow) error {
+	query, args, err := sqlx.In(`SELECT COUNT(*) FROM join_requests WHERE event_id = ? AND status IN (?)`,
+		event.Id, []api.JoinRequestStatus{api.JoinRequestStatusWaiting, api.JoinRequestStatusAccepted})
+	if err != nil {
+		return errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+
+	var active int
+	if err := tx.GetContext(ctx, &active, tx.Rebind(query), args...); err != nil {
+		return errors.WithMessage(err, "Failed to count join requests")
+	}
+	if active <= event.ExpectedPlayers-1 {
+		return nil
+	}
+
+	query = `UPDATE join_requests SET status = ? WHERE event_id = ? AND status = ? ORDER BY created_at DESC, id DESC LIMIT 1`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusWaitlisted, event.Id, api.JoinRequestStatusWaiting); err != nil {
+		return errors.WithMessage(err, "Failed to waitlist join request")
+	}
+	return nil
+}
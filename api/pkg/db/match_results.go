@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+const matchResultColumns = `id, event_id, submitted_by, team, opponents, submitter_won, sets, status, dispute_reason, responded_by, created_at, responded_at`
+
+// SubmitMatchResult stores the result of the event's match waiting for the confirmation of the opposing side.
+// team are the submitting player and their partners, opponents the players of the opposing side.
+// A result can be corrected by the submitting side while pending and resubmitted by either side once disputed.
+func (db *Db) SubmitMatchResult(ctx context.Context, eventId string, submittedBy string, team []string, opponents []string, sets []api.SetScore) (*api.MatchResult, error) {
+	logCtx := slog.With("method", "SubmitMatchResult", "eventId", eventId, "userId", submittedBy)
+	logCtx.Debug("Submitting match result")
+
+	row := &MatchResultRow{
+		Id:          uuid.New().String(),
+		EventId:     eventId,
+		SubmittedBy: submittedBy,
+		Team:        team,
+		Opponents:   opponents,
+		Sets:        sets,
+		Status:      string(api.MatchResultStatusPending),
+		CreatedAt:   time.Now().UTC(),
+	}
+	row.SubmitterWon = row.ToApi().PlayerWon()
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.submitMatchResultTx(ctx, logCtx, tx, row); err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	return row.ToApi(), nil
+}
+
+func (db *Db) submitMatchResultTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, row *MatchResultRow) error {
+	var existing MatchResultRow
+	err := tx.GetContext(ctx, &existing, `SELECT `+matchResultColumns+` FROM match_results WHERE event_id = ? FOR UPDATE`, row.EventId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.WithMessage(err, "Failed to lock match result")
+	}
+
+	if err == nil {
+		switch api.MatchResultStatus(existing.Status) {
+		case api.MatchResultStatusConfirmed:
+			return &ValidationError{Message: "Result is already confirmed"}
+		case api.MatchResultStatusPending:
+			if slices.Contains(existing.Opponents, row.SubmittedBy) {
+				return &ValidationError{Message: "Result submitted by the opponents awaits your confirmation"}
+			}
+		}
+		row.Id = existing.Id
+
+		query := `UPDATE match_results
+			SET submitted_by = :submitted_by, team = :team, opponents = :opponents, submitter_won = :submitter_won, sets = :sets,
+				status = :status, dispute_reason = NULL, responded_by = NULL, created_at = :created_at, responded_at = NULL
+			WHERE id = :id`
+		logCtx.Debug("Executing SQL query", "query", query)
+		if _, err := tx.NamedExecContext(ctx, query, row); err != nil {
+			return errors.WithMessage(err, "Failed to update match result")
+		}
+		return nil
+	}
+
+	query := `INSERT INTO match_results (id, event_id, submitted_by, team, opponents, submitter_won, sets, status, created_at)
+		VALUES (:id, :event_id, :submitted_by, :team, :opponents, :submitter_won, :sets, :status, :created_at)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.NamedExecContext(ctx, query, row); err != nil {
+		return errors.WithMessage(err, "Failed to insert match result")
+	}
+	return nil
+}
+
+// RespondMatchResult confirms or disputes the pending result of the event's match on behalf of the opposing side,
+// the response of any of its players settles the result. Confirmed results update ratings of the players.
+func (db *Db) RespondMatchResult(ctx context.Context, eventId string, opponentId string, status api.MatchResultStatus, disputeReason string) (*api.MatchResult, error) {
+	logCtx := slog.With("method", "RespondMatchResult", "eventId", eventId, "userId", opponentId, "status", status)
+	logCtx.Debug("Responding to match result")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	if err := db.respondMatchResultTx(ctx, logCtx, tx, eventId, opponentId, status, disputeReason); err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	return db.GetMatchResult(ctx, eventId)
+}
+
+func (db *Db) respondMatchResultTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, eventId string, opponentId string, status api.MatchResultStatus, disputeReason string) error {
+	var row MatchResultRow
+	err := tx.GetContext(ctx, &row, `SELECT `+matchResultColumns+` FROM match_results WHERE event_id = ? FOR UPDATE`, eventId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DbObjectNotFoundError{Message: "Match result not found"}
+		}
+		return errors.WithMessage(err, "Failed to lock match result")
+	}
+
+	if !slices.Contains(row.Opponents, opponentId) {
+		return &ValidationError{Message: "Only the opponents can respond to the result"}
+	}
+	if api.MatchResultStatus(row.Status) != api.MatchResultStatusPending {
+		return &ValidationError{Message: "Result is not waiting for confirmation"}
+	}
+
+	respondedAt := time.Now().UTC()
+	row.Status = string(status)
+	row.RespondedBy = &opponentId
+	row.RespondedAt = &respondedAt
+	if disputeReason != "" {
+		row.DisputeReason = &disputeReason
+	}
+
+	query := `UPDATE match_results
+		SET status = :status, dispute_reason = :dispute_reason, responded_by = :responded_by, responded_at = :responded_at
+		WHERE id = :id`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.NamedExecContext(ctx, query, &row); err != nil {
+		return errors.WithMessage(err, "Failed to update match result")
+	}
+
+	if status == api.MatchResultStatusConfirmed {
+		return db.applyMatchRatingTx(ctx, logCtx, tx, &row)
+	}
+	return nil
+}
+
+// GetMatchResult returns the result of the event's match or nil when none was submitted
+func (db *Db) GetMatchResult(ctx context.Context, eventId string) (*api.MatchResult, error) {
+	results, err := db.GetMatchResults(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+	return results[eventId], nil
+}
+
+// GetMatchResults returns match results of the events keyed by event id
+func (db *Db) GetMatchResults(ctx context.Context, eventIds ...string) (map[string]*api.MatchResult, error) {
+	logCtx := slog.With("method", "GetMatchResults", "eventIds", eventIds)
+	logCtx.Debug("Getting match results")
+
+	result := make(map[string]*api.MatchResult)
+	if len(eventIds) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT `+matchResultColumns+` FROM match_results WHERE event_id IN (?)`, eventIds)
+	if err != nil {
+		logCtx.Error("Failed to prepare query with IN clause", "error", err)
+		return nil, err
+	}
+
+	var rows []MatchResultRow
+	if err := db.conn.SelectContext(ctx, &rows, db.conn.Rebind(query), args...); err != nil {
+		logCtx.Error("Failed to get match results", "error", err)
+		return nil, err
+	}
+
+	for i := range rows {
+		result[rows[i].EventId] = rows[i].ToApi()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// GetPartnerCards returns all partner cards known to the application
+func (db *Db) GetPartnerCards(ctx context.Context) ([]api.PartnerCard, error) {
+	cards := []api.PartnerCard{}
+	if err := db.conn.SelectContext(ctx, &cards, `SELECT id, name FROM partner_cards ORDER BY name`); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get partner cards")
+	}
+	return cards, nil
+}
+
+// getUserPartnerCards returns ids of the partner cards held by the user
+func (db *Db) getUserPartnerCards(ctx context.Context, userId string) ([]string, error) {
+	cards := []string{}
+	query := `SELECT partner_card_id FROM user_partner_cards WHERE uid = ? ORDER BY partner_card_id`
+	if err := db.conn.SelectContext(ctx, &cards, query, userId); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get partner cards of user")
+	}
+	return cards, nil
+}
+
+// setUserPartnerCardsTx replaces the partner cards held by the user
+func (db *Db) setUserPartnerCardsTx(ctx context.Context, tx *sqlx.Tx, logCtx *slog.Logger, userId string, cardIds []string) error {
+	cardIds = compactIds(cardIds)
+	if len(cardIds) > 0 {
+		query, args, err := sqlx.In(`SELECT id FROM partner_cards WHERE id IN (?)`, cardIds)
+		if err != nil {
+			return errors.WithMessage(err, "Failed to prepare partner cards query")
+		}
+		var known []string
+		if err := tx.SelectContext(ctx, &known, tx.Rebind(query), args...); err != nil {
+			return errors.WithMessage(err, "Failed to get partner cards")
+		}
+		var unknown []string
+		for _, id := range cardIds {
+			if !slices.Contains(known, id) {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			return &ValidationError{Message: "Unknown partner cards: " + strings.Join(unknown, ", ")}
+		}
+	}
+
+	logCtx.Debug("Replacing partner cards of user", "cardIds", cardIds)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_partner_cards WHERE uid = ?`, userId); err != nil {
+		return errors.WithMessage(err, "Failed to delete partner cards of user")
+	}
+	for _, id := range cardIds {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_partner_cards (uid, partner_card_id) VALUES (?, ?)`, userId, id)
+		if err != nil {
+			return errors.WithMessage(err, "Failed to insert partner card of user")
+		}
+	}
+	return nil
+}
+
+// compactIds trims the ids and drops empty and repeated ones
+func compactIds(ids []string) []string {
+	result := []string{}
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// getPartnerCardsByKey runs a query selecting (key, partner_card_id) rows for the keys and groups the cards by key
+func (db *Db) getPartnerCardsByKey(ctx context.Context, query string, keys []string) (map[string][]string, error) {
+	cards := map[string][]string{}
+	if len(keys) == 0 {
+		return cards, nil
+	}
+
+	query, args, err := sqlx.In(query, keys)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare partner cards query")
+	}
+	var rows []struct {
+		Key  string `db:"k"`
+		Card string `db:"partner_card_id"`
+	}
+	if err := db.conn.SelectContext(ctx, &rows, db.conn.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get partner cards")
+	}
+	for _, row := range rows {
+		cards[row.Key] = append(cards[row.Key], row.Card)
+	}
+	return cards, nil
+}
+
+// setPartnerCardLocations sets PartnerCardLocations of the events
+func (db *Db) setPartnerCardLocations(ctx context.Context, events map[string]*api.Event) error {
+	var userIds, locationIds []string
+	for _, event := range events {
+		userIds = append(userIds, eventPlayers(event)...)
+		locationIds = append(locationIds, cardLocations(event)...)
+	}
+
+	userCards, err := db.getPartnerCardsByKey(ctx,
+		`SELECT uid AS k, partner_card_id FROM user_partner_cards WHERE uid IN (?)`, compactIds(userIds))
+	if err != nil {
+		return err
+	}
+	facilityCards, err := db.getPartnerCardsByKey(ctx,
+		`SELECT facility_id AS k, partner_card_id FROM facility_partner_cards WHERE facility_id IN (?)`, compactIds(locationIds))
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.PartnerCardLocations = partnerCardLocations(cardLocations(event), eventPlayers(event), userCards, facilityCards)
+	}
+	return nil
+}
+
+// eventPlayers returns the host and the accepted players of the event
+func eventPlayers(event *api.Event) []string {
+	players := []string{event.UserId}
+	for _, jr := range event.JoinRequests {
+		if jr.Status == api.JoinRequestStatusAccepted {
+			players = append(players, jr.UserId)
+		}
+	}
+	return players
+}
+
+// cardLocations returns the locations where the event may be played, only the confirmed one once it is confirmed
+func cardLocations(event *api.Event) []string {
+	if event.Confirmation != nil {
+		return []string{event.Confirmation.LocationId}
+	}
+	return event.Locations
+}
+
+// partnerCardLocations returns the locations accepting a partner card of every player
+func partnerCardLocations(locations []string, players []string, userCards map[string][]string, facilityCards map[string][]string) []string {
+	accepting := []string{}
+	if len(players) == 0 {
+		return accepting
+	}
+	for _, location := range locations {
+		accepted := !slices.ContainsFunc(players, func(player string) bool {
+			return !slices.ContainsFunc(userCards[player], func(card string) bool {
+				return slices.Contains(facilityCards[location], card)
+			})
+		})
+		if accepted {
+			accepting = append(accepting, location)
+		}
+	}
+	return accepting
+}
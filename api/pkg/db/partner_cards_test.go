@@ -0,0 +1,50 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestPartnerCardLocations(t *testing.T) {
+	userCards := map[string][]string{
+		"host":   {"multisport-plus"},
+		"guest":  {"medicover", "multisport-classic"},
+		"nocard": nil,
+	}
+	facilityCards := map[string][]string{
+		"matchpoint":       {"multisport-plus"},
+		"spartan-pultuska": {"multisport-plus", "medicover"},
+		"krzycka-park":     {"multisport-plus", "multisport-classic"},
+	}
+
+	assert.Equal(t, []string{"spartan-pultuska", "krzycka-park"},
+		partnerCardLocations([]string{"spartan-pultuska", "krzycka-park"}, []string{"host", "guest"}, userCards, facilityCards))
+	assert.Equal(t, []string{"spartan-pultuska"},
+		partnerCardLocations([]string{"spartan-pultuska", "matchpoint"}, []string{"host", "guest"}, userCards, facilityCards), "guest has no card of matchpoint")
+	assert.Equal(t, []string{"matchpoint"}, partnerCardLocations([]string{"matchpoint"}, []string{"host"}, userCards, facilityCards))
+	assert.Empty(t, partnerCardLocations([]string{"matchpoint"}, []string{"host", "nocard"}, userCards, facilityCards), "player without a card")
+	assert.Empty(t, partnerCardLocations([]string{"unknown"}, []string{"host"}, userCards, facilityCards))
+	assert.Equal(t, []string{}, partnerCardLocations(nil, []string{"host"}, userCards, facilityCards))
+}
+
+func TestEventPlayersAndCardLocations(t *testing.T) {
+	event := &api.Event{
+		EventData: api.EventData{UserId: "host", Locations: []string{"matchpoint", "krzycka-park"}},
+		JoinRequests: []*api.JoinRequest{
+			{UserId: "accepted", Status: api.JoinRequestStatusAccepted},
+			{UserId: "waiting", Status: api.JoinRequestStatusWaitlisted},
+		},
+	}
+	assert.Equal(t, []string{"host", "accepted"}, eventPlayers(event))
+	assert.Equal(t, []string{"matchpoint", "krzycka-park"}, cardLocations(event))
+
+	event.Confirmation = &api.Confirmation{LocationId: "krzycka-park"}
+	assert.Equal(t, []string{"krzycka-park"}, cardLocations(event))
+}
+
+func TestCompactIds(t *testing.T) {
+	assert.Equal(t, []string{"medicover", "multisport"}, compactIds([]string{" medicover", "", "multisport", "medicover"}))
+	assert.Equal(t, []string{}, compactIds(nil))
+}
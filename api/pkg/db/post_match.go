@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// SubmitFeedback stores the feedback of a player about another player of the completed event,
+// an earlier feedback of the player about the same player is replaced
+func (db *Db) SubmitFeedback(ctx context.Context, eventId string, fromUserId string, toUserId string, rating int, comment string) (*api.PlayerFeedback, error) {
+	logCtx := slog.With("method", "SubmitFeedback", "eventId", eventId, "userId", fromUserId, "toUserId", toUserId)
+	logCtx.Debug("Submitting feedback")
+
+	row := &PlayerFeedbackRow{
+		EventId:    eventId,
+		FromUserId: fromUserId,
+		ToUserId:   toUserId,
+		Rating:     rating,
+		Comment:    optionalText(comment),
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	query := `INSERT INTO player_feedback (event_id, from_user_id, to_user_id, rating, comment, created_at)
+		VALUES (:event_id, :from_user_id, :to_user_id, :rating, :comment, :created_at)
+		ON DUPLICATE KEY UPDATE rating = VALUES(rating), comment = VALUES(comment), created_at = VALUES(created_at)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := db.conn.NamedExecContext(ctx, query, row); err != nil {
+		return nil, errors.WithMessage(err, "Failed to store feedback")
+	}
+
+	return row.ToApi(), nil
+}
+
+// ReportNoShow records that a player of the completed event did not show up,
+// an earlier report of the same player about them is replaced
+func (db *Db) ReportNoShow(ctx context.Context, eventId string, reportedBy string, userId string, comment string) (*api.NoShowReport, error) {
+	logCtx := slog.With("method", "ReportNoShow", "eventId", eventId, "userId", reportedBy, "noShowUserId", userId)
+	logCtx.Debug("Reporting no-show")
+
+	row := &NoShowReportRow{
+		EventId:    eventId,
+		ReportedBy: reportedBy,
+		UserId:     userId,
+		Comment:    optionalText(comment),
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	query := `INSERT INTO no_show_reports (event_id, reported_by, user_id, comment, created_at)
+		VALUES (:event_id, :reported_by, :user_id, :comment, :created_at)
+		ON DUPLICATE KEY UPDATE comment = VALUES(comment), created_at = VALUES(created_at)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := db.conn.NamedExecContext(ctx, query, row); err != nil {
+		return nil, errors.WithMessage(err, "Failed to store no-show report")
+	}
+
+	return row.ToApi(), nil
+}
+
+// optionalText stores empty texts as NULL
+func optionalText(text string) *string {
+	if text == "" {
+		return nil
+	}
+	return &text
+}
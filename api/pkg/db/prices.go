@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+type priceRuleRow struct {
+	CourtGroupId  int             `db:"court_group_id"`
+	Surface       string          `db:"surface"`
+	Type          string          `db:"type"`
+	PricePeriodId sql.NullInt64   `db:"price_period_id"`
+	ValidFrom     sql.NullTime    `db:"valid_from"`
+	ValidTo       sql.NullTime    `db:"valid_to"`
+	DayPattern    sql.NullString  `db:"day_pattern"`
+	TimeStart     sql.NullInt64   `db:"time_start"`
+	TimeEnd       sql.NullInt64   `db:"time_end"`
+	Price         sql.NullFloat64 `db:"price"`
+}
+
+// GetPriceList returns the price periods and rules of all court groups of the facility, expressed in the time zone
+// of its country. Holidays of the country are not attached.
+func (db *Db) GetPriceList(ctx context.Context, facilityId string) (*pricing.PriceList, error) {
+	logCtx := slog.With("method", "GetPriceList", "facilityId", facilityId)
+
+	country, err := db.GetFacilityCountry(ctx, facilityId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT cg.id AS court_group_id, cg.surface, cg.type,
+			pp.id AS price_period_id, pp.valid_from, pp.valid_to,
+			pr.day_pattern, TIME_TO_SEC(pr.time_start) DIV 60 AS time_start, TIME_TO_SEC(pr.time_end) DIV 60 AS time_end, pr.price
+		FROM court_groups cg
+		LEFT JOIN price_periods pp ON pp.court_group_id = cg.id
+		LEFT JOIN price_rules pr ON pr.price_period_id = pp.id
+		WHERE cg.facility_id = ?
+		ORDER BY cg.id, pp.id, pr.id`
+	logCtx.Debug("Executing SQL query", "query", query)
+	var rows []priceRuleRow
+	if err := db.conn.SelectContext(ctx, &rows, query, facilityId); err != nil {
+		logCtx.Error("Failed to get price rules", "error", err)
+		return nil, errors.WithMessage(err, "Failed to get price rules")
+	}
+
+	priceList := &pricing.PriceList{
+		FacilityId: facilityId,
+		Country:    country,
+		Location:   pricing.TimeZone(country),
+	}
+	var group *pricing.CourtGroup
+	var period *pricing.Period
+	var periodId int64
+	for _, row := range rows {
+		if group == nil || group.Id != row.CourtGroupId {
+			priceList.CourtGroups = append(priceList.CourtGroups, pricing.CourtGroup{
+				Id:      row.CourtGroupId,
+				Surface: row.Surface,
+				Type:    row.Type,
+			})
+			group = &priceList.CourtGroups[len(priceList.CourtGroups)-1]
+			period = nil
+		}
+		if !row.PricePeriodId.Valid {
+			continue
+		}
+		if period == nil || periodId != row.PricePeriodId.Int64 {
+			group.Periods = append(group.Periods, pricing.Period{
+				ValidFrom: row.ValidFrom.Time,
+				ValidTo:   row.ValidTo.Time,
+			})
+			period = &group.Periods[len(group.Periods)-1]
+			periodId = row.PricePeriodId.Int64
+		}
+		if !row.DayPattern.Valid {
+			continue
+		}
+		period.Rules = append(period.Rules, pricing.Rule{
+			DayPattern: normalizeDayPattern(row.DayPattern.String),
+			Start:      int(row.TimeStart.Int64),
+			End:        int(row.TimeEnd.Int64),
+			Price:      row.Price.Float64,
+		})
+	}
+
+	return priceList, nil
+}
+
+// normalizeDayPattern maps the Saturday pattern some seeded price lists use to the documented one
+func normalizeDayPattern(pattern string) string {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "sa" {
+		return pricing.DayPatternSaturday
+	}
+	return pattern
+}
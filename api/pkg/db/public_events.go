@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// PublicEventsFilter narrows down the list of public events returned by GetPublicEvents.
+// Zero values mean "no filter".
+type PublicEventsFilter struct {
+	SkillLevels []api.SkillLevel
+	EventType   api.EventType
+	FacilityIds []string
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	Near        *GeoRadius
+	Text        string
+	Limit       int
+	After       *EventsCursor
+	// FavouritesFirst lists events at favourite facilities of the user before the others
+	FavouritesFirst bool
+}
+
+// EventsCursor points to the last event of a page. Events at favourite facilities come first,
+// then events are ordered by creation time and id, both descending.
+type EventsCursor struct {
+	Favourite bool
+	CreatedAt time.Time
+	Id        string
+}
+
+// favouriteCursorPrefix marks cursors pointing to an event at a favourite facility, cursors without it
+// were issued before favourites existed and stay valid
+const favouriteCursorPrefix = "f|"
+
+// Encode returns an opaque string representation of the cursor
+func (c EventsCursor) Encode() string {
+	raw := fmt.Sprintf("%d|%s", c.CreatedAt.UTC().Unix(), c.Id)
+	if c.Favourite {
+		raw = favouriteCursorPrefix + raw
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeEventsCursor parses a cursor produced by EventsCursor.Encode
+func DecodeEventsCursor(s string) (*EventsCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	rest, favourite := strings.CutPrefix(string(raw), favouriteCursorPrefix)
+	parts := strings.SplitN(rest, "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var unix int64
+	if _, err := fmt.Sscanf(parts[0], "%d", &unix); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &EventsCursor{Favourite: favourite, CreatedAt: time.Unix(unix, 0).UTC(), Id: parts[1]}, nil
+}
+
+// buildPublicEventsWhere returns the WHERE clause (without cursor) shared by the page and count queries
+func buildPublicEventsWhere(userId string, filter *PublicEventsFilter, now time.Time) (string, []interface{}) {
+	conds := []string{"e.user_id <> ?", "e.visibility = ?", "e.status = ?", "e.expiration_time > ?"}
+	args := []interface{}{userId, api.EventVisibilityPublic, api.EventStatusOpen, now}
+
+	if len(filter.SkillLevels) > 0 {
+		conds = append(conds, "e.skill_level IN (?)")
+		args = append(args, filter.SkillLevels)
+	}
+
+	if filter.EventType != "" {
+		conds = append(conds, "e.event_type = ?")
+		args = append(args, filter.EventType)
+	}
+
+	if len(filter.FacilityIds) > 0 {
+		conds = append(conds, "EXISTS (SELECT 1 FROM event_locations el WHERE el.event_id = e.id AND el.location_id IN (?))")
+		args = append(args, filter.FacilityIds)
+	}
+
+	if filter.DateFrom != nil || filter.DateTo != nil {
+		slotConds := []string{"ts.event_id = e.id"}
+		if filter.DateFrom != nil {
+			slotConds = append(slotConds, "ts.dt >= ?")
+			args = append(args, *filter.DateFrom)
+		}
+		if filter.DateTo != nil {
+			slotConds = append(slotConds, "ts.dt < ?")
+			args = append(args, *filter.DateTo)
+		}
+		conds = append(conds, "EXISTS (SELECT 1 FROM event_time_slots ts WHERE "+strings.Join(slotConds, " AND ")+")")
+	}
+
+	if filter.Near != nil {
+		geoCond, geoArgs := geoRadiusCondition("f.location", *filter.Near)
+		conds = append(conds, "EXISTS (SELECT 1 FROM event_locations el INNER JOIN facilities f ON f.id = el.location_id WHERE el.event_id = e.id AND "+geoCond+")")
+		args = append(args, geoArgs...)
+	}
+
+	if filter.Text != "" {
+		conds = append(conds, "e.description LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Text)+"%")
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+// buildPublicEventsPage returns the query of a page of public events matching the WHERE clause. It selects
+// one more event than the page size to tell whether there is a next page.
+func buildPublicEventsPage(userId string, where string, args []interface{}, filter *PublicEventsFilter) (string, []interface{}) {
+	favourite := "FALSE"
+	pageArgs := []interface{}{}
+	if filter.FavouritesFirst {
+		favourite = "EXISTS (SELECT 1 FROM event_locations fel INNER JOIN user_favourite_facilities uf ON uf.facility_id = fel.location_id WHERE fel.event_id = e.id AND uf.uid = ?)"
+		pageArgs = append(pageArgs, userId)
+	}
+	pageArgs = append(pageArgs, args...)
+
+	query := "SELECT p.id, p.created_at, p.favourite FROM (SELECT e.id, e.created_at, " + favourite + " AS favourite FROM events e WHERE " + where + ") p"
+	if filter.After != nil {
+		olderCond := "(p.created_at < ? OR (p.created_at = ? AND p.id < ?))"
+		if filter.After.Favourite {
+			query += " WHERE (p.favourite = FALSE OR " + olderCond + ")"
+		} else {
+			query += " WHERE p.favourite = FALSE AND " + olderCond
+		}
+		pageArgs = append(pageArgs, filter.After.CreatedAt, filter.After.CreatedAt, filter.After.Id)
+	}
+
+	query += " ORDER BY p.favourite DESC, p.created_at DESC, p.id DESC LIMIT ?"
+	pageArgs = append(pageArgs, filter.Limit+1)
+	return query, pageArgs
+}
+
+// escapeLike escapes LIKE wildcards so that user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// GetPublicEvents returns a page of open public events not owned by the user that match the filter,
+// the total number of matching events and the cursor of the next page (nil if this is the last page)
+func (db *Db) GetPublicEvents(ctx context.Context, userId string, filter *PublicEventsFilter) ([]*api.Event, int, *EventsCursor, error) {
+	logCtx := slog.With("method", "GetPublicEvents", "userId", userId)
+	logCtx.Debug("Getting public events", "filter", filter)
+
+	where, args := buildPublicEventsWhere(userId, filter, time.Now().UTC())
+
+	countQuery, countArgs, err := sqlx.In("SELECT COUNT(*) FROM events e WHERE "+where, args...)
+	if err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to prepare count query")
+	}
+	countQuery = db.conn.Rebind(countQuery)
+
+	var total int
+	logCtx.Debug("Executing SQL query", "query", countQuery, "params", countArgs)
+	if err := db.conn.GetContext(ctx, &total, countQuery, countArgs...); err != nil {
+		logCtx.Error("Failed to count public events", "error", err)
+		return nil, 0, nil, err
+	}
+
+	pageQuery, pageArgs := buildPublicEventsPage(userId, where, args, filter)
+	pageQuery, pageArgs, err = sqlx.In(pageQuery, pageArgs...)
+	if err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to prepare page query")
+	}
+	pageQuery = db.conn.Rebind(pageQuery)
+
+	var page []struct {
+		Id        string    `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+		Favourite bool      `db:"favourite"`
+	}
+	logCtx.Debug("Executing SQL query", "query", pageQuery, "params", pageArgs)
+	if err := db.conn.SelectContext(ctx, &page, pageQuery, pageArgs...); err != nil {
+		logCtx.Error("Failed to get public events page", "error", err)
+		return nil, 0, nil, err
+	}
+
+	var next *EventsCursor
+	if len(page) > filter.Limit {
+		page = page[:filter.Limit]
+		last := page[len(page)-1]
+		next = &EventsCursor{Favourite: last.Favourite, CreatedAt: last.CreatedAt, Id: last.Id}
+	}
+
+	if len(page) == 0 {
+		return []*api.Event{}, total, nil, nil
+	}
+
+	eventIds := make([]string, len(page))
+	for i, p := range page {
+		eventIds[i] = p.Id
+	}
+
+	events, err := db.getEventsInternal(ctx, "event_id IN (?)", eventIds)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// getEventsInternal does not keep the order, restore the page order
+	byId := make(map[string]*api.Event, len(events))
+	for _, e := range events {
+		byId[e.Id] = e
+	}
+	result := make([]*api.Event, 0, len(eventIds))
+	for _, id := range eventIds {
+		if e, ok := byId[id]; ok {
+			result = append(result, e)
+		}
+	}
+
+	return result, total, next, nil
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestEventsCursor_RoundTrip(t *testing.T) {
+	cursor := EventsCursor{
+		CreatedAt: time.Date(2025, 6, 1, 10, 30, 15, 0, time.UTC),
+		Id:        "0b7a3c1e-5d0e-4c2f-9a55-1f3e1f0c8d11",
+	}
+
+	decoded, err := DecodeEventsCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor.Id, decoded.Id)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+}
+
+func TestEventsCursor_Favourite(t *testing.T) {
+	cursor := EventsCursor{Favourite: true, CreatedAt: time.Date(2025, 6, 1, 10, 30, 15, 0, time.UTC), Id: "event-1"}
+
+	decoded, err := DecodeEventsCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, decoded.Favourite)
+	assert.Equal(t, "event-1", decoded.Id)
+
+	// cursors issued before favourites point to other events
+	decoded, err = DecodeEventsCursor("MTc0ODc3MzgxNXxldmVudC0x")
+	require.NoError(t, err)
+	assert.False(t, decoded.Favourite)
+	assert.Equal(t, "event-1", decoded.Id)
+}
+
+func TestDecodeEventsCursor_Invalid(t *testing.T) {
+	for _, c := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", "MTIzfA"} {
+		_, err := DecodeEventsCursor(c)
+		assert.Error(t, err, "cursor %q should be rejected", c)
+	}
+}
+
+func TestBuildPublicEventsWhere_NoFilters(t *testing.T) {
+	now := time.Now()
+	where, args := buildPublicEventsWhere("user-1", &PublicEventsFilter{}, now)
+
+	assert.Equal(t, "e.user_id <> ? AND e.visibility = ? AND e.status = ? AND e.expiration_time > ?", where)
+	assert.Equal(t, []interface{}{"user-1", api.EventVisibilityPublic, api.EventStatusOpen, now}, args)
+}
+
+func TestBuildPublicEventsWhere_AllFilters(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	filter := &PublicEventsFilter{
+		SkillLevels: []api.SkillLevel{api.SkillLevelBeginner, api.SkillLevelIntermediate},
+		EventType:   api.ActivityTypeMatch,
+		FacilityIds: []string{"matchpoint"},
+		DateFrom:    &from,
+		DateTo:      &to,
+		Near:        &GeoRadius{Latitude: 51.1, Longitude: 17.03, RadiusKm: 5},
+		Text:        "50%_off",
+	}
+
+	where, args := buildPublicEventsWhere("user-1", filter, time.Now())
+
+	assert.Contains(t, where, "e.skill_level IN (?)")
+	assert.Contains(t, where, "e.event_type = ?")
+	assert.Contains(t, where, "el.location_id IN (?)")
+	assert.Contains(t, where, "ts.dt >= ? AND ts.dt < ?")
+	assert.Contains(t, where, "MBRContains(ST_GeomFromText(?), f.location)")
+	assert.Contains(t, where, "e.description LIKE ?")
+	assert.Equal(t, strings.Count(where, "?"), len(args))
+	assert.Equal(t, `%50\%\_off%`, args[len(args)-1])
+}
+
+func TestGeoRadius_BoundingBoxContainsRadius(t *testing.T) {
+	g := GeoRadius{Latitude: 51.1, Longitude: 17.03, RadiusKm: 10}
+	wkt := g.BoundingBoxWkt()
+
+	assert.True(t, strings.HasPrefix(wkt, "POLYGON(("))
+	// 10 km is roughly 0.09 degrees of latitude and 0.14 degrees of longitude at this latitude
+	assert.Contains(t, wkt, "16.886")
+	assert.Contains(t, wkt, "51.010")
+	assert.Equal(t, "POINT(17.030000 51.100000)", g.CenterWkt())
+	assert.Equal(t, 10000.0, g.RadiusMeters())
+}
+
+func TestBuildPublicEventsPage(t *testing.T) {
+	where, args := buildPublicEventsWhere("user-1", &PublicEventsFilter{}, time.Now())
+
+	query, pageArgs := buildPublicEventsPage("user-1", where, args, &PublicEventsFilter{Limit: 10})
+	assert.Contains(t, query, "FALSE AS favourite")
+	assert.NotContains(t, query, "p.created_at <")
+	assert.Equal(t, append(args, 11), pageArgs)
+
+	createdAt := time.Date(2025, 6, 1, 10, 30, 15, 0, time.UTC)
+	filter := &PublicEventsFilter{Limit: 10, FavouritesFirst: true, After: &EventsCursor{Favourite: true, CreatedAt: createdAt, Id: "event-1"}}
+	query, pageArgs = buildPublicEventsPage("user-1", where, args, filter)
+	assert.Contains(t, query, "uf.uid = ?")
+	assert.Contains(t, query, "WHERE (p.favourite = FALSE OR (p.created_at < ?")
+	assert.Contains(t, query, "ORDER BY p.favourite DESC, p.created_at DESC, p.id DESC")
+	assert.Equal(t, strings.Count(query, "?"), len(pageArgs))
+	assert.Equal(t, "user-1", pageArgs[0])
+
+	filter.After.Favourite = false
+	query, _ = buildPublicEventsPage("user-1", where, args, filter)
+	assert.Contains(t, query, "WHERE p.favourite = FALSE AND (p.created_at < ?")
+}
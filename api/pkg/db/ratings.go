@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/rating"
+)
+
+// RatingTrendMatches is the number of latest rated matches the rating trend is computed over
+const RatingTrendMatches = 5
+
+type playerRatingRow struct {
+	UserId        string  `db:"user_id"`
+	Rating        float64 `db:"rating"`
+	MatchesPlayed int     `db:"matches_played"`
+}
+
+type ratingHistoryRow struct {
+	UserId        string    `db:"user_id"`
+	MatchResultId string    `db:"match_result_id"`
+	RatingBefore  float64   `db:"rating_before"`
+	RatingAfter   float64   `db:"rating_after"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// GetPlayerRating returns the current rating of the user or nil when the user has no rated matches
+func (db *Db) GetPlayerRating(ctx context.Context, userId string) (*api.PlayerRating, error) {
+	logCtx := slog.With("method", "GetPlayerRating", "userId", userId)
+	logCtx.Debug("Getting player rating")
+
+	var row playerRatingRow
+	err := db.conn.GetContext(ctx, &row, `SELECT user_id, rating, matches_played FROM player_ratings WHERE user_id = ?`, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logCtx.Error("Failed to get player rating", "error", err)
+		return nil, err
+	}
+
+	var changes []float64
+	query := `SELECT rating_after - rating_before FROM rating_history WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ?`
+	if err := db.conn.SelectContext(ctx, &changes, query, userId, RatingTrendMatches); err != nil {
+		logCtx.Error("Failed to get rating history", "error", err)
+		return nil, err
+	}
+
+	trend := 0.0
+	for _, c := range changes {
+		trend += c
+	}
+
+	return &api.PlayerRating{
+		Rating:        int(math.Round(row.Rating)),
+		MatchesPlayed: row.MatchesPlayed,
+		Provisional:   row.MatchesPlayed < rating.ProvisionalMatches,
+		Trend:         int(math.Round(trend)),
+	}, nil
+}
+
+// RecalculateRatings rebuilds ratings and their history by replaying all confirmed match results in order.
+// Returns the number of replayed results.
+func (db *Db) RecalculateRatings(ctx context.Context) (int, error) {
+	logCtx := slog.With("method", "RecalculateRatings")
+	logCtx.Info("Recalculating player ratings")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	count, err := db.recalculateRatingsTx(ctx, logCtx, tx)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return 0, err
+	}
+
+	logCtx.Info("Player ratings recalculated", "results", count)
+	return count, nil
+}
+
+func (db *Db) recalculateRatingsTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx) (int, error) {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rating_history`); err != nil {
+		return 0, errors.WithMessage(err, "Failed to delete rating history")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM player_ratings`); err != nil {
+		return 0, errors.WithMessage(err, "Failed to delete player ratings")
+	}
+
+	var results []MatchResultRow
+	query := `SELECT ` + matchResultColumns + ` FROM match_results WHERE status = ? ORDER BY responded_at, created_at, id`
+	if err := tx.SelectContext(ctx, &results, query, api.MatchResultStatusConfirmed); err != nil {
+		return 0, errors.WithMessage(err, "Failed to get confirmed match results")
+	}
+
+	for i := range results {
+		if err := db.applyMatchRatingTx(ctx, logCtx, tx, &results[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(results), nil
+}
+
+// applyMatchRatingTx updates ratings of all players of the confirmed result and records the changes in the history.
+// Doubles are rated by team.
+func (db *Db) applyMatchRatingTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, result *MatchResultRow) error {
+	winnerIds, loserIds := result.Winners(), result.Losers()
+	if len(winnerIds) == 0 || len(loserIds) == 0 {
+		return nil
+	}
+
+	players, err := db.lockPlayerRatingsTx(ctx, tx, append(slices.Clone(winnerIds), loserIds...)...)
+	if err != nil {
+		return err
+	}
+
+	winnersBefore, losersBefore := make([]rating.Player, len(winnerIds)), make([]rating.Player, len(loserIds))
+	for i, userId := range winnerIds {
+		winnersBefore[i] = players[userId]
+	}
+	for i, userId := range loserIds {
+		losersBefore[i] = players[userId]
+	}
+	winnersAfter, losersAfter := rating.UpdateTeams(winnersBefore, losersBefore)
+
+	ratedAt := result.CreatedAt
+	if result.RespondedAt != nil {
+		ratedAt = *result.RespondedAt
+	}
+
+	userIds := append(slices.Clone(winnerIds), loserIds...)
+	before := append(winnersBefore, losersBefore...)
+	after := append(winnersAfter, losersAfter...)
+
+	query := `INSERT INTO player_ratings (user_id, rating, matches_played, updated_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE rating = VALUES(rating), matches_played = VALUES(matches_played), updated_at = VALUES(updated_at)`
+	history := make([]ratingHistoryRow, len(userIds))
+	for i, userId := range userIds {
+		if _, err := tx.ExecContext(ctx, query, userId, after[i].Rating, after[i].MatchesPlayed, ratedAt); err != nil {
+			return errors.WithMessage(err, "Failed to save player rating")
+		}
+		history[i] = ratingHistoryRow{UserId: userId, MatchResultId: result.Id, RatingBefore: before[i].Rating, RatingAfter: after[i].Rating, CreatedAt: ratedAt}
+	}
+
+	query = `INSERT INTO rating_history (user_id, match_result_id, rating_before, rating_after, created_at)
+		VALUES (:user_id, :match_result_id, :rating_before, :rating_after, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, history); err != nil {
+		return errors.WithMessage(err, "Failed to insert rating history")
+	}
+
+	logCtx.Debug("Ratings updated", "matchResultId", result.Id, "winners", winnerIds, "losers", loserIds)
+	return nil
+}
+
+// lockPlayerRatingsTx returns current ratings of the users, players without rated matches are seeded from their NTRP level
+func (db *Db) lockPlayerRatingsTx(ctx context.Context, tx *sqlx.Tx, userIds ...string) (map[string]rating.Player, error) {
+	query, args, err := sqlx.In(`SELECT user_id, rating, matches_played FROM player_ratings WHERE user_id IN (?) FOR UPDATE`, userIds)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+
+	var rows []playerRatingRow
+	if err := tx.SelectContext(ctx, &rows, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to lock player ratings")
+	}
+
+	players := make(map[string]rating.Player, len(userIds))
+	for _, row := range rows {
+		players[row.UserId] = rating.Player{Rating: row.Rating, MatchesPlayed: row.MatchesPlayed}
+	}
+
+	for _, userId := range userIds {
+		if _, ok := players[userId]; ok {
+			continue
+		}
+
+		var ntrpLevel float64
+		err := tx.GetContext(ctx, &ntrpLevel, `SELECT COALESCE(ntrp_level, 0) FROM user_pref WHERE uid = ?`, userId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithMessage(err, "Failed to get NTRP level")
+		}
+		players[userId] = rating.Player{Rating: rating.InitialRating(ntrpLevel)}
+	}
+
+	return players, nil
+}
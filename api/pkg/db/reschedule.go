@@ -0,0 +1,240 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// RescheduleEvent moves the confirmed event of the host to another location and time. The current confirmation is
+// kept as a superseded version and the new one gets the next version number, which accepted players have to
+// acknowledge again. An event whose court reservation failed is confirmed again with its players. The court price is
+// replaced by the one of the new session. Returns the court booked for the previous version, if any, which the caller
+// releases at the reservation system.
+func (db *Db) RescheduleEvent(ctx context.Context, userId string, eventId string, locationId string, dt time.Time, reason string, courtPrice *float64) (*api.Confirmation, *CourtBooking, error) {
+	logCtx := slog.With("method", "RescheduleEvent", "userId", userId, "eventId", eventId)
+	logCtx.Debug("Rescheduling event")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	confirmation, players, booking, err := db.rescheduleEventTx(ctx, logCtx, tx, userId, eventId, locationId, dt, reason, courtPrice)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, nil, err
+	}
+
+	result := confirmation.ToApi()
+	if courtPrice != nil {
+		result.CourtCost = splitCourtCost(*courtPrice, players)
+	}
+	return result, booking, nil
+}
+
+// rescheduleEventTx returns the new confirmation version, the number of its accepted players and the court booked
+// for the previous version
+func (db *Db) rescheduleEventTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, userId string, eventId string, locationId string, dt time.Time, reason string, courtPrice *float64) (*ConfirmationRow, int, *CourtBooking, error) {
+	// the event is locked first, the same order as when cancelling join requests
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if event.UserId != userId {
+		return nil, 0, nil, DbObjectNotFoundError{Message: "Event not found"}
+	}
+	status := api.EventStatus(event.Status)
+	if status != api.EventStatusConfirmed && status != api.EventStatusReservationFailed {
+		return nil, 0, nil, &ValidationError{Message: "Only confirmed events can be rescheduled"}
+	}
+
+	var current ConfirmationRow
+	err = tx.GetContext(ctx, &current, `SELECT id, event_id, location_id, dt, version, created_at, reservation_status, court_number
+		FROM confirmations WHERE event_id = ? FOR UPDATE`, eventId)
+	if err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to get confirmation")
+	}
+	if status == api.EventStatusConfirmed && current.LocationId == locationId && current.Dt.Equal(dt) {
+		return nil, 0, nil, &ValidationError{Message: "Event is already confirmed at this location and time"}
+	}
+
+	bookings, err := db.courtBookingsTx(ctx, logCtx, tx, []string{eventId})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	now := time.Now().UTC()
+	query := `INSERT INTO confirmation_versions (confirmation_id, version, location_id, dt, reason, created_at, superseded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	logCtx.Debug("Executing SQL query", "query", query)
+	_, err = tx.ExecContext(ctx, query, current.Id, current.Version, current.LocationId, current.Dt, sql.NullString{String: reason, Valid: reason != ""}, current.CreatedAt, now)
+	if err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to keep confirmation version")
+	}
+
+	// the court of the new version has not been booked yet
+	query = `UPDATE confirmations SET location_id = ?, dt = ?, version = version + 1, created_at = ?,
+		reservation_status = ?, court_number = NULL, reservation_ref = NULL, reservation_error = NULL, court_price = ?
+		WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err = tx.ExecContext(ctx, query, locationId, dt, now, api.ReservationStatusNotRequired, courtPrice, current.Id); err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to update confirmation")
+	}
+
+	if status == api.EventStatusReservationFailed {
+		if _, err = tx.ExecContext(ctx, `UPDATE events SET status = ? WHERE id = ?`, api.EventStatusConfirmed, eventId); err != nil {
+			return nil, 0, nil, errors.WithMessage(err, "Failed to update event status")
+		}
+		query = `UPDATE join_requests SET status = ? WHERE event_id = ? AND status = ?`
+		if _, err = tx.ExecContext(ctx, query, api.JoinRequestStatusAccepted, eventId, api.JoinRequestStatusReservationFailed); err != nil {
+			return nil, 0, nil, errors.WithMessage(err, "Failed to update join requests")
+		}
+	}
+
+	current.LocationId = locationId
+	current.Dt = dt
+	current.Version++
+	current.CreatedAt = now
+	current.ReservationStatus = string(api.ReservationStatusNotRequired)
+	current.CourtNumber = sql.NullString{}
+
+	var players int
+	query = `SELECT COUNT(*) FROM join_requests WHERE event_id = ? AND status = ?`
+	if err = tx.GetContext(ctx, &players, query, eventId, api.JoinRequestStatusAccepted); err != nil {
+		return nil, 0, nil, errors.WithMessage(err, "Failed to count accepted join requests")
+	}
+	return &current, players, bookings[eventId], nil
+}
+
+// AcknowledgeReschedule records that the accepted player of the event agrees with the latest confirmation version.
+// Players who do not agree cancel their join request instead.
+func (db *Db) AcknowledgeReschedule(ctx context.Context, userId string, eventId string) error {
+	logCtx := slog.With("method", "AcknowledgeReschedule", "userId", userId, "eventId", eventId)
+	logCtx.Debug("Acknowledging rescheduled event")
+
+	var row struct {
+		Id      string        `db:"id"`
+		Version sql.NullInt32 `db:"version"`
+	}
+	query := `SELECT jr.id, c.version FROM join_requests jr
+		LEFT JOIN confirmations c ON c.event_id = jr.event_id
+		WHERE jr.event_id = ? AND jr.user_id = ? AND jr.status = ?`
+	err := db.conn.GetContext(ctx, &row, query, eventId, userId, api.JoinRequestStatusAccepted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DbObjectNotFoundError{Message: "Accepted join request not found"}
+		}
+		return errors.WithMessage(err, "Failed to get join request")
+	}
+	if !row.Version.Valid {
+		return &ValidationError{Message: "Event is not confirmed"}
+	}
+
+	query = `UPDATE join_requests SET acknowledged_version = ? WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query, "params", row.Id)
+	if _, err := db.conn.ExecContext(ctx, query, row.Version.Int32, row.Id); err != nil {
+		return errors.WithMessage(err, "Failed to acknowledge confirmation")
+	}
+
+	return nil
+}
+
+// RescheduleReminder is an accepted player of a rescheduled event who has not acknowledged its latest version
+type RescheduleReminder struct {
+	JoinRequestId string    `db:"join_request_id"`
+	UserId        string    `db:"user_id"`
+	EventId       string    `db:"event_id"`
+	HostUserId    string    `db:"host_user_id"`
+	LocationId    string    `db:"location_id"`
+	DateTime      time.Time `db:"dt"`
+	Version       int       `db:"version"`
+}
+
+// MarkRescheduleReminders returns accepted players of confirmed events starting within lead who have not
+// acknowledged the latest version of the confirmation, and records that they were reminded so that each version
+// is reminded once. Returns at most limit of them.
+func (db *Db) MarkRescheduleReminders(ctx context.Context, limit int, lead time.Duration) ([]RescheduleReminder, error) {
+	logCtx := slog.With("method", "MarkRescheduleReminders")
+	logCtx.Debug("Marking reschedule reminders", "limit", limit)
+
+	now := time.Now().UTC()
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		logCtx.Error("Failed to begin transaction", "error", err)
+		return nil, err
+	}
+
+	// players confirmed with the first version acknowledged it when they joined
+	query := `
+		SELECT jr.id AS join_request_id, jr.user_id, e.id AS event_id, e.user_id AS host_user_id,
+			c.location_id, c.dt, c.version
+		FROM join_requests jr
+		INNER JOIN events e ON e.id = jr.event_id
+		INNER JOIN confirmations c ON c.event_id = e.id
+		WHERE e.status = ?
+		  AND jr.status = ?
+		  AND c.version > GREATEST(COALESCE(jr.acknowledged_version, 1), COALESCE(jr.reminded_version, 1))
+		  AND c.dt > ?
+		  AND c.dt <= ?
+		LIMIT ?
+		FOR UPDATE
+	`
+
+	var reminders []RescheduleReminder
+	err = tx.SelectContext(ctx, &reminders, query, api.EventStatusConfirmed, api.JoinRequestStatusAccepted, now, now.Add(lead), limit)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		logCtx.Error("Failed to select reschedule reminders", "error", err)
+		return nil, err
+	}
+
+	if len(reminders) == 0 {
+		db.rollback(logCtx, tx)
+		return nil, nil
+	}
+
+	for _, reminder := range reminders {
+		_, err := tx.ExecContext(ctx, `UPDATE join_requests SET reminded_version = ? WHERE id = ?`, reminder.Version, reminder.JoinRequestId)
+		if err != nil {
+			db.rollback(logCtx, tx)
+			logCtx.Error("Failed to mark reschedule reminder", "error", err, "joinRequestId", reminder.JoinRequestId)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	logCtx.Debug("Marked reschedule reminders", "count", len(reminders))
+	return reminders, nil
+}
+
+// awaitingAcknowledgement returns user ids of the accepted players who have not acknowledged the latest version of
+// the confirmation. Players confirmed with the first version acknowledged it when they joined.
+func awaitingAcknowledgement(confirmation *api.Confirmation, joinRequests []*api.JoinRequest) []string {
+	var awaiting []string
+	for _, jr := range joinRequests {
+		if jr.Status != api.JoinRequestStatusAccepted {
+			continue
+		}
+		if max(jr.AcknowledgedVersion, 1) < confirmation.Version && !slices.Contains(awaiting, jr.UserId) {
+			awaiting = append(awaiting, jr.UserId)
+		}
+	}
+	return awaiting
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func TestAwaitingAcknowledgement(t *testing.T) {
+	joinRequests := []*api.JoinRequest{
+		{UserId: "first-version", Status: api.JoinRequestStatusAccepted},
+		{UserId: "acknowledged", Status: api.JoinRequestStatusAccepted, AcknowledgedVersion: 2},
+		{UserId: "outdated", Status: api.JoinRequestStatusAccepted, AcknowledgedVersion: 1},
+		{UserId: "waitlisted", Status: api.JoinRequestStatusWaitlisted},
+		{UserId: "cancelled", Status: api.JoinRequestStatusCancelled},
+	}
+
+	t.Run("FirstVersion", func(tt *testing.T) {
+		assert.Nil(tt, awaitingAcknowledgement(&api.Confirmation{Version: 1}, joinRequests))
+	})
+
+	t.Run("Rescheduled", func(tt *testing.T) {
+		awaiting := awaitingAcknowledgement(&api.Confirmation{Version: 2}, joinRequests)
+		assert.Equal(tt, []string{"first-version", "outdated"}, awaiting)
+	})
+}
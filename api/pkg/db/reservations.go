@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// CourtBooking is a court booked in the reservation system of the facility for a confirmation
+type CourtBooking struct {
+	FacilityId  string `db:"location_id"`
+	CourtNumber string `db:"court_number"`
+	Reference   string `db:"reservation_ref"`
+}
+
+// StartReservation moves the reservation of the confirmation version from NOT_REQUIRED to PENDING.
+// Returns false when the event is not confirmed anymore or was rescheduled to another version.
+func (db *Db) StartReservation(ctx context.Context, eventId string, version int) (bool, error) {
+	logCtx := slog.With("method", "StartReservation", "eventId", eventId, "version", version)
+
+	query := `UPDATE confirmations c
+		JOIN events e ON e.id = c.event_id
+		SET c.reservation_status = ?, c.reservation_error = NULL
+		WHERE c.event_id = ? AND c.version = ? AND c.reservation_status = ? AND e.status = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, api.ReservationStatusPending, eventId, version,
+		api.ReservationStatusNotRequired, api.EventStatusConfirmed)
+	if err != nil {
+		return false, errors.WithMessage(err, "Failed to start reservation")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.WithMessage(err, "Failed to get affected rows")
+	}
+	return affected > 0, nil
+}
+
+// CompleteReservation records the court booked for the pending reservation of the confirmation version.
+// Returns false when the reservation is not pending anymore, the booking is then no longer needed.
+func (db *Db) CompleteReservation(ctx context.Context, eventId string, version int, courtNumber string, reference string) (bool, error) {
+	logCtx := slog.With("method", "CompleteReservation", "eventId", eventId, "version", version)
+
+	query := `UPDATE confirmations c
+		JOIN events e ON e.id = c.event_id
+		SET c.reservation_status = ?, c.court_number = ?, c.reservation_ref = ?
+		WHERE c.event_id = ? AND c.version = ? AND c.reservation_status = ? AND e.status = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	result, err := db.conn.ExecContext(ctx, query, api.ReservationStatusReserved, courtNumber, reference,
+		eventId, version, api.ReservationStatusPending, api.EventStatusConfirmed)
+	if err != nil {
+		return false, errors.WithMessage(err, "Failed to complete reservation")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.WithMessage(err, "Failed to get affected rows")
+	}
+	return affected > 0, nil
+}
+
+// FailReservation marks the pending reservation of the confirmation version as failed and moves the event and its
+// accepted join requests to RESERVATION_FAILED. Returns false when the reservation is not pending anymore.
+func (db *Db) FailReservation(ctx context.Context, eventId string, version int, reason string) (bool, error) {
+	logCtx := slog.With("method", "FailReservation", "eventId", eventId, "version", version)
+	logCtx.Debug("Failing reservation")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	failed, err := db.failReservationTx(ctx, logCtx, tx, eventId, version, reason)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return false, err
+	}
+
+	return failed, nil
+}
+
+// courtBookingsTx returns the courts booked for the confirmations of the events, they have to be released at the
+// reservation system once the events are cancelled or moved
+func (db *Db) courtBookingsTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, eventIds []string) (map[string]*CourtBooking, error) {
+	query, args, err := sqlx.In(`SELECT event_id, location_id, COALESCE(court_number, '') AS court_number, reservation_ref
+		FROM confirmations WHERE event_id IN (?) AND reservation_status = ? AND reservation_ref IS NOT NULL FOR UPDATE`,
+		eventIds, api.ReservationStatusReserved)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to prepare query with IN clause")
+	}
+
+	var rows []struct {
+		EventId string `db:"event_id"`
+		CourtBooking
+	}
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if err := tx.SelectContext(ctx, &rows, tx.Rebind(query), args...); err != nil {
+		return nil, errors.WithMessage(err, "Failed to get court bookings")
+	}
+
+	bookings := make(map[string]*CourtBooking, len(rows))
+	for _, row := range rows {
+		bookings[row.EventId] = &row.CourtBooking
+	}
+	return bookings, nil
+}
+
+func (db *Db) failReservationTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, eventId string, version int, reason string) (bool, error) {
+	// the event is locked first, the same order as when rescheduling it
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return false, err
+	}
+	if api.EventStatus(event.Status) != api.EventStatusConfirmed {
+		return false, nil
+	}
+
+	var status string
+	query := `SELECT reservation_status FROM confirmations WHERE event_id = ? AND version = ? FOR UPDATE`
+	if err := tx.GetContext(ctx, &status, query, eventId, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.WithMessage(err, "Failed to lock confirmation")
+	}
+	if api.ReservationStatus(status) != api.ReservationStatusPending {
+		return false, nil
+	}
+
+	query = `UPDATE confirmations SET reservation_status = ?, reservation_error = ? WHERE event_id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, api.ReservationStatusFailed, reason, eventId); err != nil {
+		return false, errors.WithMessage(err, "Failed to fail reservation")
+	}
+
+	query = `UPDATE events SET status = ? WHERE id = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, api.EventStatusReservationFailed, eventId); err != nil {
+		return false, errors.WithMessage(err, "Failed to update event status")
+	}
+
+	query = `UPDATE join_requests SET status = ? WHERE event_id = ? AND status = ?`
+	logCtx.Debug("Executing SQL query", "query", query)
+	if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusReservationFailed, eventId, api.JoinRequestStatusAccepted); err != nil {
+		return false, errors.WithMessage(err, "Failed to update join requests")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+type EventSeriesRow struct {
+	Id          string     `db:"id"`
+	UserId      string     `db:"user_id"`
+	Frequency   string     `db:"frequency"`
+	UntilDt     *time.Time `db:"until_dt"`
+	Occurrences *int       `db:"occurrences"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// CreateEventSeries creates a series and one event per occurrence in a single transaction.
+// Every occurrence is a copy of event with its own time slots. The first occurrence is written back to event.
+// Returns the id of the series and the created occurrences in series order.
+func (db *Db) CreateEventSeries(ctx context.Context, event *api.EventData, occurrences [][]time.Time) (string, []api.EventData, error) {
+	logCtx := slog.With("method", "CreateEventSeries", "userId", event.UserId)
+	logCtx.Debug("Creating event series", "occurrences", len(occurrences))
+
+	if event.Recurrence == nil {
+		return "", nil, &ValidationError{Message: "Recurrence is required for a series"}
+	}
+
+	series := EventSeriesRow{
+		Id:        uuid.New().String(),
+		UserId:    event.UserId,
+		Frequency: string(event.Recurrence.Frequency),
+		CreatedAt: time.Now(),
+	}
+	if event.Recurrence.Count > 0 {
+		series.Occurrences = &event.Recurrence.Count
+	} else {
+		until, err := time.Parse(time.RFC3339, event.Recurrence.Until)
+		if err != nil {
+			return "", nil, &ValidationError{Message: "Invalid recurrence until date"}
+		}
+		series.UntilDt = &until
+	}
+	logCtx = logCtx.With("seriesId", series.Id)
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		logCtx.Error("Failed to begin transaction", "error", err)
+		return "", nil, err
+	}
+
+	query := `INSERT INTO event_series (id, user_id, frequency, until_dt, occurrences, created_at)
+		VALUES (:id, :user_id, :frequency, :until_dt, :occurrences, :created_at)`
+	logCtx.Debug("Executing SQL query", "query", query, "params", series)
+	if _, err := tx.NamedExecContext(ctx, query, series); err != nil {
+		db.rollback(logCtx, tx)
+		logCtx.Error("Failed to insert event series", "error", err)
+		return "", nil, err
+	}
+
+	created := make([]api.EventData, len(occurrences))
+	for i, timeSlots := range occurrences {
+		occurrence := *event
+		occurrence.TimeSlots = api.DtToIsoArray(timeSlots)
+		index := i + 1
+
+		if err := db.insertEvent(ctx, logCtx, tx, &occurrence, &series.Id, &index); err != nil {
+			db.rollback(logCtx, tx)
+			return "", nil, errors.WithMessagef(err, "Failed to insert occurrence %d", index)
+		}
+		created[i] = occurrence
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return "", nil, err
+	}
+
+	*event = created[0]
+	return series.Id, created, nil
+}
+
+// GetOpenSeriesEventIds returns ids of the series occurrences that are still open and not expired, in series order
+func (db *Db) GetOpenSeriesEventIds(ctx context.Context, userId string, seriesId string) ([]string, error) {
+	logCtx := slog.With("method", "GetOpenSeriesEventIds", "userId", userId, "seriesId", seriesId)
+	logCtx.Debug("Getting open series events")
+
+	query := `SELECT id FROM events
+		WHERE series_id = ? AND user_id = ? AND status = ? AND expiration_time > ?
+		ORDER BY series_index`
+	var ids []string
+	logCtx.Debug("Executing SQL query", "query", query)
+	if err := db.conn.SelectContext(ctx, &ids, query, seriesId, userId, api.EventStatusOpen, time.Now().UTC()); err != nil {
+		logCtx.Error("Failed to get open series events", "error", err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetEventsByIds returns events with the given ids, newest first
+func (db *Db) GetEventsByIds(ctx context.Context, eventIds []string) ([]*api.Event, error) {
+	if len(eventIds) == 0 {
+		return nil, nil
+	}
+	return db.getEventsInternal(ctx, "event_id IN (?)", eventIds)
+}
+
+// UpdateEventsDetails changes the editable details of the user's open events
+func (db *Db) UpdateEventsDetails(ctx context.Context, userId string, eventIds []string, data *api.SeriesEventData) error {
+	logCtx := slog.With("method", "UpdateEventsDetails", "userId", userId, "eventIds", eventIds)
+	logCtx.Debug("Updating events details")
+
+	query, args, err := sqlx.In(`UPDATE events
+		SET skill_level = ?, description = ?, expected_players = ?, session_duration = ?
+		WHERE id IN (?) AND user_id = ? AND status = ?`,
+		data.SkillLevel, data.Description, data.ExpectedPlayers, data.SessionDuration,
+		eventIds, userId, api.EventStatusOpen)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to prepare update query")
+	}
+	query = db.conn.Rebind(query)
+
+	logCtx.Debug("Executing SQL query", "query", query, "params", args)
+	if _, err := db.conn.ExecContext(ctx, query, args...); err != nil {
+		logCtx.Error("Failed to update events", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+DROP INDEX idx_event_time_slots_event_dt ON event_time_slots;
+
+ALTER TABLE facilities DROP INDEX idx_location;
+ALTER TABLE facilities MODIFY COLUMN location POINT NOT NULL;
+ALTER TABLE facilities ADD SPATIAL INDEX idx_location (location);
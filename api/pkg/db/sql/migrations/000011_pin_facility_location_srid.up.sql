@@ -0,0 +1,11 @@
+-- Seeded facilities were stored with SRID 4326 while user-suggested ones use SRID 0.
+-- Bring every point to SRID 0 (x = longitude, y = latitude) and pin the column to it,
+-- otherwise MySQL ignores idx_location for MBR predicates.
+UPDATE facilities SET location = ST_SRID(location, 0) WHERE ST_SRID(location) <> 0;
+
+ALTER TABLE facilities DROP INDEX idx_location;
+ALTER TABLE facilities MODIFY COLUMN location POINT NOT NULL SRID 0;
+ALTER TABLE facilities ADD SPATIAL INDEX idx_location (location);
+
+-- Speeds up date range filtering of public events
+CREATE INDEX idx_event_time_slots_event_dt ON event_time_slots(event_id, dt);
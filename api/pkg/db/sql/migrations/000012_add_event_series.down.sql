@@ -0,0 +1,7 @@
+ALTER TABLE events DROP FOREIGN KEY fk_events_series;
+DROP INDEX idx_events_series ON events;
+ALTER TABLE events
+    DROP COLUMN series_index,
+    DROP COLUMN series_id;
+
+DROP TABLE IF EXISTS event_series;
@@ -0,0 +1,16 @@
+-- Recurring events are materialised as regular events linked to a series
+CREATE TABLE IF NOT EXISTS event_series (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    frequency ENUM('WEEKLY', 'BIWEEKLY') NOT NULL,
+    until_dt DATETIME NULL,
+    occurrences INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE events
+    ADD COLUMN series_id VARCHAR(36) NULL,
+    ADD COLUMN series_index INT NULL,
+    ADD CONSTRAINT fk_events_series FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_events_series ON events(series_id, series_index);
@@ -0,0 +1,5 @@
+ALTER TABLE join_requests DROP COLUMN status;
+
+ALTER TABLE events
+    DROP COLUMN cancelled_at,
+    DROP COLUMN cancel_reason;
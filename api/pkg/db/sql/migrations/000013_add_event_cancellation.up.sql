@@ -0,0 +1,15 @@
+-- Cancelled events are kept together with their chat history, the reason is shown to joiners
+ALTER TABLE events
+    ADD COLUMN cancel_reason TEXT NULL,
+    ADD COLUMN cancelled_at TIMESTAMP NULL;
+
+-- Join request lifecycle, is_accepted is kept in sync for existing queries
+ALTER TABLE join_requests
+    ADD COLUMN status ENUM('WAITING', 'ACCEPTED', 'REJECTED', 'CANCELLED', 'RESERVATION_FAILED') NOT NULL DEFAULT 'WAITING';
+
+UPDATE join_requests
+SET status = CASE
+    WHEN is_accepted = TRUE THEN 'ACCEPTED'
+    WHEN is_accepted = FALSE THEN 'REJECTED'
+    ELSE 'WAITING'
+END;
@@ -0,0 +1,5 @@
+DROP INDEX idx_confirmations_dt ON confirmations;
+
+ALTER TABLE events
+    DROP COLUMN post_match_until,
+    DROP COLUMN completed_at;
@@ -0,0 +1,7 @@
+-- Confirmed events are completed once the session is over, which opens the post-match window
+-- for results, feedback and no-show reports
+ALTER TABLE events
+    ADD COLUMN completed_at TIMESTAMP NULL,
+    ADD COLUMN post_match_until TIMESTAMP NULL;
+
+CREATE INDEX idx_confirmations_dt ON confirmations(dt);
@@ -0,0 +1,16 @@
+-- Set-by-set result of a completed match, submitted by one player and confirmed or disputed by the opponent.
+-- Sets are stored from the point of view of the submitting player.
+CREATE TABLE IF NOT EXISTS match_results (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    submitted_by VARCHAR(36) NOT NULL,
+    opponent_id VARCHAR(36) NOT NULL,
+    winner_id VARCHAR(36) NOT NULL,
+    sets JSON NOT NULL,
+    status ENUM('PENDING', 'CONFIRMED', 'DISPUTED') NOT NULL DEFAULT 'PENDING',
+    dispute_reason TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP NULL,
+    UNIQUE KEY uq_match_results_event (event_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
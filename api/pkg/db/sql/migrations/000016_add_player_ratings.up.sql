@@ -0,0 +1,21 @@
+-- Current Elo rating of a player, computed from confirmed match results
+CREATE TABLE IF NOT EXISTS player_ratings (
+    user_id VARCHAR(36) PRIMARY KEY,
+    rating DOUBLE NOT NULL,
+    matches_played INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(uid) ON DELETE CASCADE
+);
+
+-- Rating change of a player caused by a confirmed match result
+CREATE TABLE IF NOT EXISTS rating_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    match_result_id VARCHAR(36) NOT NULL,
+    rating_before DOUBLE NOT NULL,
+    rating_after DOUBLE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_rating_history_user (user_id, created_at),
+    FOREIGN KEY (user_id) REFERENCES users(uid) ON DELETE CASCADE,
+    FOREIGN KEY (match_result_id) REFERENCES match_results(id) ON DELETE CASCADE
+);
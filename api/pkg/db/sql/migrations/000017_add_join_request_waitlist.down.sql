@@ -0,0 +1,4 @@
+UPDATE join_requests SET status = 'WAITING' WHERE status = 'WAITLISTED';
+
+ALTER TABLE join_requests
+    MODIFY COLUMN status ENUM('WAITING', 'ACCEPTED', 'REJECTED', 'CANCELLED', 'RESERVATION_FAILED') NOT NULL DEFAULT 'WAITING';
@@ -0,0 +1,3 @@
+-- Join requests beyond the event capacity are queued on the waitlist in order of creation
+ALTER TABLE join_requests
+    MODIFY COLUMN status ENUM('WAITING', 'ACCEPTED', 'REJECTED', 'CANCELLED', 'RESERVATION_FAILED', 'WAITLISTED') NOT NULL DEFAULT 'WAITING';
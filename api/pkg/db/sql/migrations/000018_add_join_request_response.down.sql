@@ -0,0 +1,3 @@
+ALTER TABLE join_requests
+    DROP COLUMN responded_at,
+    DROP COLUMN host_message;
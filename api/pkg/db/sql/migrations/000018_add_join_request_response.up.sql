@@ -0,0 +1,4 @@
+-- Optional message of the host accepting or rejecting a join request before the event is confirmed
+ALTER TABLE join_requests
+    ADD COLUMN host_message TEXT NULL,
+    ADD COLUMN responded_at TIMESTAMP NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_team_players;
+
+ALTER TABLE events DROP COLUMN format;
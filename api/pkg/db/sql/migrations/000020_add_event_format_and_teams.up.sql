@@ -0,0 +1,16 @@
+-- Game format of the event, existing events with two players are singles and with four players doubles,
+-- the player count was the only way to ask for doubles before
+ALTER TABLE events
+    ADD COLUMN format ENUM('SINGLE', 'DOUBLES', 'CUSTOM') NOT NULL DEFAULT 'CUSTOM' AFTER event_type;
+
+UPDATE events SET format = 'SINGLE' WHERE expected_players = 2;
+UPDATE events SET format = 'DOUBLES' WHERE expected_players = 4;
+
+-- Team assignments recorded by the host when confirming doubles and custom events
+CREATE TABLE IF NOT EXISTS event_team_players (
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    team TINYINT UNSIGNED NOT NULL COMMENT 'Team number starting from 1',
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE join_requests DROP COLUMN acknowledged_version;
+
+DROP TABLE IF EXISTS confirmation_versions;
+
+ALTER TABLE confirmations DROP COLUMN version;
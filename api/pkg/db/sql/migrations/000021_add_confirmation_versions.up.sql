@@ -0,0 +1,20 @@
+-- Rescheduling a confirmed event bumps the version of its confirmation, calendars use it as the ICS SEQUENCE
+ALTER TABLE confirmations
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER dt;
+
+-- Superseded versions of a confirmation
+CREATE TABLE IF NOT EXISTS confirmation_versions (
+    confirmation_id VARCHAR(36) NOT NULL,
+    version INT UNSIGNED NOT NULL,
+    location_id VARCHAR(36) NOT NULL,
+    dt DATETIME NOT NULL,
+    reason TEXT NULL COMMENT 'Reason the host gave for moving away from this version',
+    created_at DATETIME NOT NULL,
+    superseded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (confirmation_id, version),
+    FOREIGN KEY (confirmation_id) REFERENCES confirmations(id) ON DELETE CASCADE
+);
+
+-- Latest confirmation version the accepted player acknowledged, players confirmed with the first version acknowledged it by joining
+ALTER TABLE join_requests
+    ADD COLUMN acknowledged_version INT UNSIGNED NULL AFTER confirmation_id;
@@ -0,0 +1,5 @@
+ALTER TABLE confirmations
+    DROP COLUMN reservation_error,
+    DROP COLUMN reservation_ref,
+    DROP COLUMN court_number,
+    DROP COLUMN reservation_status;
@@ -0,0 +1,7 @@
+-- Court booking made through the reservation provider of the confirmed facility.
+-- NOT_REQUIRED when the facility has no provider and the host books the court themselves
+ALTER TABLE confirmations
+    ADD COLUMN reservation_status ENUM('NOT_REQUIRED', 'PENDING', 'RESERVED', 'FAILED') NOT NULL DEFAULT 'NOT_REQUIRED' AFTER version,
+    ADD COLUMN court_number VARCHAR(64) NULL AFTER reservation_status,
+    ADD COLUMN reservation_ref VARCHAR(255) NULL COMMENT 'Booking id in the external reservation system' AFTER court_number,
+    ADD COLUMN reservation_error TEXT NULL AFTER reservation_ref;
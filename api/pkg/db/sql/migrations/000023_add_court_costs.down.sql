@@ -0,0 +1,3 @@
+ALTER TABLE join_requests DROP COLUMN paid_at;
+
+ALTER TABLE confirmations DROP COLUMN court_price;
@@ -0,0 +1,7 @@
+-- Court price of the confirmed session, NULL when the facility publishes no price for it.
+-- The host pays the court and the other players pay their share back to the host
+ALTER TABLE confirmations
+    ADD COLUMN court_price DECIMAL(10, 2) NULL AFTER reservation_error;
+
+ALTER TABLE join_requests
+    ADD COLUMN paid_at TIMESTAMP NULL COMMENT 'When the host marked the share of the court cost as paid';
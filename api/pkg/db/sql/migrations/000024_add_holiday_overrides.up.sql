@@ -0,0 +1,11 @@
+-- Admin corrections of the public holidays embedded in the application per country.
+-- is_holiday = false removes an embedded holiday, true adds a missing one
+CREATE TABLE holiday_overrides (
+    country VARCHAR(3) NOT NULL COMMENT 'Two letter country code',
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    is_holiday BOOLEAN NOT NULL,
+    updated_by VARCHAR(36) NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (country, date)
+);
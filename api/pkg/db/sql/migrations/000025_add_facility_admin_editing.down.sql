@@ -0,0 +1,6 @@
+UPDATE facilities SET source = 'seed' WHERE source = 'admin';
+
+ALTER TABLE facilities
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at,
+    MODIFY COLUMN source ENUM('seed', 'user') NOT NULL DEFAULT 'seed';
@@ -0,0 +1,6 @@
+-- Facilities can be created by admins, court groups and prices are edited through the admin API.
+-- created_at was expected by the admin facility list but never added.
+ALTER TABLE facilities
+    MODIFY COLUMN source ENUM('seed', 'user', 'admin') NOT NULL DEFAULT 'seed',
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;
@@ -0,0 +1,8 @@
+-- Partner cards held by players, locations accepting them are easier to agree on
+CREATE TABLE IF NOT EXISTS user_partner_cards (
+    uid VARCHAR(36) NOT NULL,
+    partner_card_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (uid, partner_card_id),
+    FOREIGN KEY (uid) REFERENCES users(uid) ON DELETE CASCADE,
+    FOREIGN KEY (partner_card_id) REFERENCES partner_cards(id) ON DELETE CASCADE
+);
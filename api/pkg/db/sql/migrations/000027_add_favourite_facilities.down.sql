@@ -0,0 +1,5 @@
+ALTER TABLE user_pref
+    DROP FOREIGN KEY fk_user_pref_home_facility,
+    DROP COLUMN home_facility_id;
+
+DROP TABLE IF EXISTS user_favourite_facilities;
@@ -0,0 +1,14 @@
+-- Facilities a player usually plays at, they are suggested when creating events and their events are listed first
+CREATE TABLE IF NOT EXISTS user_favourite_facilities (
+    uid VARCHAR(36) NOT NULL,
+    facility_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uid, facility_id),
+    FOREIGN KEY (uid) REFERENCES users(uid) ON DELETE CASCADE,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+-- Home facility of the player, always one of the favourites
+ALTER TABLE user_pref
+    ADD COLUMN home_facility_id VARCHAR(36) NULL,
+    ADD CONSTRAINT fk_user_pref_home_facility FOREIGN KEY (home_facility_id) REFERENCES facilities(id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+ALTER TABLE match_results
+    ADD COLUMN opponent_id VARCHAR(36) NULL AFTER submitted_by,
+    ADD COLUMN winner_id VARCHAR(36) NULL AFTER opponent_id;
+
+UPDATE match_results
+SET opponent_id = JSON_UNQUOTE(JSON_EXTRACT(opponents, '$[0]')),
+    winner_id = IF(submitter_won, submitted_by, JSON_UNQUOTE(JSON_EXTRACT(opponents, '$[0]')));
+
+ALTER TABLE match_results
+    MODIFY opponent_id VARCHAR(36) NOT NULL,
+    MODIFY winner_id VARCHAR(36) NOT NULL,
+    DROP COLUMN team,
+    DROP COLUMN opponents,
+    DROP COLUMN submitter_won,
+    DROP COLUMN responded_by;
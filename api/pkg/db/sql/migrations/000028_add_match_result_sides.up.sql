@@ -0,0 +1,19 @@
+-- Results record both sides of the match so that doubles are reported per team.
+-- Any player of the opposing side confirms or disputes the result.
+ALTER TABLE match_results
+    ADD COLUMN team JSON NULL AFTER submitted_by,
+    ADD COLUMN opponents JSON NULL AFTER team,
+    ADD COLUMN submitter_won BOOLEAN NOT NULL DEFAULT FALSE AFTER opponents,
+    ADD COLUMN responded_by VARCHAR(36) NULL AFTER dispute_reason;
+
+UPDATE match_results
+SET team = JSON_ARRAY(submitted_by),
+    opponents = JSON_ARRAY(opponent_id),
+    submitter_won = (winner_id = submitted_by),
+    responded_by = IF(responded_at IS NULL, NULL, opponent_id);
+
+ALTER TABLE match_results
+    MODIFY team JSON NOT NULL,
+    MODIFY opponents JSON NOT NULL,
+    DROP COLUMN opponent_id,
+    DROP COLUMN winner_id;
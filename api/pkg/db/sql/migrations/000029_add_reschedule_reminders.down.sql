@@ -0,0 +1 @@
+ALTER TABLE join_requests DROP COLUMN reminded_version;
@@ -0,0 +1,3 @@
+-- Latest confirmation version the accepted player was reminded to acknowledge, each version is reminded once
+ALTER TABLE join_requests
+    ADD COLUMN reminded_version INT UNSIGNED NULL AFTER acknowledged_version;
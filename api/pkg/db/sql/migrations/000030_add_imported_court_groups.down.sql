@@ -0,0 +1 @@
+ALTER TABLE court_groups DROP COLUMN imported;
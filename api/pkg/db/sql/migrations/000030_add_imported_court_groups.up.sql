@@ -0,0 +1,9 @@
+-- Court groups read from an import file, a later import replaces only these and keeps the ones edited by admins.
+-- Groups of seeded facilities came from the seed migrations or earlier imports.
+ALTER TABLE court_groups
+    ADD COLUMN imported BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE court_groups cg
+    JOIN facilities f ON f.id = cg.facility_id
+SET cg.imported = TRUE
+WHERE f.source = 'seed';
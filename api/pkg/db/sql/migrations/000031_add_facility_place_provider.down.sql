@@ -0,0 +1,7 @@
+DROP INDEX idx_facilities_place ON facilities;
+
+ALTER TABLE facilities
+    DROP COLUMN place_provider,
+    RENAME COLUMN place_id TO google_place_id;
+
+CREATE UNIQUE INDEX idx_facilities_google_place_id ON facilities(google_place_id);
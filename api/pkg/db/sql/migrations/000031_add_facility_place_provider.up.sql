@@ -0,0 +1,10 @@
+-- Places come from Google or from an OpenStreetMap extract, the id is unique per provider
+DROP INDEX idx_facilities_google_place_id ON facilities;
+
+ALTER TABLE facilities
+    RENAME COLUMN google_place_id TO place_id,
+    ADD COLUMN place_provider VARCHAR(16) NULL AFTER added_by;
+
+UPDATE facilities SET place_provider = IF(place_id LIKE 'osm-%', 'osm', 'google') WHERE place_id IS NOT NULL;
+
+CREATE UNIQUE INDEX idx_facilities_place ON facilities(place_provider, place_id);
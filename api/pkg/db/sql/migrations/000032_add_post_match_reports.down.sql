@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS no_show_reports;
+
+DROP TABLE IF EXISTS player_feedback;
@@ -0,0 +1,22 @@
+-- Feedback players leave for each other and reports of players who did not show up, both accepted while the
+-- post-match window of a completed event is open. A player can change their report until the window closes
+CREATE TABLE IF NOT EXISTS player_feedback (
+    event_id VARCHAR(36) NOT NULL,
+    from_user_id VARCHAR(36) NOT NULL,
+    to_user_id VARCHAR(36) NOT NULL,
+    rating TINYINT NOT NULL COMMENT 'From 1 to 5',
+    comment TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, from_user_id, to_user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS no_show_reports (
+    event_id VARCHAR(36) NOT NULL,
+    reported_by VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL COMMENT 'Player who did not show up',
+    comment TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, reported_by, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type EventTeamPlayerRow struct {
+	EventId string `db:"event_id"`
+	UserId  string `db:"user_id"`
+	Team    int    `db:"team"`
+}
+
+// insertEventTeamsTx records the players of each team, teams are numbered from 1 in the given order
+func (db *Db) insertEventTeamsTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, eventId string, teams [][]string) error {
+	if len(teams) == 0 {
+		return nil
+	}
+
+	rows := []EventTeamPlayerRow{}
+	for i, team := range teams {
+		for _, userId := range team {
+			rows = append(rows, EventTeamPlayerRow{EventId: eventId, UserId: userId, Team: i + 1})
+		}
+	}
+
+	query := `INSERT INTO event_team_players (event_id, user_id, team) VALUES (:event_id, :user_id, :team)`
+	logCtx.Debug("Executing SQL query", "query", query, "params", rows)
+	if _, err := tx.NamedExecContext(ctx, query, rows); err != nil {
+		logCtx.Error("Failed to insert event teams", "error", err)
+		return errors.WithMessage(err, "Failed to insert event teams")
+	}
+	return nil
+}
+
+// replaceTeamPlayerTx gives the team place of a player who left a confirmed event to the player promoted instead,
+// the place is removed when nobody was promoted
+func (db *Db) replaceTeamPlayerTx(ctx context.Context, tx *sqlx.Tx, eventId string, userId string, promotedUserId string) error {
+	if promotedUserId == "" {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM event_team_players WHERE event_id = ? AND user_id = ?`, eventId, userId); err != nil {
+			return errors.WithMessage(err, "Failed to remove team player")
+		}
+		return nil
+	}
+
+	query := `UPDATE event_team_players SET user_id = ? WHERE event_id = ? AND user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, promotedUserId, eventId, userId); err != nil {
+		return errors.WithMessage(err, "Failed to replace team player")
+	}
+	return nil
+}
+
+// GetEventTeams returns user ids of the players of each team by event id, events without teams are omitted
+func (db *Db) GetEventTeams(ctx context.Context, eventIds ...string) (map[string][][]string, error) {
+	logCtx := slog.With("method", "GetEventTeams", "eventIds", eventIds)
+
+	result := map[string][][]string{}
+	if len(eventIds) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT event_id, user_id, team FROM event_team_players WHERE event_id IN (?) ORDER BY event_id, team, user_id`, eventIds)
+	if err != nil {
+		logCtx.Error("Failed to prepare query with IN clause", "error", err)
+		return nil, err
+	}
+
+	var rows []EventTeamPlayerRow
+	if err := db.conn.SelectContext(ctx, &rows, db.conn.Rebind(query), args...); err != nil {
+		logCtx.Error("Failed to get event teams", "error", err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		teams := result[row.EventId]
+		for len(teams) < row.Team {
+			teams = append(teams, []string{})
+		}
+		teams[row.Team-1] = append(teams[row.Team-1], row.UserId)
+		result[row.EventId] = teams
+	}
+	return result, nil
+}
@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// WaitlistPromotion describes a waitlisted join request which took the place of a cancelled one
+type WaitlistPromotion struct {
+	EventId       string
+	HostUserId    string
+	JoinRequestId string
+	UserId        string
+	// Accepted is true when the event is confirmed and the promoted player joins the confirmed session,
+	// otherwise the join request waits for the host's confirmation
+	Accepted   bool
+	LocationId string
+	DateTime   time.Time
+}
+
+type waitlistEventRow struct {
+	Id              string `db:"id"`
+	UserId          string `db:"user_id"`
+	Status          string `db:"status"`
+	ExpectedPlayers int    `db:"expected_players"`
+}
+
+type waitlistJoinRequestRow struct {
+	Id     string `db:"id"`
+	UserId string `db:"user_id"`
+}
+
+func (db *Db) lockWaitlistEventTx(ctx context.Context, tx *sqlx.Tx, eventId string) (*waitlistEventRow, error) {
+	var event waitlistEventRow
+	err := tx.GetContext(ctx, &event, `SELECT id, user_id, status, expected_players FROM events WHERE id = ? FOR UPDATE`, eventId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Event not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to lock event")
+	}
+	return &event, nil
+}
+
+// newJoinRequestStatusTx locks the event and returns the status of a new join request to it:
+// requests beyond the capacity of an open event and requests to a confirmed event are waitlisted
+func (db *Db) newJoinRequestStatusTx(ctx context.Context, tx *sqlx.Tx, eventId string) (api.JoinRequestStatus, error) {
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return "", err
+	}
+
+	switch api.EventStatus(event.Status) {
+	case api.EventStatusOpen:
+		query, args, err := sqlx.In(`SELECT COUNT(*) FROM join_requests WHERE event_id = ? AND status IN (?)`,
+			eventId, []api.JoinRequestStatus{api.JoinRequestStatusWaiting, api.JoinRequestStatusAccepted})
+		if err != nil {
+			return "", errors.WithMessage(err, "Failed to prepare query with IN clause")
+		}
+
+		var active int
+		if err := tx.GetContext(ctx, &active, tx.Rebind(query), args...); err != nil {
+			return "", errors.WithMessage(err, "Failed to count join requests")
+		}
+
+		if active >= event.ExpectedPlayers-1 {
+			return api.JoinRequestStatusWaitlisted, nil
+		}
+		return api.JoinRequestStatusWaiting, nil
+	case api.EventStatusConfirmed:
+		return api.JoinRequestStatusWaitlisted, nil
+	default:
+		return "", &ValidationError{Message: "Cannot join " + event.Status + " event"}
+	}
+}
+
+// CancelJoinRequest withdraws the user's join request. The place it frees is taken by the oldest waitlisted
+// request: it waits for the host's confirmation on an open event and is accepted on a confirmed one if it
+// fits the confirmed location and time. Returns the promotion or nil when nobody was promoted.
+func (db *Db) CancelJoinRequest(ctx context.Context, userId string, joinRequestId string) (*WaitlistPromotion, error) {
+	logCtx := slog.With("method", "CancelJoinRequest", "userId", userId, "joinRequestId", joinRequestId)
+	logCtx.Debug("Cancelling join request")
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to begin transaction")
+	}
+
+	promotion, err := db.cancelJoinRequestTx(ctx, logCtx, tx, userId, joinRequestId)
+	if err != nil {
+		db.rollback(logCtx, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logCtx.Error("Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (db *Db) cancelJoinRequestTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, userId string, joinRequestId string) (*WaitlistPromotion, error) {
+	var eventId string
+	err := tx.GetContext(ctx, &eventId, `SELECT event_id FROM join_requests WHERE id = ? AND user_id = ?`, joinRequestId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Join request not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to get join request")
+	}
+
+	// the event is locked first, the same order as when joining, so that concurrent joins see the freed place
+	event, err := db.lockWaitlistEventTx(ctx, tx, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	var status string
+	if err := tx.GetContext(ctx, &status, `SELECT status FROM join_requests WHERE id = ? FOR UPDATE`, joinRequestId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, DbObjectNotFoundError{Message: "Join request not found"}
+		}
+		return nil, errors.WithMessage(err, "Failed to lock join request")
+	}
+
+	switch api.EventStatus(event.Status) {
+	case api.EventStatusOpen:
+		if _, err := tx.ExecContext(ctx, `DELETE FROM join_requests WHERE id = ?`, joinRequestId); err != nil {
+			return nil, errors.WithMessage(err, "Failed to delete join request")
+		}
+		if api.JoinRequestStatus(status) != api.JoinRequestStatusWaiting && api.JoinRequestStatus(status) != api.JoinRequestStatusAccepted {
+			return nil, nil
+		}
+		return db.promoteFromWaitlistTx(ctx, logCtx, tx, event, nil)
+	case api.EventStatusConfirmed:
+		if api.JoinRequestStatus(status) != api.JoinRequestStatusAccepted {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM join_requests WHERE id = ?`, joinRequestId); err != nil {
+				return nil, errors.WithMessage(err, "Failed to delete join request")
+			}
+			return nil, nil
+		}
+
+		// accepted requests are kept for the history of the played session
+		query := `UPDATE join_requests SET is_accepted = false, status = ? WHERE id = ?`
+		logCtx.Debug("Executing SQL query", "query", query)
+		if _, err := tx.ExecContext(ctx, query, api.JoinRequestStatusCancelled, joinRequestId); err != nil {
+			return nil, errors.WithMessage(err, "Failed to cancel join request")
+		}
+
+		var confirmation ConfirmationRow
+		err := tx.GetContext(ctx, &confirmation, `SELECT id, event_id, location_id, dt, version, created_at FROM confirmations WHERE event_id = ?`, event.Id)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to get confirmation")
+		}
+		promotion, err := db.promoteFromWaitlistTx(ctx, logCtx, tx, event, &confirmation)
+		if err != nil {
+			return nil, err
+		}
+
+		promotedUserId := ""
+		if promotion != nil {
+			promotedUserId = promotion.UserId
+		}
+		if err := db.replaceTeamPlayerTx(ctx, tx, event.Id, userId, promotedUserId); err != nil {
+			return nil, err
+		}
+		return promotion, nil
+	default:
+		return nil, &ValidationError{Message: "Cannot cancel join request for " + event.Status + " event"}
+	}
+}
+
+// promoteFromWaitlistTx moves the oldest waitlisted join request of the event to the place freed by a cancelled one.
+// For a confirmed event only requests offering the confirmed location and time are considered.
+func (db *Db) promoteFromWaitlistTx(ctx context.Context, logCtx *slog.Logger, tx *sqlx.Tx, event *waitlistEventRow, confirmation *ConfirmationRow) (*WaitlistPromotion, error) {
+	query := `SELECT jr.id, jr.user_id FROM join_requests jr WHERE jr.event_id = ? AND jr.status = ?`
+	args := []interface{}{event.Id, api.JoinRequestStatusWaitlisted}
+	if confirmation != nil {
+		query += `
+			AND EXISTS (SELECT 1 FROM join_request_locations jrl WHERE jrl.join_request_id = jr.id AND jrl.location_id = ?)
+			AND EXISTS (SELECT 1 FROM join_request_time_slots jrts WHERE jrts.join_request_id = jr.id AND jrts.dt = ?)`
+		args = append(args, confirmation.LocationId, confirmation.Dt)
+	}
+	query += ` ORDER BY jr.created_at, jr.id LIMIT 1 FOR UPDATE`
+
+	var next waitlistJoinRequestRow
+	if err := tx.GetContext(ctx, &next, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logCtx.Debug("Nobody to promote from the waitlist")
+			return nil, nil
+		}
+		return nil, errors.WithMessage(err, "Failed to get waitlisted join request")
+	}
+
+	promotion := &WaitlistPromotion{
+		EventId:       event.Id,
+		HostUserId:    event.UserId,
+		JoinRequestId: next.Id,
+		UserId:        next.UserId,
+	}
+
+	if confirmation == nil {
+		_, err := tx.ExecContext(ctx, `UPDATE join_requests SET status = ? WHERE id = ?`, api.JoinRequestStatusWaiting, next.Id)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to promote join request")
+		}
+	} else {
+		// the promoted player offered the confirmed location and time, so they acknowledged the current version
+		_, err := tx.ExecContext(ctx, `UPDATE join_requests SET is_accepted = true, status = ?, acknowledged_version = ? WHERE id = ?`,
+			api.JoinRequestStatusAccepted, confirmation.Version, next.Id)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to promote join request")
+		}
+		promotion.Accepted = true
+		promotion.LocationId = confirmation.LocationId
+		promotion.DateTime = confirmation.Dt
+	}
+
+	logCtx.Debug("Join request promoted from the waitlist", "promotedJoinRequestId", next.Id, "accepted", promotion.Accepted)
+	return promotion, nil
+}
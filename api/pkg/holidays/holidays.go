@@ -0,0 +1,228 @@
+// Package holidays knows the public holidays of the countries facilities are in. Holidays come from rules in data files
+// embedded per country, moveable feasts are computed from the date of Easter, so the data does not run out. Admins
+// can add or remove holidays with overrides stored in the database.
+package holidays
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// alpha3 maps the three letter country codes used by seeded facilities to the two letter codes of the data files
+var alpha3 = map[string]string{
+	"POL": "PL",
+}
+
+// Holiday is a public holiday, Date is the local date in YYYY-MM-DD format
+type Holiday struct {
+	Date string
+	Name string
+}
+
+// Override adds a holiday missing from the data files or, when IsHoliday is false, removes one from them
+type Override struct {
+	Country   string
+	Date      string
+	Name      string
+	IsHoliday bool
+}
+
+// dataFile lists the holidays of a country. A holiday is either on a fixed day every year, "date" in MM-DD format,
+// on a single day, "date" in YYYY-MM-DD format, or a moveable feast "easter" days after Easter Sunday. Holidays
+// introduced or abolished by law are limited to the years from "since" until "until", both inclusive.
+type dataFile struct {
+	Country  string `json:"country"`
+	Holidays []rule `json:"holidays"`
+}
+
+type rule struct {
+	Date   string `json:"date"`
+	Easter *int   `json:"easter"`
+	Name   string `json:"name"`
+	Since  int    `json:"since"`
+	Until  int    `json:"until"`
+}
+
+// dateIn returns the local date of the holiday in the year in YYYY-MM-DD format, ok is false when it is not
+// a holiday that year
+func (r rule) dateIn(year int) (date string, ok bool) {
+	if (r.Since != 0 && year < r.Since) || (r.Until != 0 && year > r.Until) {
+		return "", false
+	}
+	if r.Easter != nil {
+		return easterSunday(year).AddDate(0, 0, *r.Easter).Format(time.DateOnly), true
+	}
+	if len(r.Date) == len("01-02") {
+		return fmt.Sprintf("%04d-%s", year, r.Date), true
+	}
+	return r.Date, strings.HasPrefix(r.Date, fmt.Sprintf("%04d-", year))
+}
+
+func (r rule) validate() error {
+	if r.Name == "" {
+		return errors.New("holiday without name")
+	}
+	if (r.Date == "") == (r.Easter == nil) {
+		return fmt.Errorf("holiday %q needs either a date or an easter offset", r.Name)
+	}
+	if r.Date == "" {
+		return nil
+	}
+	date := r.Date
+	if len(date) == len("01-02") {
+		// yearly dates are checked in a leap year so that February 29 parses
+		date = "2000-" + date
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("invalid date of holiday %q: %w", r.Name, err)
+	}
+	return nil
+}
+
+// easterSunday returns the date of Western Easter Sunday in the year, computed with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Calendar holds the holidays of the embedded data files by country
+type Calendar struct {
+	byCountry map[string][]rule
+}
+
+// NewCalendar loads the embedded data files
+func NewCalendar() (*Calendar, error) {
+	entries, err := dataFiles.ReadDir("data")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Calendar{byCountry: map[string][]rule{}}
+	for _, entry := range entries {
+		content, err := dataFiles.ReadFile(path.Join("data", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var file dataFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("invalid holiday file %s: %w", entry.Name(), err)
+		}
+
+		for _, r := range file.Holidays {
+			if err := r.validate(); err != nil {
+				return nil, fmt.Errorf("invalid holiday file %s: %w", entry.Name(), err)
+			}
+		}
+		c.byCountry[NormalizeCountry(file.Country)] = file.Holidays
+	}
+	return c, nil
+}
+
+// NormalizeCountry returns the two letter code holidays of the country are stored under
+func NormalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if code, ok := alpha3[country]; ok {
+		return code
+	}
+	return country
+}
+
+// Countries returns the countries with embedded holiday data
+func (c *Calendar) Countries() []string {
+	countries := make([]string, 0, len(c.byCountry))
+	for country := range c.byCountry {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// Country returns the holidays of the country with the overrides applied. Countries without data have no holidays
+// except the ones added by overrides.
+func (c *Calendar) Country(country string, overrides []Override) *CountryCalendar {
+	country = NormalizeCountry(country)
+	calendar := &CountryCalendar{Country: country, rules: c.byCountry[country], overrides: map[string]Override{}}
+	for _, o := range overrides {
+		if NormalizeCountry(o.Country) == country {
+			calendar.overrides[o.Date] = o
+		}
+	}
+	return calendar
+}
+
+// CountryCalendar holds the holidays of a single country
+type CountryCalendar struct {
+	Country   string
+	rules     []rule
+	overrides map[string]Override // by date
+}
+
+// year returns the holidays of the year by local date
+func (c *CountryCalendar) year(year int) map[string]string {
+	dates := map[string]string{}
+	for _, r := range c.rules {
+		if date, ok := r.dateIn(year); ok {
+			dates[date] = r.Name
+		}
+	}
+	prefix := fmt.Sprintf("%04d-", year)
+	for date, o := range c.overrides {
+		if !strings.HasPrefix(date, prefix) {
+			continue
+		}
+		if o.IsHoliday {
+			dates[date] = o.Name
+		} else {
+			delete(dates, date)
+		}
+	}
+	return dates
+}
+
+// IsHoliday tells whether the local day is a public holiday, it can be used as pricing.HolidayFunc
+func (c *CountryCalendar) IsHoliday(day time.Time) bool {
+	_, ok := c.Name(day)
+	return ok
+}
+
+// Name returns the name of the holiday on the local day
+func (c *CountryCalendar) Name(day time.Time) (string, bool) {
+	name, ok := c.year(day.Year())[day.Format(time.DateOnly)]
+	return name, ok
+}
+
+// Between returns the holidays between the two local dates, both inclusive, in chronological order
+func (c *CountryCalendar) Between(from time.Time, to time.Time) []Holiday {
+	fromDate, toDate := from.Format(time.DateOnly), to.Format(time.DateOnly)
+	holidays := []Holiday{}
+	for year := from.Year(); year <= to.Year(); year++ {
+		for date, name := range c.year(year) {
+			if date >= fromDate && date <= toDate {
+				holidays = append(holidays, Holiday{Date: date, Name: name})
+			}
+		}
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+	return holidays
+}
@@ -0,0 +1,94 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendar(t *testing.T) {
+	calendar, err := NewCalendar()
+	require.NoError(t, err)
+	assert.Contains(t, calendar.Countries(), "PL")
+
+	// seeded facilities use three letter country codes
+	poland := calendar.Country("POL", nil)
+	assert.Equal(t, "PL", poland.Country)
+
+	name, ok := poland.Name(time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Independence Day", name)
+	assert.False(t, poland.IsHoliday(time.Date(2026, 11, 12, 0, 0, 0, 0, time.UTC)))
+
+	assert.Empty(t, calendar.Country("XX", nil).Between(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCountry_Overrides(t *testing.T) {
+	calendar, err := NewCalendar()
+	require.NoError(t, err)
+
+	overrides := []Override{
+		{Country: "PL", Date: "2026-05-02", Name: "Bridge day", IsHoliday: true},
+		{Country: "PL", Date: "2026-05-03", IsHoliday: false},
+		{Country: "DE", Date: "2026-05-04", Name: "Other country", IsHoliday: true},
+	}
+	poland := calendar.Country("PL", overrides)
+
+	holidays := poland.Between(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []Holiday{
+		{Date: "2026-05-01", Name: "Labour Day"},
+		{Date: "2026-05-02", Name: "Bridge day"},
+		{Date: "2026-05-24", Name: "Pentecost"},
+	}, holidays)
+}
+
+func TestEasterSunday(t *testing.T) {
+	for _, date := range []string{"2024-03-31", "2025-04-20", "2026-04-05", "2027-03-28", "2030-04-21", "2038-04-25", "2285-03-22"} {
+		day, err := time.Parse(time.DateOnly, date)
+		require.NoError(t, err)
+		assert.Equal(t, date, easterSunday(day.Year()).Format(time.DateOnly))
+	}
+}
+
+func TestCountry_MoveableFeasts(t *testing.T) {
+	calendar, err := NewCalendar()
+	require.NoError(t, err)
+	poland := calendar.Country("PL", nil)
+
+	assert.Equal(t, []Holiday{
+		{Date: "2027-01-01", Name: "New Year's Day"},
+		{Date: "2027-01-06", Name: "Epiphany"},
+		{Date: "2027-03-28", Name: "Easter Sunday"},
+		{Date: "2027-03-29", Name: "Easter Monday"},
+		{Date: "2027-05-01", Name: "Labour Day"},
+		{Date: "2027-05-03", Name: "Constitution Day"},
+		{Date: "2027-05-16", Name: "Pentecost"},
+		{Date: "2027-05-27", Name: "Corpus Christi"},
+		{Date: "2027-08-15", Name: "Assumption Day"},
+		{Date: "2027-11-01", Name: "All Saints' Day"},
+		{Date: "2027-11-11", Name: "Independence Day"},
+		{Date: "2027-12-24", Name: "Christmas Eve"},
+		{Date: "2027-12-25", Name: "Christmas Day"},
+		{Date: "2027-12-26", Name: "Second Day of Christmas"},
+	}, poland.Between(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	// Christmas Eve is a public holiday since 2025
+	assert.False(t, poland.IsHoliday(time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)))
+
+	// the data does not run out
+	name, ok := poland.Name(time.Date(2040, 5, 31, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Corpus Christi", name)
+	assert.Len(t, poland.Between(time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2041, 12, 31, 0, 0, 0, 0, time.UTC)), 28)
+}
+
+func TestIsCountryCode(t *testing.T) {
+	assert.True(t, IsCountryCode("PL"))
+	assert.True(t, IsCountryCode("de"))
+	assert.False(t, IsCountryCode("POL"), "three letter codes are not accepted")
+	assert.False(t, IsCountryCode("XX"))
+	assert.False(t, IsCountryCode("UK"))
+	assert.False(t, IsCountryCode(""))
+}
@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+)
+
+var requiredCsvColumns = []string{"name", "address", "country", "latitude", "longitude"}
+
+// ParseCSV reads facilities from a CSV file with a header row. Every row describes one court group, rows with the
+// same google_place_id, or the same name and coordinates when it is empty, belong to the same facility whose profile
+// is taken from its first row. A row without a surface only describes the facility.
+//
+// Columns: name, address, country, latitude, longitude, website, google_maps_link, google_place_id, surface, type,
+// light, heating, reservation_link, court_names, prices. Court names are comma separated. Prices list periods
+// separated by "|", each made of the dates followed by the rules, e.g.
+// "2025-05-01..2025-09-30 *@07:00-15:00=60 *@15:00-23:00=80 | 2025-10-01..2026-04-30 *@07:00-23:00=100".
+func ParseCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredCsvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var records []Record
+	byKey := map[string]int{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		key := get("google_place_id")
+		if key == "" {
+			key = strings.ToLower(get("name")) + "@" + get("latitude") + "," + get("longitude")
+		}
+		i, ok := byKey[key]
+		if !ok {
+			record := Record{Source: fmt.Sprintf("line %d", line)}
+			record.Facility, record.Err = csvFacility(get)
+			records = append(records, record)
+			i = len(records) - 1
+			byKey[key] = i
+		}
+
+		record := &records[i]
+		if record.Err != nil || get("surface") == "" {
+			continue
+		}
+		group, err := csvCourtGroup(get)
+		if err != nil {
+			record.Err = fmt.Errorf("line %d: %w", line, err)
+			continue
+		}
+		record.Facility.CourtGroups = append(record.Facility.CourtGroups, group)
+	}
+	return records, nil
+}
+
+func csvFacility(get func(string) string) (db.FacilityImport, error) {
+	f := db.FacilityImport{
+		AdminFacilityData: api.AdminFacilityData{
+			Name:           get("name"),
+			Address:        get("address"),
+			Country:        get("country"),
+			Website:        get("website"),
+			GoogleMapsLink: get("google_maps_link"),
+		},
+		GooglePlaceID: get("google_place_id"),
+	}
+
+	var err error
+	if f.Coordinates.Latitude, err = strconv.ParseFloat(get("latitude"), 64); err != nil {
+		return f, fmt.Errorf("invalid latitude %q", get("latitude"))
+	}
+	if f.Coordinates.Longitude, err = strconv.ParseFloat(get("longitude"), 64); err != nil {
+		return f, fmt.Errorf("invalid longitude %q", get("longitude"))
+	}
+	return f, nil
+}
+
+func csvCourtGroup(get func(string) string) (db.CourtGroupImport, error) {
+	group := db.CourtGroupImport{
+		AdminCourtGroupData: api.AdminCourtGroupData{
+			Surface:         api.CourtSurface(strings.ToLower(get("surface"))),
+			Type:            strings.ToLower(get("type")),
+			ReservationLink: get("reservation_link"),
+		},
+	}
+
+	var err error
+	if group.Light, err = parseFlag(get("light")); err != nil {
+		return group, err
+	}
+	if group.Heating, err = parseFlag(get("heating")); err != nil {
+		return group, err
+	}
+	if names := get("court_names"); names != "" {
+		group.CourtNames = strings.Split(names, ",")
+	}
+
+	for _, text := range strings.Split(get("prices"), "|") {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		data, err := parsePricePeriod(text)
+		if err != nil {
+			return group, err
+		}
+		period, err := toPeriod(data)
+		if err != nil {
+			return group, err
+		}
+		group.Periods = append(group.Periods, period)
+	}
+	return group, nil
+}
+
+// parsePricePeriod parses a period written as "FROM..TO PATTERN@START-END=PRICE ..."
+func parsePricePeriod(text string) (api.AdminPricePeriodData, error) {
+	fields := strings.Fields(text)
+	from, to, ok := strings.Cut(fields[0], "..")
+	if !ok {
+		return api.AdminPricePeriodData{}, fmt.Errorf("invalid price period dates %q, expected FROM..TO", fields[0])
+	}
+
+	data := api.AdminPricePeriodData{ValidFrom: from, ValidTo: to}
+	for _, field := range fields[1:] {
+		pattern, rest, ok1 := strings.Cut(field, "@")
+		hours, price, ok2 := strings.Cut(rest, "=")
+		start, end, ok3 := strings.Cut(hours, "-")
+		if !ok1 || !ok2 || !ok3 {
+			return data, fmt.Errorf("invalid price rule %q, expected PATTERN@HH:MM-HH:MM=PRICE", field)
+		}
+		value, err := strconv.ParseFloat(price, 64)
+		if err != nil {
+			return data, fmt.Errorf("invalid price in rule %q", field)
+		}
+		data.Rules = append(data.Rules, api.AdminPriceRule{DayPattern: pattern, Start: start, End: end, Price: value})
+	}
+	return data, nil
+}
+
+// parseFlag parses an optional yes/no column
+func parseFlag(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "false", "no", "n":
+		return false, nil
+	case "1", "true", "yes", "y":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid flag %q, expected yes or no", value)
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+const testCsv = `name,address,country,latitude,longitude,website,google_place_id,surface,type,light,heating,court_names,prices
+Matchpoint,"ul. Kiełczowska 70, Wrocław",PL,51.1425,17.0913,https://matchpoint.com.pl,place_1,hard,indoor,yes,yes,"1,2,3",2025-05-01..2025-09-30 *@07:00-15:00=60 *@15:00-23:00=80 | 2025-10-01..2026-04-30 *@07:00-23:00=100
+Matchpoint,"ul. Kiełczowska 70, Wrocław",PL,51.1425,17.0913,,place_1,clay,outdoor,no,no,"4,5",2025-05-01..2025-09-30 !@07:00-22:00=70
+Park Club,Park 1,PL,51.1,17.03,,,,,,,,
+Broken,Street 2,PL,north,17.03,,,,,,,,
+`
+
+func TestParseCSV(t *testing.T) {
+	records, err := ParseCSV(strings.NewReader(testCsv))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	matchpoint := records[0]
+	assert.Equal(t, "line 2", matchpoint.Source)
+	assert.NoError(t, matchpoint.Err)
+	assert.Equal(t, "place_1", matchpoint.Facility.GooglePlaceID)
+	assert.Equal(t, api.Coordinates{Latitude: 51.1425, Longitude: 17.0913}, matchpoint.Facility.Coordinates)
+	require.Len(t, matchpoint.Facility.CourtGroups, 2)
+
+	indoor := matchpoint.Facility.CourtGroups[0]
+	assert.Equal(t, api.CourtSurfaceHard, indoor.Surface)
+	assert.True(t, indoor.Light)
+	assert.Equal(t, []string{"1", "2", "3"}, indoor.CourtNames)
+	require.Len(t, indoor.Periods, 2)
+	assert.Equal(t, "2025-10-01", indoor.Periods[1].ValidFrom.Format("2006-01-02"))
+	assert.Equal(t, pricing.Rule{DayPattern: "*", Start: 15 * 60, End: 23 * 60, Price: 80}, indoor.Periods[0].Rules[1])
+
+	park := records[1]
+	assert.Equal(t, "line 4", park.Source)
+	assert.NoError(t, park.Err)
+	assert.Empty(t, park.Facility.CourtGroups)
+
+	assert.Error(t, records[2].Err)
+}
+
+func TestParseCSV_InvalidRows(t *testing.T) {
+	_, err := ParseCSV(strings.NewReader("name,address,country\nClub,Street,PL\n"))
+	assert.Error(t, err, "missing coordinate columns")
+
+	tests := map[string]string{
+		"flag":  "Club,Street,PL,51.1,17.03,hard,indoor,maybe,",
+		"dates": "Club,Street,PL,51.1,17.03,hard,indoor,yes,2025-05-01 *@07:00-15:00=60",
+		"rule":  "Club,Street,PL,51.1,17.03,hard,indoor,yes,2025-05-01..2025-09-30 *@07:00=60",
+		"time":  "Club,Street,PL,51.1,17.03,hard,indoor,yes,2025-05-01..2025-09-30 *@7am-15:00=60",
+	}
+	for name, row := range tests {
+		records, err := ParseCSV(strings.NewReader("name,address,country,latitude,longitude,surface,type,light,prices\n" + row + "\n"))
+		require.NoError(t, err, name)
+		require.Len(t, records, 1, name)
+		assert.Error(t, records[0].Err, name)
+	}
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+)
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties featureProperties `json:"properties"`
+}
+
+type featureProperties struct {
+	Name           string                 `json:"name"`
+	Address        string                 `json:"address"`
+	Country        string                 `json:"country"`
+	Website        string                 `json:"website"`
+	GoogleMapsLink string                 `json:"googleMapsLink"`
+	GooglePlaceId  string                 `json:"googlePlaceId"`
+	CourtGroups    []courtGroupProperties `json:"courtGroups"`
+}
+
+type courtGroupProperties struct {
+	api.AdminCourtGroupData
+	PricePeriods []api.AdminPricePeriodData `json:"pricePeriods"`
+}
+
+// ParseGeoJSON reads facilities from a GeoJSON FeatureCollection of points. The properties of a feature are the
+// profile of the facility and its court groups in the format of the admin API, every court group listing its
+// pricePeriods.
+func ParseGeoJSON(r io.Reader) ([]Record, error) {
+	var collection featureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("failed to decode GeoJSON: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("expected a FeatureCollection, got %q", collection.Type)
+	}
+
+	records := make([]Record, 0, len(collection.Features))
+	for i, feature := range collection.Features {
+		record := Record{Source: fmt.Sprintf("feature %d", i+1)}
+		record.Facility, record.Err = geoJSONFacility(feature)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func geoJSONFacility(feature feature) (db.FacilityImport, error) {
+	props := feature.Properties
+	f := db.FacilityImport{
+		AdminFacilityData: api.AdminFacilityData{
+			Name:           props.Name,
+			Address:        props.Address,
+			Country:        props.Country,
+			Website:        props.Website,
+			GoogleMapsLink: props.GoogleMapsLink,
+		},
+		GooglePlaceID: props.GooglePlaceId,
+	}
+
+	// GeoJSON positions are longitude first
+	var position []float64
+	if feature.Geometry.Type != "Point" || json.Unmarshal(feature.Geometry.Coordinates, &position) != nil || len(position) < 2 {
+		return f, fmt.Errorf("geometry must be a point")
+	}
+	f.Coordinates = api.Coordinates{Latitude: position[1], Longitude: position[0]}
+
+	for i, groupProps := range props.CourtGroups {
+		group := db.CourtGroupImport{AdminCourtGroupData: groupProps.AdminCourtGroupData}
+		for _, data := range groupProps.PricePeriods {
+			period, err := toPeriod(data)
+			if err != nil {
+				return f, fmt.Errorf("court group %d: %w", i+1, err)
+			}
+			group.Periods = append(group.Periods, period)
+		}
+		f.CourtGroups = append(f.CourtGroups, group)
+	}
+	return f, nil
+}
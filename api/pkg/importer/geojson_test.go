@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+const testGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {"type": "Point", "coordinates": [17.0913, 51.1425]},
+      "properties": {
+        "name": "Matchpoint",
+        "address": "ul. Kiełczowska 70, Wrocław",
+        "country": "PL",
+        "googlePlaceId": "place_1",
+        "courtGroups": [
+          {
+            "surface": "hard",
+            "type": "baloon",
+            "light": true,
+            "courtNames": ["1", "2"],
+            "pricePeriods": [
+              {"validFrom": "2025-10-01", "validTo": "2026-04-30", "rules": [{"dayPattern": "*", "start": "07:00", "end": "23:00", "price": 100}]}
+            ]
+          }
+        ]
+      }
+    },
+    {
+      "type": "Feature",
+      "geometry": {"type": "LineString", "coordinates": [[17.0, 51.1], [17.1, 51.2]]},
+      "properties": {"name": "Path"}
+    }
+  ]
+}`
+
+func TestParseGeoJSON(t *testing.T) {
+	records, err := ParseGeoJSON(strings.NewReader(testGeoJSON))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	matchpoint := records[0]
+	assert.Equal(t, "feature 1", matchpoint.Source)
+	assert.NoError(t, matchpoint.Err)
+	assert.Equal(t, "place_1", matchpoint.Facility.GooglePlaceID)
+	assert.Equal(t, api.Coordinates{Latitude: 51.1425, Longitude: 17.0913}, matchpoint.Facility.Coordinates)
+	require.Len(t, matchpoint.Facility.CourtGroups, 1)
+	group := matchpoint.Facility.CourtGroups[0]
+	assert.Equal(t, []string{"1", "2"}, group.CourtNames)
+	require.Len(t, group.Periods, 1)
+	assert.Equal(t, 7*60, group.Periods[0].Rules[0].Start)
+
+	assert.Error(t, records[1].Err, "only points are supported")
+
+	_, err = ParseGeoJSON(strings.NewReader(`{"type": "Feature"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+// Package importer reads facilities with their court groups and prices from CSV or GeoJSON files and upserts them
+// into the database
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+// Store finds and upserts imported facilities
+type Store interface {
+	FindFacilityForImport(ctx context.Context, f *db.FacilityImport) (string, error)
+	ImportFacility(ctx context.Context, f *db.FacilityImport) (string, bool, error)
+}
+
+// Record is a facility read from the import file
+type Record struct {
+	// Source tells where the facility is in the file, e.g. "line 2" or "feature 1"
+	Source   string
+	Facility db.FacilityImport
+	// Err is set when the facility could not be read
+	Err error
+}
+
+// Action is what the import does with a facility
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionInvalid Action = "invalid"
+	ActionFailed  Action = "failed"
+)
+
+// Result is the outcome of importing a single facility
+type Result struct {
+	Source     string
+	Name       string
+	Action     Action
+	FacilityId string
+	Err        error
+}
+
+// Report lists the outcome of every facility of the import
+type Report struct {
+	DryRun  bool
+	Results []Result
+}
+
+// Count returns the number of facilities with the given outcome
+func (r *Report) Count(action Action) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed tells whether any facility was invalid or could not be imported
+func (r *Report) Failed() bool {
+	return r.Count(ActionInvalid) > 0 || r.Count(ActionFailed) > 0
+}
+
+// Write prints one line per facility followed by a summary
+func (r *Report) Write(w io.Writer) {
+	for _, result := range r.Results {
+		line := fmt.Sprintf("%-12s %-8s %s", result.Source, result.Action, result.Name)
+		if result.FacilityId != "" {
+			line += " (" + result.FacilityId + ")"
+		}
+		if result.Err != nil {
+			line += ": " + result.Err.Error()
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	mode := "imported"
+	if r.DryRun {
+		mode = "dry run, nothing imported"
+	}
+	_, _ = fmt.Fprintf(w, "%d to create, %d to update, %d invalid, %d failed (%s)\n",
+		r.Count(ActionCreate), r.Count(ActionUpdate), r.Count(ActionInvalid), r.Count(ActionFailed), mode)
+}
+
+// Import validates the records and upserts the valid ones. A dry run only checks which facilities would be created
+// or updated. Invalid records and failures are reported without stopping the import. A record with the Google Place
+// ID or the name and coordinates of an earlier record is invalid, as both would update the same facility.
+func Import(ctx context.Context, store Store, records []Record, dryRun bool) *Report {
+	report := &Report{DryRun: dryRun}
+	sources := map[string]string{}
+	for i := range records {
+		record := &records[i]
+		err := record.Err
+		if err == nil {
+			err = Validate(&record.Facility)
+		}
+		if err == nil {
+			err = checkDuplicate(sources, record)
+		}
+		result := Result{Source: record.Source, Name: record.Facility.Name}
+		if err != nil {
+			result.Action = ActionInvalid
+			result.Err = err
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		if dryRun {
+			result.FacilityId, err = store.FindFacilityForImport(ctx, &record.Facility)
+			result.Action = ActionCreate
+			if result.FacilityId != "" {
+				result.Action = ActionUpdate
+			}
+		} else {
+			var created bool
+			result.FacilityId, created, err = store.ImportFacility(ctx, &record.Facility)
+			result.Action = ActionUpdate
+			if created {
+				result.Action = ActionCreate
+			}
+		}
+		if err != nil {
+			result.Action = ActionFailed
+			result.Err = err
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// checkDuplicate fails when the facility has the key of an earlier record, otherwise it remembers the keys
+func checkDuplicate(sources map[string]string, record *Record) error {
+	keys := importKeys(&record.Facility)
+	for _, key := range keys {
+		if source, ok := sources[key]; ok {
+			return fmt.Errorf("duplicate of the facility at %s", source)
+		}
+	}
+	for _, key := range keys {
+		sources[key] = record.Source
+	}
+	return nil
+}
+
+// importKeys returns the keys matching the facility to an existing one, like the lookup of the store
+func importKeys(f *db.FacilityImport) []string {
+	keys := []string{fmt.Sprintf("name:%s@%.6f,%.6f", strings.ToLower(f.Name), f.Coordinates.Latitude, f.Coordinates.Longitude)}
+	if f.GooglePlaceID != "" {
+		keys = append(keys, "place:"+f.GooglePlaceID)
+	}
+	return keys
+}
+
+// Validate normalizes the facility and checks it together with its court groups and prices
+func Validate(f *db.FacilityImport) error {
+	if err := api.NormalizeFacilityData(&f.AdminFacilityData); err != nil {
+		return err
+	}
+	for i := range f.CourtGroups {
+		group := &f.CourtGroups[i]
+		// old seed files use the misspelled type
+		if group.Type == "baloon" {
+			group.Type = "balloon"
+		}
+		if err := api.NormalizeCourtGroupData(&group.AdminCourtGroupData); err != nil {
+			return fmt.Errorf("court group %d: %w", i+1, err)
+		}
+		for j, period := range group.Periods {
+			if err := pricing.ValidateRules(period.Rules); err != nil {
+				return fmt.Errorf("court group %d: %w", i+1, err)
+			}
+			if err := pricing.ValidatePeriod(period, group.Periods[:j]); err != nil {
+				return fmt.Errorf("court group %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// toPeriod parses the dates and clock times of the price period
+func toPeriod(data api.AdminPricePeriodData) (pricing.Period, error) {
+	validFrom, err := time.Parse(time.DateOnly, data.ValidFrom)
+	if err != nil {
+		return pricing.Period{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", data.ValidFrom)
+	}
+	validTo, err := time.Parse(time.DateOnly, data.ValidTo)
+	if err != nil {
+		return pricing.Period{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", data.ValidTo)
+	}
+
+	period := pricing.Period{ValidFrom: validFrom, ValidTo: validTo}
+	for _, r := range data.Rules {
+		start, err := pricing.ParseClock(r.Start)
+		if err != nil {
+			return pricing.Period{}, err
+		}
+		end, err := pricing.ParseClock(r.End)
+		if err != nil {
+			return pricing.Period{}, err
+		}
+		period.Rules = append(period.Rules, pricing.Rule{DayPattern: r.DayPattern, Start: start, End: end, Price: r.Price})
+	}
+	return period, nil
+}
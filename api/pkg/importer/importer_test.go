@@ -0,0 +1,140 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/importer/mocks"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+func testFacility(name string) db.FacilityImport {
+	return db.FacilityImport{
+		AdminFacilityData: api.AdminFacilityData{
+			Name:        name,
+			Address:     "Street 1",
+			Country:     "pl",
+			Coordinates: api.Coordinates{Latitude: 51.1, Longitude: 17.03},
+		},
+	}
+}
+
+func testPeriod(from, to string) pricing.Period {
+	validFrom, _ := time.Parse(time.DateOnly, from)
+	validTo, _ := time.Parse(time.DateOnly, to)
+	return pricing.Period{
+		ValidFrom: validFrom,
+		ValidTo:   validTo,
+		Rules:     []pricing.Rule{{DayPattern: pricing.DayPatternEveryday, Start: 7 * 60, End: 23 * 60, Price: 60}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	f := testFacility(" Club ")
+	f.CourtGroups = []db.CourtGroupImport{{
+		AdminCourtGroupData: api.AdminCourtGroupData{Surface: api.CourtSurfaceClay, Type: "baloon"},
+		Periods:             []pricing.Period{testPeriod("2025-01-01", "2025-12-31"), testPeriod("2025-06-01", "2025-06-30")},
+	}}
+	assert.NoError(t, Validate(&f))
+	assert.Equal(t, "Club", f.Name)
+	assert.Equal(t, "PL", f.Country)
+	assert.Equal(t, "balloon", f.CourtGroups[0].Type)
+
+	f.CourtGroups[0].Periods = append(f.CourtGroups[0].Periods, testPeriod("2025-12-01", "2026-03-31"))
+	assert.Error(t, Validate(&f), "partially overlapping periods")
+
+	f = testFacility("Club")
+	f.CourtGroups = []db.CourtGroupImport{{
+		AdminCourtGroupData: api.AdminCourtGroupData{Surface: "ice", Type: "indoor"},
+	}}
+	assert.Error(t, Validate(&f))
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	records := []Record{
+		{Source: "line 2", Facility: testFacility("New Club")},
+		{Source: "line 3", Facility: testFacility("Old Club")},
+		{Source: "line 4", Facility: testFacility("")},
+		{Source: "line 5", Facility: testFacility("Broken"), Err: fmt.Errorf("invalid latitude")},
+		{Source: "line 6", Facility: testFacility("Failing Club")},
+	}
+
+	byName := func(name string) interface{} {
+		return mock.MatchedBy(func(f *db.FacilityImport) bool { return f.Name == name })
+	}
+	store := mocks.NewMockStore(t)
+	store.EXPECT().ImportFacility(ctx, byName("New Club")).Return("id_1", true, nil).Once()
+	store.EXPECT().ImportFacility(ctx, byName("Old Club")).Return("id_2", false, nil).Once()
+	store.EXPECT().ImportFacility(ctx, byName("Failing Club")).Return("", false, fmt.Errorf("duplicate place")).Once()
+
+	report := Import(ctx, store, records, false)
+
+	actions := []Action{}
+	for _, result := range report.Results {
+		actions = append(actions, result.Action)
+	}
+	assert.Equal(t, []Action{ActionCreate, ActionUpdate, ActionInvalid, ActionInvalid, ActionFailed}, actions)
+	assert.Equal(t, "id_1", report.Results[0].FacilityId)
+	assert.True(t, report.Failed())
+
+	var out bytes.Buffer
+	report.Write(&out)
+	assert.True(t, strings.HasSuffix(out.String(), "1 to create, 1 to update, 2 invalid, 1 failed (imported)\n"), out.String())
+}
+
+func TestImport_DryRun(t *testing.T) {
+	ctx := context.Background()
+	records := []Record{
+		{Source: "feature 1", Facility: testFacility("New Club")},
+		{Source: "feature 2", Facility: testFacility("Old Club")},
+	}
+
+	store := mocks.NewMockStore(t)
+	store.EXPECT().FindFacilityForImport(ctx, &records[0].Facility).Return("", nil).Once()
+	store.EXPECT().FindFacilityForImport(ctx, &records[1].Facility).Return("id_2", nil).Once()
+
+	report := Import(ctx, store, records, true)
+
+	assert.Equal(t, ActionCreate, report.Results[0].Action)
+	assert.Equal(t, ActionUpdate, report.Results[1].Action)
+	assert.Equal(t, "id_2", report.Results[1].FacilityId)
+	assert.False(t, report.Failed())
+}
+
+func TestImport_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	samePlace := testFacility("Club Entrance")
+	samePlace.GooglePlaceID = "place_1"
+	sameName := testFacility(" club ")
+	records := []Record{
+		{Source: "feature 1", Facility: testFacility("Club")},
+		{Source: "feature 2", Facility: testFacility("Other Club")},
+		{Source: "feature 3", Facility: sameName},
+		{Source: "feature 4", Facility: testFacility("Club On Maps")},
+		{Source: "feature 5", Facility: samePlace},
+	}
+	records[3].Facility.GooglePlaceID = "place_1"
+
+	store := mocks.NewMockStore(t)
+	store.EXPECT().FindFacilityForImport(ctx, mock.Anything).Return("", nil).Times(3)
+
+	report := Import(ctx, store, records, true)
+
+	actions := []Action{}
+	for _, result := range report.Results {
+		actions = append(actions, result.Action)
+	}
+	assert.Equal(t, []Action{ActionCreate, ActionCreate, ActionInvalid, ActionCreate, ActionInvalid}, actions)
+	assert.EqualError(t, report.Results[2].Err, "duplicate of the facility at feature 1")
+	assert.EqualError(t, report.Results[4].Err, "duplicate of the facility at feature 4")
+	assert.True(t, report.Failed())
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+)
+
+// CompletionDb defines the database operations needed by the completion worker
+type CompletionDb interface {
+	MarkCompletedEvents(ctx context.Context, limit int, postMatchWindow time.Duration) ([]db.CompletedEventInfo, error)
+}
+
+// CompletionNotifier defines the notification operations needed by the completion worker
+type CompletionNotifier interface {
+	EventCompleted(hostUserId string, eventId string, postMatchUntil time.Time)
+}
+
+// CompletionWorker periodically completes confirmed events whose session is over.
+// The transition opens the post-match window in which players report results, leave feedback and report no-shows.
+type CompletionWorker struct {
+	db              CompletionDb
+	notifier        CompletionNotifier
+	postMatchWindow time.Duration
+	logger          *slog.Logger
+}
+
+// NewCompletionWorker creates a new completion worker
+func NewCompletionWorker(database CompletionDb, notifier CompletionNotifier, postMatchWindow time.Duration) *CompletionWorker {
+	return &CompletionWorker{
+		db:              database,
+		notifier:        notifier,
+		postMatchWindow: postMatchWindow,
+		logger:          slog.With("service", "jobs"),
+	}
+}
+
+// Start begins the completion worker loop
+func (w *CompletionWorker) Start(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting completion worker", "interval", interval, "postMatchWindow", w.postMatchWindow)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Completion worker stopping due to context cancellation")
+			return
+		case <-ticker.C:
+			w.processCompletedEvents(ctx)
+		}
+	}
+}
+
+func (w *CompletionWorker) processCompletedEvents(ctx context.Context) {
+	const batchSize = 100
+	totalCompleted := 0
+
+	for {
+		completedEvents, err := w.db.MarkCompletedEvents(ctx, batchSize, w.postMatchWindow)
+		if err != nil {
+			w.logger.Error("Failed to mark completed events", "error", err)
+			return
+		}
+
+		if len(completedEvents) == 0 {
+			break
+		}
+
+		totalCompleted += len(completedEvents)
+
+		// Open the post-match window for the players of each completed event
+		for _, event := range completedEvents {
+			w.notifier.EventCompleted(event.UserId, event.EventId, event.PostMatchUntil)
+		}
+
+		if len(completedEvents) < batchSize {
+			break
+		}
+	}
+
+	if totalCompleted > 0 {
+		w.logger.Info("Marked events as completed", "count", totalCompleted)
+	} else {
+		w.logger.Debug("No completed events found")
+	}
+}
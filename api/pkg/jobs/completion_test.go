@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/jobs/mocks"
+)
+
+const testPostMatchWindow = 7 * 24 * time.Hour
+
+func TestProcessCompletedEvents_NoCompletedEvents(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockCompletionDb(t)
+	mockNotifier := mocks.NewMockCompletionNotifier(t)
+	mockDb.EXPECT().MarkCompletedEvents(ctx, 100, testPostMatchWindow).Return(nil, nil).Once()
+
+	worker := NewCompletionWorker(mockDb, mockNotifier, testPostMatchWindow)
+	worker.processCompletedEvents(ctx)
+}
+
+func TestProcessCompletedEvents_OpensPostMatchWindow(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockCompletionDb(t)
+	mockNotifier := mocks.NewMockCompletionNotifier(t)
+
+	until := time.Date(2025, 6, 10, 20, 0, 0, 0, time.UTC)
+	completedEvents := []db.CompletedEventInfo{
+		{EventId: "event_1", UserId: "user_1", PostMatchUntil: until},
+		{EventId: "event_2", UserId: "user_2", PostMatchUntil: until},
+	}
+	mockDb.EXPECT().MarkCompletedEvents(ctx, 100, testPostMatchWindow).Return(completedEvents, nil).Once()
+
+	mockNotifier.EXPECT().EventCompleted("user_1", "event_1", until).Return().Once()
+	mockNotifier.EXPECT().EventCompleted("user_2", "event_2", until).Return().Once()
+
+	worker := NewCompletionWorker(mockDb, mockNotifier, testPostMatchWindow)
+	worker.processCompletedEvents(ctx)
+}
+
+func TestProcessCompletedEvents_MultipleBatches(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockCompletionDb(t)
+	mockNotifier := mocks.NewMockCompletionNotifier(t)
+
+	firstBatch := make([]db.CompletedEventInfo, 100)
+	for i := range firstBatch {
+		firstBatch[i] = db.CompletedEventInfo{EventId: fmt.Sprintf("event_%d", i), UserId: "user_1"}
+	}
+	secondBatch := []db.CompletedEventInfo{
+		{EventId: "event_last", UserId: "user_2"},
+	}
+
+	mockDb.EXPECT().MarkCompletedEvents(ctx, 100, testPostMatchWindow).Return(firstBatch, nil).Once()
+	mockDb.EXPECT().MarkCompletedEvents(ctx, 100, testPostMatchWindow).Return(secondBatch, nil).Once()
+
+	for _, event := range append(firstBatch, secondBatch...) {
+		mockNotifier.EXPECT().EventCompleted(event.UserId, event.EventId, event.PostMatchUntil).Return().Once()
+	}
+
+	worker := NewCompletionWorker(mockDb, mockNotifier, testPostMatchWindow)
+	worker.processCompletedEvents(ctx)
+}
+
+func TestProcessCompletedEvents_DatabaseError(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockCompletionDb(t)
+	mockNotifier := mocks.NewMockCompletionNotifier(t)
+
+	mockDb.EXPECT().MarkCompletedEvents(ctx, 100, testPostMatchWindow).Return(nil, errors.New("database connection failed")).Once()
+
+	worker := NewCompletionWorker(mockDb, mockNotifier, testPostMatchWindow)
+	worker.processCompletedEvents(ctx)
+
+	// No notifications should be sent when DB errors
+}
@@ -19,6 +19,7 @@ type ExpirationDb interface {
 // ExpirationNotifier defines the notification operations needed by the expiration worker
 type ExpirationNotifier interface {
 	EventExpired(userId string, eventId string)
+	SeriesOccurrenceExpired(userId string, eventId string, seriesId string)
 }
 
 // ExpirationWorker periodically checks for expired events and updates their status
@@ -73,8 +74,13 @@ func (w *ExpirationWorker) processExpiredEvents(ctx context.Context) {
 
 		totalExpired += len(expiredEvents)
 
-		// Notify each expired event owner
+		// Notify each expired event owner. Occurrences of a series get a dedicated
+		// notification as the rest of the series stays open.
 		for _, event := range expiredEvents {
+			if event.SeriesId != "" {
+				w.notifier.SeriesOccurrenceExpired(event.UserId, event.EventId, event.SeriesId)
+				continue
+			}
 			w.notifier.EventExpired(event.UserId, event.EventId)
 		}
 
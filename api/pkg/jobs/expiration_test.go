@@ -48,6 +48,26 @@ func TestProcessExpiredEvents_SingleBatch(t *testing.T) {
 	// Expectations are automatically verified by mockery
 }
 
+func TestProcessExpiredEvents_SeriesOccurrence(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockExpirationDb(t)
+	mockNotifier := mocks.NewMockExpirationNotifier(t)
+
+	expiredEvents := []db.ExpiredEventInfo{
+		{EventId: "event_1", UserId: "user_1", SeriesId: "series_1"},
+		{EventId: "event_2", UserId: "user_2"},
+	}
+	mockDb.EXPECT().MarkExpiredEvents(ctx, 100).Return(expiredEvents, nil).Once()
+
+	// Series occurrences get the series notification, one-off events the regular one
+	mockNotifier.EXPECT().SeriesOccurrenceExpired("user_1", "event_1", "series_1").Return().Once()
+	mockNotifier.EXPECT().EventExpired("user_2", "event_2").Return().Once()
+
+	worker := NewExpirationWorker(mockDb, mockNotifier)
+	worker.processExpiredEvents(ctx)
+}
+
 func TestProcessExpiredEvents_MultipleBatches(t *testing.T) {
 	ctx := context.Background()
 
@@ -80,3 +80,55 @@ func (_c *MockExpirationNotifier_EventExpired_Call) RunAndReturn(run func(userId
 	_c.Run(run)
 	return _c
 }
+
+// SeriesOccurrenceExpired provides a mock function for the type MockExpirationNotifier
+func (_mock *MockExpirationNotifier) SeriesOccurrenceExpired(userId string, eventId string, seriesId string) {
+	_mock.Called(userId, eventId, seriesId)
+	return
+}
+
+// MockExpirationNotifier_SeriesOccurrenceExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SeriesOccurrenceExpired'
+type MockExpirationNotifier_SeriesOccurrenceExpired_Call struct {
+	*mock.Call
+}
+
+// SeriesOccurrenceExpired is a helper method to define mock.On call
+//   - userId string
+//   - eventId string
+//   - seriesId string
+func (_e *MockExpirationNotifier_Expecter) SeriesOccurrenceExpired(userId interface{}, eventId interface{}, seriesId interface{}) *MockExpirationNotifier_SeriesOccurrenceExpired_Call {
+	return &MockExpirationNotifier_SeriesOccurrenceExpired_Call{Call: _e.mock.On("SeriesOccurrenceExpired", userId, eventId, seriesId)}
+}
+
+func (_c *MockExpirationNotifier_SeriesOccurrenceExpired_Call) Run(run func(userId string, eventId string, seriesId string)) *MockExpirationNotifier_SeriesOccurrenceExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpirationNotifier_SeriesOccurrenceExpired_Call) Return() *MockExpirationNotifier_SeriesOccurrenceExpired_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpirationNotifier_SeriesOccurrenceExpired_Call) RunAndReturn(run func(userId string, eventId string, seriesId string)) *MockExpirationNotifier_SeriesOccurrenceExpired_Call {
+	_c.Run(run)
+	return _c
+}
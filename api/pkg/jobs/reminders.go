@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+)
+
+// ReminderDb defines the database operations needed by the reminder worker
+type ReminderDb interface {
+	MarkRescheduleReminders(ctx context.Context, limit int, lead time.Duration) ([]db.RescheduleReminder, error)
+}
+
+// ReminderNotifier defines the notification operations needed by the reminder worker
+type ReminderNotifier interface {
+	RescheduleReminder(reminder db.RescheduleReminder)
+}
+
+// ReminderWorker periodically reminds accepted players of rescheduled events to acknowledge the new time and court
+// before the session starts. Each version of a confirmation is reminded once.
+type ReminderWorker struct {
+	db       ReminderDb
+	notifier ReminderNotifier
+	lead     time.Duration
+	logger   *slog.Logger
+}
+
+// NewReminderWorker creates a new reminder worker reminding players lead before the session
+func NewReminderWorker(database ReminderDb, notifier ReminderNotifier, lead time.Duration) *ReminderWorker {
+	return &ReminderWorker{
+		db:       database,
+		notifier: notifier,
+		lead:     lead,
+		logger:   slog.With("service", "jobs"),
+	}
+}
+
+// Start begins the reminder worker loop
+func (w *ReminderWorker) Start(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting reminder worker", "interval", interval, "lead", w.lead)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Reminder worker stopping due to context cancellation")
+			return
+		case <-ticker.C:
+			w.processRescheduleReminders(ctx)
+		}
+	}
+}
+
+func (w *ReminderWorker) processRescheduleReminders(ctx context.Context) {
+	const batchSize = 100
+	totalReminded := 0
+
+	for {
+		reminders, err := w.db.MarkRescheduleReminders(ctx, batchSize, w.lead)
+		if err != nil {
+			w.logger.Error("Failed to mark reschedule reminders", "error", err)
+			return
+		}
+
+		if len(reminders) == 0 {
+			break
+		}
+
+		totalReminded += len(reminders)
+
+		for _, reminder := range reminders {
+			w.notifier.RescheduleReminder(reminder)
+		}
+
+		if len(reminders) < batchSize {
+			break
+		}
+	}
+
+	if totalReminded > 0 {
+		w.logger.Info("Reminded players to acknowledge rescheduled events", "count", totalReminded)
+	} else {
+		w.logger.Debug("No reschedule reminders due")
+	}
+}
@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/jobs/mocks"
+)
+
+const testRescheduleLead = 24 * time.Hour
+
+func TestProcessRescheduleReminders_NoReminders(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReminderDb(t)
+	mockNotifier := mocks.NewMockReminderNotifier(t)
+	mockDb.EXPECT().MarkRescheduleReminders(ctx, 100, testRescheduleLead).Return(nil, nil).Once()
+
+	worker := NewReminderWorker(mockDb, mockNotifier, testRescheduleLead)
+	worker.processRescheduleReminders(ctx)
+}
+
+func TestProcessRescheduleReminders_RemindsPlayers(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReminderDb(t)
+	mockNotifier := mocks.NewMockReminderNotifier(t)
+
+	dt := time.Date(2025, 6, 10, 18, 0, 0, 0, time.UTC)
+	reminders := []db.RescheduleReminder{
+		{JoinRequestId: "jr_1", UserId: "user_1", EventId: "event_1", HostUserId: "host", LocationId: "court", DateTime: dt, Version: 2},
+		{JoinRequestId: "jr_2", UserId: "user_2", EventId: "event_1", HostUserId: "host", LocationId: "court", DateTime: dt, Version: 2},
+	}
+	mockDb.EXPECT().MarkRescheduleReminders(ctx, 100, testRescheduleLead).Return(reminders, nil).Once()
+
+	mockNotifier.EXPECT().RescheduleReminder(reminders[0]).Return().Once()
+	mockNotifier.EXPECT().RescheduleReminder(reminders[1]).Return().Once()
+
+	worker := NewReminderWorker(mockDb, mockNotifier, testRescheduleLead)
+	worker.processRescheduleReminders(ctx)
+}
+
+func TestProcessRescheduleReminders_MultipleBatches(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReminderDb(t)
+	mockNotifier := mocks.NewMockReminderNotifier(t)
+
+	firstBatch := make([]db.RescheduleReminder, 100)
+	for i := range firstBatch {
+		firstBatch[i] = db.RescheduleReminder{JoinRequestId: fmt.Sprintf("jr_%d", i), UserId: "user_1"}
+	}
+	secondBatch := []db.RescheduleReminder{
+		{JoinRequestId: "jr_last", UserId: "user_2"},
+	}
+
+	mockDb.EXPECT().MarkRescheduleReminders(ctx, 100, testRescheduleLead).Return(firstBatch, nil).Once()
+	mockDb.EXPECT().MarkRescheduleReminders(ctx, 100, testRescheduleLead).Return(secondBatch, nil).Once()
+
+	for _, reminder := range append(firstBatch, secondBatch...) {
+		mockNotifier.EXPECT().RescheduleReminder(reminder).Return().Once()
+	}
+
+	worker := NewReminderWorker(mockDb, mockNotifier, testRescheduleLead)
+	worker.processRescheduleReminders(ctx)
+}
+
+func TestProcessRescheduleReminders_DatabaseError(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReminderDb(t)
+	mockNotifier := mocks.NewMockReminderNotifier(t)
+
+	mockDb.EXPECT().MarkRescheduleReminders(ctx, 100, testRescheduleLead).Return(nil, errors.New("database connection failed")).Once()
+
+	worker := NewReminderWorker(mockDb, mockNotifier, testRescheduleLead)
+	worker.processRescheduleReminders(ctx)
+}
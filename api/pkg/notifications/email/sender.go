@@ -202,6 +202,42 @@ func (s *Sender) renderTemplate(data db.NotificationQueueData) (*RenderedEmail,
 		return s.renderEventExpired(data.TemplateData)
 	case notifications.TemplateChatMessage:
 		return s.renderChatMessage(data.TemplateData)
+	case notifications.TemplateEventCancelled:
+		return s.renderEventCancelled(data.TemplateData)
+	case notifications.TemplateEventUpdated:
+		return s.renderEventUpdated(data.TemplateData)
+	case notifications.TemplateEventCompleted:
+		return s.renderEventCompleted(data.TemplateData)
+	case notifications.TemplateWaitlistPromoted:
+		return s.renderWaitlistPromoted(data.TemplateData)
+	case notifications.TemplateJoinRequestAccepted:
+		return s.templateRenderer.RenderJoinRequestAccepted(joinRequestAnsweredData(data.TemplateData))
+	case notifications.TemplateJoinRequestRejected:
+		return s.templateRenderer.RenderJoinRequestRejected(joinRequestAnsweredData(data.TemplateData))
+	case notifications.TemplateEventInvitation:
+		return s.templateRenderer.RenderEventInvitation(EventInvitationData{
+			RecipientName: getStringFromMap(data.TemplateData, "RecipientName"),
+			HostName:      getStringFromMap(data.TemplateData, "HostName"),
+			EventId:       getStringFromMap(data.TemplateData, "EventId"),
+		})
+	case notifications.TemplateEventRescheduled:
+		return s.templateRenderer.RenderEventRescheduled(EventRescheduledData{
+			RecipientName: getStringFromMap(data.TemplateData, "RecipientName"),
+			HostName:      getStringFromMap(data.TemplateData, "HostName"),
+			EventId:       getStringFromMap(data.TemplateData, "EventId"),
+			DateTime:      getStringFromMap(data.TemplateData, "DateTime"),
+			Location:      getStringFromMap(data.TemplateData, "Location"),
+			Reason:        getStringFromMap(data.TemplateData, "Reason"),
+			IsReminder:    getBoolFromMap(data.TemplateData, "IsReminder"),
+		})
+	case notifications.TemplateReservationFailed:
+		return s.templateRenderer.RenderReservationFailed(ReservationFailedData{
+			RecipientName: getStringFromMap(data.TemplateData, "RecipientName"),
+			IsHost:        getBoolFromMap(data.TemplateData, "IsHost"),
+			HostName:      getStringFromMap(data.TemplateData, "HostName"),
+			EventId:       getStringFromMap(data.TemplateData, "EventId"),
+			Reason:        getStringFromMap(data.TemplateData, "Reason"),
+		})
 	default:
 		return nil, nil
 	}
@@ -215,16 +251,11 @@ func (s *Sender) renderEventConfirmed(data map[string]interface{}) (*RenderedEma
 		DateTime:      getStringFromMap(data, "DateTime"),
 		Location:      getStringFromMap(data, "Location"),
 		EventId:       getStringFromMap(data, "EventId"),
+		CourtCost:     getStringFromMap(data, "CourtCost"),
+		CostPerPlayer: getStringFromMap(data, "CostPerPlayer"),
 	}
-	if players, ok := data["ConfirmedPlayers"].([]string); ok {
-		templateData.ConfirmedPlayers = players
-	} else if players, ok := data["ConfirmedPlayers"].([]interface{}); ok {
-		for _, p := range players {
-			if str, ok := p.(string); ok {
-				templateData.ConfirmedPlayers = append(templateData.ConfirmedPlayers, str)
-			}
-		}
-	}
+	templateData.ConfirmedPlayers = getStringSliceFromMap(data, "ConfirmedPlayers")
+	templateData.Teams = getStringSliceFromMap(data, "Teams")
 	return s.templateRenderer.RenderEventConfirmed(templateData)
 }
 
@@ -242,6 +273,7 @@ func (s *Sender) renderEventExpired(data map[string]interface{}) (*RenderedEmail
 	templateData := EventExpiredData{
 		RecipientName: getStringFromMap(data, "RecipientName"),
 		EventId:       getStringFromMap(data, "EventId"),
+		SeriesId:      getStringFromMap(data, "SeriesId"),
 	}
 	return s.templateRenderer.RenderEventExpired(templateData)
 }
@@ -254,6 +286,62 @@ func (s *Sender) renderChatMessage(data map[string]interface{}) (*RenderedEmail,
 	return s.templateRenderer.RenderChatMessage(templateData)
 }
 
+func (s *Sender) renderEventCancelled(data map[string]interface{}) (*RenderedEmail, error) {
+	templateData := EventCancelledData{
+		RecipientName: getStringFromMap(data, "RecipientName"),
+		HostName:      getStringFromMap(data, "HostName"),
+		EventId:       getStringFromMap(data, "EventId"),
+		SeriesId:      getStringFromMap(data, "SeriesId"),
+		Occurrences:   getIntFromMap(data, "Occurrences"),
+		Reason:        getStringFromMap(data, "Reason"),
+		WasConfirmed:  getBoolFromMap(data, "WasConfirmed"),
+	}
+	return s.templateRenderer.RenderEventCancelled(templateData)
+}
+
+func (s *Sender) renderEventUpdated(data map[string]interface{}) (*RenderedEmail, error) {
+	templateData := EventUpdatedData{
+		RecipientName:    getStringFromMap(data, "RecipientName"),
+		HostName:         getStringFromMap(data, "HostName"),
+		EventId:          getStringFromMap(data, "EventId"),
+		RequestRemoved:   getBoolFromMap(data, "RequestRemoved"),
+		RemovedLocations: getStringSliceFromMap(data, "RemovedLocations"),
+		RemovedTimeSlots: getStringSliceFromMap(data, "RemovedTimeSlots"),
+	}
+	return s.templateRenderer.RenderEventUpdated(templateData)
+}
+
+func (s *Sender) renderEventCompleted(data map[string]interface{}) (*RenderedEmail, error) {
+	templateData := EventCompletedData{
+		RecipientName:  getStringFromMap(data, "RecipientName"),
+		IsHost:         getBoolFromMap(data, "IsHost"),
+		EventId:        getStringFromMap(data, "EventId"),
+		PostMatchUntil: getStringFromMap(data, "PostMatchUntil"),
+	}
+	return s.templateRenderer.RenderEventCompleted(templateData)
+}
+
+func (s *Sender) renderWaitlistPromoted(data map[string]interface{}) (*RenderedEmail, error) {
+	templateData := WaitlistPromotedData{
+		RecipientName: getStringFromMap(data, "RecipientName"),
+		HostName:      getStringFromMap(data, "HostName"),
+		EventId:       getStringFromMap(data, "EventId"),
+		Accepted:      getBoolFromMap(data, "Accepted"),
+		DateTime:      getStringFromMap(data, "DateTime"),
+		Location:      getStringFromMap(data, "Location"),
+	}
+	return s.templateRenderer.RenderWaitlistPromoted(templateData)
+}
+
+func joinRequestAnsweredData(data map[string]interface{}) JoinRequestAnsweredData {
+	return JoinRequestAnsweredData{
+		RecipientName: getStringFromMap(data, "RecipientName"),
+		HostName:      getStringFromMap(data, "HostName"),
+		EventId:       getStringFromMap(data, "EventId"),
+		HostMessage:   getStringFromMap(data, "HostMessage"),
+	}
+}
+
 func getStringFromMap(m map[string]interface{}, key string) string {
 	if v, ok := m[key]; ok {
 		if str, ok := v.(string); ok {
@@ -272,6 +360,34 @@ func getBoolFromMap(m map[string]interface{}, key string) bool {
 	return false
 }
 
+// getStringSliceFromMap also accepts []interface{} as slices come back untyped after the queue JSON round trip
+func getStringSliceFromMap(m map[string]interface{}, key string) []string {
+	switch v := m[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// getIntFromMap also accepts float64 as numbers come back as float64 after the queue JSON round trip
+func getIntFromMap(m map[string]interface{}, key string) int {
+	switch v := m[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
 // sendRendered sends a rendered email with both HTML and plain text parts
 func (s *Sender) sendRendered(ctx context.Context, address string, rendered *RenderedEmail) error {
 	if !s.enabled {
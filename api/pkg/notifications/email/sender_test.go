@@ -229,6 +229,42 @@ func TestRenderEventExpired_DataTransformation(t *testing.T) {
 	})
 }
 
+func TestRenderEventCancelled_DataTransformation(t *testing.T) {
+	sender := newTestSenderWithTemplates(t)
+
+	// Occurrences is a float64 once the template data went through the queue
+	data := map[string]interface{}{
+		"RecipientName": "Alice",
+		"HostName":      "Bob",
+		"EventId":       "event-1",
+		"SeriesId":      "series-1",
+		"Occurrences":   float64(3),
+	}
+
+	rendered, err := sender.renderEventCancelled(data)
+	require.NoError(t, err)
+	assert.Contains(t, rendered.HTMLBody, "3 occurrences")
+	assert.Contains(t, rendered.PlainBody, "Bob has cancelled 3 occurrences")
+}
+
+func TestRenderEventUpdated_DataTransformation(t *testing.T) {
+	sender := newTestSenderWithTemplates(t)
+
+	// Slices are []interface{} once the template data went through the queue
+	data := map[string]interface{}{
+		"HostName":         "Bob",
+		"EventId":          "event-1",
+		"RequestRemoved":   false,
+		"RemovedLocations": []interface{}{"Spartan Courts"},
+		"RemovedTimeSlots": []interface{}{"2025-06-02T18:00:00Z"},
+	}
+
+	rendered, err := sender.renderEventUpdated(data)
+	require.NoError(t, err)
+	assert.Contains(t, rendered.HTMLBody, "Spartan Courts")
+	assert.Contains(t, rendered.PlainBody, "- Time: 2025-06-02T18:00:00Z")
+}
+
 func TestRenderTemplate_RoutesToCorrectRenderer(t *testing.T) {
 	sender := newTestSenderWithTemplates(t)
 
@@ -252,6 +288,51 @@ func TestRenderTemplate_RoutesToCorrectRenderer(t *testing.T) {
 			templateType: notifications.TemplateEventExpired,
 			expectNil:    false,
 		},
+		{
+			name:         "event_cancelled",
+			templateType: notifications.TemplateEventCancelled,
+			expectNil:    false,
+		},
+		{
+			name:         "event_updated",
+			templateType: notifications.TemplateEventUpdated,
+			expectNil:    false,
+		},
+		{
+			name:         "event_completed",
+			templateType: notifications.TemplateEventCompleted,
+			expectNil:    false,
+		},
+		{
+			name:         "waitlist_promoted",
+			templateType: notifications.TemplateWaitlistPromoted,
+			expectNil:    false,
+		},
+		{
+			name:         "join_request_accepted",
+			templateType: notifications.TemplateJoinRequestAccepted,
+			expectNil:    false,
+		},
+		{
+			name:         "join_request_rejected",
+			templateType: notifications.TemplateJoinRequestRejected,
+			expectNil:    false,
+		},
+		{
+			name:         "event_invitation",
+			templateType: notifications.TemplateEventInvitation,
+			expectNil:    false,
+		},
+		{
+			name:         "event_rescheduled",
+			templateType: notifications.TemplateEventRescheduled,
+			expectNil:    false,
+		},
+		{
+			name:         "reservation_failed",
+			templateType: notifications.TemplateReservationFailed,
+			expectNil:    false,
+		},
 		{
 			name:         "unknown type returns nil",
 			templateType: "unknown_type",
@@ -29,9 +29,12 @@ type EventConfirmedData struct {
 	DateTime         string
 	Location         string
 	ConfirmedPlayers []string
-	EventId          string // Used to construct EventURL and CalendarURL
-	EventURL         string // Populated by renderer
-	CalendarURL      string // Populated by renderer — ICS download link
+	Teams            []string // Player names of each team, empty when no teams were set
+	CourtCost        string   // Court price of the session, empty when not known
+	CostPerPlayer    string   // Share of each player, empty when not known
+	EventId          string   // Used to construct EventURL and CalendarURL
+	EventURL         string   // Populated by renderer
+	CalendarURL      string   // Populated by renderer — ICS download link
 }
 
 // UserJoinedData contains data for join request notification emails
@@ -48,8 +51,10 @@ type UserJoinedData struct {
 type EventExpiredData struct {
 	BaseTemplateData
 	RecipientName  string
-	EventId        string // Used to construct CreateEventURL
+	EventId        string // Used to construct EventURL for series occurrences
+	SeriesId       string // Set when the expired event is an occurrence of a series
 	CreateEventURL string // Populated by renderer
+	EventURL       string // Populated by renderer
 }
 
 // ChatMessageData contains data for chat message notification emails
@@ -60,6 +65,98 @@ type ChatMessageData struct {
 	EventURL   string // Populated by renderer
 }
 
+// EventCancelledData contains data for event cancellation emails
+type EventCancelledData struct {
+	BaseTemplateData
+	RecipientName string
+	HostName      string
+	EventId       string // Used to construct EventURL
+	SeriesId      string
+	Occurrences   int
+	Reason        string
+	WasConfirmed  bool
+	EventURL      string // Populated by renderer
+	CalendarURL   string // Populated by renderer for confirmed events — ICS cancellation
+}
+
+// EventUpdatedData contains data for event update emails sent to affected joiners
+type EventUpdatedData struct {
+	BaseTemplateData
+	RecipientName    string
+	HostName         string
+	EventId          string // Used to construct EventURL
+	RequestRemoved   bool
+	RemovedLocations []string
+	RemovedTimeSlots []string
+	EventURL         string // Populated by renderer
+}
+
+// EventCompletedData contains data for post-match emails sent once an event is played
+type EventCompletedData struct {
+	BaseTemplateData
+	RecipientName  string
+	IsHost         bool
+	EventId        string // Used to construct EventURL
+	PostMatchUntil string
+	EventURL       string // Populated by renderer
+}
+
+// WaitlistPromotedData contains data for emails sent to players moved up from the waitlist
+type WaitlistPromotedData struct {
+	BaseTemplateData
+	RecipientName string
+	HostName      string
+	EventId       string // Used to construct EventURL
+	Accepted      bool
+	DateTime      string
+	Location      string
+	EventURL      string // Populated by renderer
+}
+
+// JoinRequestAnsweredData contains data for emails telling a joiner that the host accepted or rejected their request
+type JoinRequestAnsweredData struct {
+	BaseTemplateData
+	RecipientName string
+	HostName      string
+	EventId       string // Used to construct EventURL
+	HostMessage   string
+	EventURL      string // Populated by renderer
+}
+
+// EventInvitationData contains data for emails inviting a user to a private event
+type EventInvitationData struct {
+	BaseTemplateData
+	RecipientName string
+	HostName      string
+	EventId       string // Used to construct EventURL
+	EventURL      string // Populated by renderer
+}
+
+// EventRescheduledData contains data for emails telling accepted players the confirmed event was moved
+type EventRescheduledData struct {
+	BaseTemplateData
+	RecipientName string
+	HostName      string
+	EventId       string // Used to construct EventURL
+	DateTime      string
+	Location      string
+	Reason        string
+	IsReminder    bool   // Reminder sent shortly before the session to players who have not confirmed yet
+	EventURL      string // Populated by renderer
+	CalendarURL   string // Populated by renderer — the updated ICS replaces the previous one
+}
+
+// ReservationFailedData contains data for emails telling players the court of the confirmed event could not be booked
+type ReservationFailedData struct {
+	BaseTemplateData
+	RecipientName string
+	IsHost        bool
+	HostName      string
+	EventId       string // Used to construct EventURL
+	Reason        string
+	EventURL      string // Populated by renderer
+}
+
 // TemplateRenderer handles email template rendering
 type TemplateRenderer struct {
 	htmlTemplates *htmltemplate.Template
@@ -157,6 +254,14 @@ func (r *TemplateRenderer) RenderEventExpired(data EventExpiredData) (*RenderedE
 	subject := "🎾 Your event has expired"
 	data.PreviewText = "Your event expired without any participants joining"
 
+	if data.SeriesId != "" {
+		if data.EventURL == "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		}
+		subject = "🎾 An occurrence of your recurring event has expired"
+		data.PreviewText = "One occurrence of your recurring event expired without any participants joining"
+	}
+
 	return r.render(notifications.TemplateEventExpired, subject, data)
 }
 
@@ -179,6 +284,197 @@ func (r *TemplateRenderer) RenderChatMessage(data ChatMessageData) (*RenderedEma
 	return r.render(notifications.TemplateChatMessage, subject, data)
 }
 
+// RenderEventCancelled renders the event cancellation email
+func (r *TemplateRenderer) RenderEventCancelled(data EventCancelledData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	if data.WasConfirmed && data.CalendarURL == "" && data.EventId != "" {
+		data.CalendarURL = r.domainName + "/api/events/public/" + data.EventId + "/calendar.ics"
+	}
+
+	subject := "🎾 An event you joined has been cancelled"
+	data.PreviewText = fmt.Sprintf("%s has cancelled the event", data.HostName)
+	if data.Occurrences > 1 {
+		subject = "🎾 A recurring event you joined has been cancelled"
+		data.PreviewText = fmt.Sprintf("%s has cancelled %d occurrences of the event", data.HostName, data.Occurrences)
+	}
+
+	return r.render(notifications.TemplateEventCancelled, subject, data)
+}
+
+// RenderEventUpdated renders the event update email
+func (r *TemplateRenderer) RenderEventUpdated(data EventUpdatedData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 An event you joined has been changed"
+	data.PreviewText = fmt.Sprintf("%s has changed the event you asked to join", data.HostName)
+	if data.RequestRemoved {
+		data.PreviewText = fmt.Sprintf("%s has changed the event and your join request no longer fits it", data.HostName)
+	}
+
+	return r.render(notifications.TemplateEventUpdated, subject, data)
+}
+
+// RenderEventCompleted renders the post-match email
+func (r *TemplateRenderer) RenderEventCompleted(data EventCompletedData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 How was your game?"
+	data.PreviewText = "Report the result and leave feedback for your game"
+
+	return r.render(notifications.TemplateEventCompleted, subject, data)
+}
+
+// RenderWaitlistPromoted renders the email for a player moved up from the waitlist
+func (r *TemplateRenderer) RenderWaitlistPromoted(data WaitlistPromotedData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 A spot opened up for you"
+	data.PreviewText = "You moved up from the waitlist"
+	if data.Accepted {
+		subject = "🎾 You're in! A spot opened up for you"
+		data.PreviewText = "You moved up from the waitlist and are now playing in the confirmed session"
+	}
+
+	return r.render(notifications.TemplateWaitlistPromoted, subject, data)
+}
+
+// RenderJoinRequestAccepted renders the email for a joiner whose request was accepted
+func (r *TemplateRenderer) RenderJoinRequestAccepted(data JoinRequestAnsweredData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 Your join request has been accepted"
+	data.PreviewText = fmt.Sprintf("%s has accepted your join request", data.HostName)
+
+	return r.render(notifications.TemplateJoinRequestAccepted, subject, data)
+}
+
+// RenderJoinRequestRejected renders the email for a joiner whose request was rejected
+func (r *TemplateRenderer) RenderJoinRequestRejected(data JoinRequestAnsweredData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	// the event is no longer an option, point to the list of other events instead
+	if data.EventURL == "" {
+		data.EventURL = r.domainName
+	}
+
+	subject := "🎾 Your join request has been declined"
+	data.PreviewText = fmt.Sprintf("%s has declined your join request", data.HostName)
+
+	return r.render(notifications.TemplateJoinRequestRejected, subject, data)
+}
+
+// RenderEventInvitation renders the email for a user invited to a private event
+func (r *TemplateRenderer) RenderEventInvitation(data EventInvitationData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 You have been invited to an event"
+	data.PreviewText = fmt.Sprintf("%s has invited you to a private event", data.HostName)
+
+	return r.render(notifications.TemplateEventInvitation, subject, data)
+}
+
+// RenderEventRescheduled renders the email for accepted players of a rescheduled event
+func (r *TemplateRenderer) RenderEventRescheduled(data EventRescheduledData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	if data.CalendarURL == "" && data.EventId != "" {
+		data.CalendarURL = r.domainName + "/api/events/public/" + data.EventId + "/calendar.ics"
+	}
+
+	subject := "🎾 Your event has been rescheduled"
+	data.PreviewText = fmt.Sprintf("%s has moved the event, please confirm you can still play", data.HostName)
+	if data.IsReminder {
+		subject = "🎾 Reminder: please confirm the rescheduled event"
+		data.PreviewText = fmt.Sprintf("%s has moved the event and you have not confirmed it yet", data.HostName)
+	}
+
+	return r.render(notifications.TemplateEventRescheduled, subject, data)
+}
+
+// RenderReservationFailed renders the email for players of an event whose court could not be booked
+func (r *TemplateRenderer) RenderReservationFailed(data ReservationFailedData) (*RenderedEmail, error) {
+	data.LogoURL = r.domainName + "/logo.png"
+	data.AppURL = r.domainName
+
+	if data.EventURL == "" {
+		if data.EventId != "" {
+			data.EventURL = r.domainName + "/events/" + data.EventId
+		} else {
+			data.EventURL = r.domainName
+		}
+	}
+
+	subject := "🎾 The court could not be booked"
+	data.PreviewText = "The court for your confirmed event could not be booked"
+
+	return r.render(notifications.TemplateReservationFailed, subject, data)
+}
+
 // render executes both HTML and text templates for a given template type
 func (r *TemplateRenderer) render(tmplType string, subject string, data interface{}) (*RenderedEmail, error) {
 	htmlName := tmplType + ".html"
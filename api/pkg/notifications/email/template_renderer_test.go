@@ -83,6 +83,31 @@ func TestRenderEventConfirmed_AsParticipant(t *testing.T) {
 	assert.Contains(t, result.PlainBody, "John Host")
 }
 
+func TestRenderEventConfirmed_WithCourtCost(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	data := EventConfirmedData{
+		RecipientName: "Alice Player",
+		HostName:      "John Host",
+		DateTime:      "Sunday, Jan 16 at 2:00 PM",
+		Location:      "City Sports Center",
+		CourtCost:     "100.00",
+		CostPerPlayer: "33.34",
+	}
+
+	result, err := renderer.RenderEventConfirmed(data)
+	require.NoError(t, err)
+
+	assert.Contains(t, result.HTMLBody, "100.00 (33.34 per player, to be paid to John Host)")
+	assert.Contains(t, result.PlainBody, "Court cost: 100.00 (33.34 per player, to be paid to John Host)")
+
+	data.IsHost = true
+	result, err = renderer.RenderEventConfirmed(data)
+	require.NoError(t, err)
+	assert.Contains(t, result.PlainBody, "Court cost: 100.00 (33.34 per player)")
+}
+
 func TestRenderEventConfirmed_DefaultEventURL(t *testing.T) {
 	renderer, err := NewTemplateRenderer(testDomainName)
 	require.NoError(t, err)
@@ -237,6 +262,328 @@ func TestRenderEventExpired_WithoutRecipientName(t *testing.T) {
 	assert.Contains(t, result.PlainBody, "expired")
 }
 
+func TestRenderEventExpired_SeriesOccurrence(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	data := EventExpiredData{
+		RecipientName: "John",
+		EventId:       "event-456",
+		SeriesId:      "series-1",
+	}
+
+	result, err := renderer.RenderEventExpired(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 An occurrence of your recurring event has expired", result.Subject)
+	assert.Contains(t, result.HTMLBody, "recurring event has expired")
+	assert.Contains(t, result.HTMLBody, testDomainName+"/events/event-456")
+	assert.NotContains(t, result.HTMLBody, "Create New Event")
+	assert.Contains(t, result.PlainBody, "still open")
+	assert.Contains(t, result.PlainBody, testDomainName+"/events/event-456")
+}
+
+func TestRenderEventCancelled_SingleEvent(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventCancelled(EventCancelledData{
+		RecipientName: "Alice",
+		HostName:      "Bob",
+		EventId:       "event-1",
+		Occurrences:   1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 An event you joined has been cancelled", result.Subject)
+	assert.Contains(t, result.HTMLBody, "Alice")
+	assert.Contains(t, result.HTMLBody, "has cancelled the event")
+	assert.Contains(t, result.HTMLBody, testDomainName+"/events/event-1")
+	assert.Contains(t, result.PlainBody, "Bob has cancelled the event you asked to join")
+}
+
+func TestRenderEventCancelled_SeriesOccurrences(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventCancelled(EventCancelledData{
+		RecipientName: "Alice",
+		HostName:      "Bob",
+		EventId:       "event-1",
+		SeriesId:      "series-1",
+		Occurrences:   4,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 A recurring event you joined has been cancelled", result.Subject)
+	assert.Contains(t, result.HTMLBody, "4 occurrences of the recurring event")
+	assert.Contains(t, result.PlainBody, "4 occurrences of the recurring event")
+}
+
+func TestRenderEventUpdated_RemovedOptions(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventUpdated(EventUpdatedData{
+		RecipientName:    "Alice",
+		HostName:         "Bob",
+		EventId:          "event-1",
+		RemovedLocations: []string{"Spartan Courts"},
+		RemovedTimeSlots: []string{"2025-06-02T18:00:00Z"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 An event you joined has been changed", result.Subject)
+	assert.Contains(t, result.HTMLBody, "Spartan Courts")
+	assert.Contains(t, result.HTMLBody, "still active")
+	assert.Contains(t, result.HTMLBody, testDomainName+"/events/event-1")
+	assert.Contains(t, result.PlainBody, "- Location: Spartan Courts")
+	assert.Contains(t, result.PlainBody, "- Time: 2025-06-02T18:00:00Z")
+}
+
+func TestRenderEventUpdated_RequestRemoved(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventUpdated(EventUpdatedData{
+		HostName:         "Bob",
+		EventId:          "event-1",
+		RequestRemoved:   true,
+		RemovedLocations: []string{"Spartan Courts"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, result.HTMLBody, "has been withdrawn")
+	assert.Contains(t, result.PlainBody, "has been withdrawn")
+	assert.NotContains(t, result.PlainBody, "still active")
+}
+
+func TestRenderEventCancelled_ReasonAndCalendar(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventCancelled(EventCancelledData{
+		HostName:     "Bob",
+		EventId:      "event-1",
+		Occurrences:  1,
+		Reason:       "Court is flooded",
+		WasConfirmed: true,
+	})
+	require.NoError(t, err)
+
+	calendarURL := testDomainName + "/api/events/public/event-1/calendar.ics"
+	assert.Contains(t, result.HTMLBody, "Court is flooded")
+	assert.Contains(t, result.HTMLBody, calendarURL)
+	assert.Contains(t, result.PlainBody, "Reason: Court is flooded")
+	assert.Contains(t, result.PlainBody, "Remove it from your calendar: "+calendarURL)
+
+	result, err = renderer.RenderEventCancelled(EventCancelledData{
+		HostName:    "Bob",
+		EventId:     "event-1",
+		Occurrences: 1,
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, result.HTMLBody, "calendar.ics")
+	assert.NotContains(t, result.PlainBody, "Reason:")
+}
+
+func TestRenderEventCompleted(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventCompleted(EventCompletedData{
+		RecipientName:  "Alice",
+		IsHost:         true,
+		EventId:        "event-1",
+		PostMatchUntil: "2025-06-10T20:00:00Z",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 How was your game?", result.Subject)
+	assert.Contains(t, result.HTMLBody, "the game you hosted")
+	assert.Contains(t, result.HTMLBody, testDomainName+"/events/event-1")
+	assert.Contains(t, result.PlainBody, "Reports are open until 2025-06-10T20:00:00Z")
+}
+
+func TestRenderWaitlistPromoted(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	t.Run("confirmed event", func(t *testing.T) {
+		result, err := renderer.RenderWaitlistPromoted(WaitlistPromotedData{
+			RecipientName: "Bob",
+			HostName:      "Alice",
+			EventId:       "event-1",
+			Accepted:      true,
+			DateTime:      "2025-06-10T18:00:00Z",
+			Location:      "Central Courts",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "🎾 You're in! A spot opened up for you", result.Subject)
+		assert.Contains(t, result.HTMLBody, "playing in the confirmed session")
+		assert.Contains(t, result.HTMLBody, "Central Courts")
+		assert.Contains(t, result.PlainBody, "When: 2025-06-10T18:00:00Z")
+		assert.Contains(t, result.PlainBody, testDomainName+"/events/event-1")
+	})
+
+	t.Run("open event", func(t *testing.T) {
+		result, err := renderer.RenderWaitlistPromoted(WaitlistPromotedData{
+			RecipientName: "Bob",
+			HostName:      "Alice",
+			EventId:       "event-1",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "🎾 A spot opened up for you", result.Subject)
+		assert.Contains(t, result.PlainBody, "waiting for the host's confirmation")
+		assert.NotContains(t, result.PlainBody, "When:")
+	})
+}
+
+func TestRenderJoinRequestAccepted(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderJoinRequestAccepted(JoinRequestAnsweredData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+		HostMessage:   "See you on court",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 Your join request has been accepted", result.Subject)
+	assert.Contains(t, result.HTMLBody, "has accepted your request")
+	assert.Contains(t, result.HTMLBody, "See you on court")
+	assert.Contains(t, result.PlainBody, testDomainName+"/events/event-1")
+}
+
+func TestRenderJoinRequestRejected(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderJoinRequestRejected(JoinRequestAnsweredData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 Your join request has been declined", result.Subject)
+	assert.Contains(t, result.PlainBody, "Alice has declined your request")
+	assert.NotContains(t, result.PlainBody, "Message from")
+	assert.NotContains(t, result.PlainBody, "/events/event-1")
+}
+
+func TestRenderEventInvitation(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventInvitation(EventInvitationData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 You have been invited to an event", result.Subject)
+	assert.Contains(t, result.HTMLBody, "has invited you to a private event")
+	assert.Contains(t, result.PlainBody, "Alice has invited you")
+	assert.Contains(t, result.PlainBody, testDomainName+"/events/event-1")
+}
+
+func TestRenderEventRescheduled(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventRescheduled(EventRescheduledData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+		DateTime:      "2025-06-03T18:00:00Z",
+		Location:      "Court 2",
+		Reason:        "Court 1 is flooded",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 Your event has been rescheduled", result.Subject)
+	assert.Contains(t, result.HTMLBody, "Court 2")
+	assert.Contains(t, result.PlainBody, "Alice has moved your confirmed event")
+	assert.Contains(t, result.PlainBody, "When: 2025-06-03T18:00:00Z")
+	assert.Contains(t, result.PlainBody, "Reason: Court 1 is flooded")
+	assert.Contains(t, result.PlainBody, testDomainName+"/api/events/public/event-1/calendar.ics")
+	assert.NotContains(t, result.PlainBody, "Reminder")
+}
+
+func TestRenderEventRescheduled_Reminder(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventRescheduled(EventRescheduledData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+		DateTime:      "2025-06-03T18:00:00Z",
+		Location:      "Court 2",
+		IsReminder:    true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 Reminder: please confirm the rescheduled event", result.Subject)
+	assert.Contains(t, result.HTMLBody, "Reminder: Event Rescheduled")
+	assert.Contains(t, result.PlainBody, "You have not confirmed the new time yet.")
+	assert.Contains(t, result.PlainBody, "When: 2025-06-03T18:00:00Z")
+}
+
+func TestRenderReservationFailed(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderReservationFailed(ReservationFailedData{
+		RecipientName: "Alice",
+		IsHost:        true,
+		HostName:      "Alice",
+		EventId:       "event-1",
+		Reason:        "No court is available at the confirmed time",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "🎾 The court could not be booked", result.Subject)
+	assert.Contains(t, result.PlainBody, "the court for your confirmed event could not be booked")
+	assert.Contains(t, result.PlainBody, "Reason: No court is available at the confirmed time")
+	assert.Contains(t, result.PlainBody, "Reschedule the event")
+
+	result, err = renderer.RenderReservationFailed(ReservationFailedData{
+		RecipientName: "Bob",
+		HostName:      "Alice",
+		EventId:       "event-1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.HTMLBody, "the event hosted by <strong>Alice</strong>")
+	assert.Contains(t, result.PlainBody, "The host will reschedule the event or cancel it")
+}
+
+func TestRenderEventConfirmed_WithTeams(t *testing.T) {
+	renderer, err := NewTemplateRenderer(testDomainName)
+	require.NoError(t, err)
+
+	result, err := renderer.RenderEventConfirmed(EventConfirmedData{
+		RecipientName:    "Alice",
+		HostName:         "Alice",
+		IsHost:           true,
+		DateTime:         "Monday at 10am",
+		Location:         "Court 1",
+		ConfirmedPlayers: []string{"Bob", "Carol", "Dave"},
+		Teams:            []string{"Alice & Carol", "Bob & Dave"},
+		EventId:          "event-1",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, result.HTMLBody, "Alice &amp; Carol vs Bob &amp; Dave")
+	assert.Contains(t, result.PlainBody, "Teams: Alice & Carol vs Bob & Dave")
+}
+
 func TestTemplateRenderer_HTMLStructure(t *testing.T) {
 	renderer, err := NewTemplateRenderer(testDomainName)
 	require.NoError(t, err)
@@ -316,6 +663,60 @@ func TestTemplateRenderer_AllTemplatesHaveBothFormats(t *testing.T) {
 				return renderer.RenderEventExpired(EventExpiredData{})
 			},
 		},
+		{
+			name: "EventCancelled",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderEventCancelled(EventCancelledData{})
+			},
+		},
+		{
+			name: "EventUpdated",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderEventUpdated(EventUpdatedData{})
+			},
+		},
+		{
+			name: "EventCompleted",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderEventCompleted(EventCompletedData{})
+			},
+		},
+		{
+			name: "WaitlistPromoted",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderWaitlistPromoted(WaitlistPromotedData{})
+			},
+		},
+		{
+			name: "JoinRequestAccepted",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderJoinRequestAccepted(JoinRequestAnsweredData{})
+			},
+		},
+		{
+			name: "JoinRequestRejected",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderJoinRequestRejected(JoinRequestAnsweredData{})
+			},
+		},
+		{
+			name: "EventInvitation",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderEventInvitation(EventInvitationData{})
+			},
+		},
+		{
+			name: "EventRescheduled",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderEventRescheduled(EventRescheduledData{})
+			},
+		},
+		{
+			name: "ReservationFailed",
+			render: func() (*RenderedEmail, error) {
+				return renderer.RenderReservationFailed(ReservationFailedData{})
+			},
+		},
 	}
 
 	for _, tt := range tests {
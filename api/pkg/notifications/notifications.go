@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/xtp-tour/xtp-tour/api/pkg/api"
 	"github.com/xtp-tour/xtp-tour/api/pkg/db"
@@ -37,7 +38,7 @@ func NewNotifier(db NotifierDb, queue Queue) *Notifier {
 	}
 }
 
-func (d *Notifier) EventConfirmed(logCtx *slog.Logger, eventId string, joinRequestIds []string, dateTime string, locationId string, hostUserId string) {
+func (d *Notifier) EventConfirmed(logCtx *slog.Logger, eventId string, joinRequestIds []string, dateTime string, locationId string, hostUserId string, teams [][]string, courtCost *api.CourtCost) {
 	ctx := context.Background()
 
 	// Get user notification settings
@@ -84,6 +85,16 @@ func (d *Notifier) EventConfirmed(logCtx *slog.Logger, eventId string, joinReque
 		hostName = "Unknown Host"
 	}
 
+	// each team is listed as the names of its players, e.g. "Alice & Bob"
+	teamNames := []string{}
+	for _, team := range teams {
+		names := make([]string, len(team))
+		for i, id := range team {
+			names[i] = userNames[id]
+		}
+		teamNames = append(teamNames, strings.Join(names, " & "))
+	}
+
 	for userId, prefs := range notifPrefs {
 		isHost := prefs.IsHost == 1
 		msg := ""
@@ -97,6 +108,15 @@ func (d *Notifier) EventConfirmed(logCtx *slog.Logger, eventId string, joinReque
 		} else {
 			continue // Skip users who are neither host nor accepted
 		}
+		if len(teamNames) > 0 {
+			msg += " Teams: " + strings.Join(teamNames, " vs ") + "."
+		}
+		courtCostText, costPerPlayerText := "", ""
+		if courtCost != nil {
+			courtCostText = fmt.Sprintf("%.2f", courtCost.Total)
+			costPerPlayerText = fmt.Sprintf("%.2f", courtCost.PerPlayer)
+			msg += fmt.Sprintf(" Court cost: %s, %s per player.", courtCostText, costPerPlayerText)
+		}
 
 		notificationData := db.NotificationQueueData{
 			Topic:        "You have a training session scheduled",
@@ -110,6 +130,9 @@ func (d *Notifier) EventConfirmed(logCtx *slog.Logger, eventId string, joinReque
 				TemplateDataKeys.Location:         facilityName,
 				TemplateDataKeys.ConfirmedPlayers: confirmedUsers,
 				TemplateDataKeys.EventId:          eventId,
+				TemplateDataKeys.Teams:            teamNames,
+				TemplateDataKeys.CourtCost:        courtCostText,
+				TemplateDataKeys.CostPerPlayer:    costPerPlayerText,
 			},
 		}
 
@@ -252,3 +275,501 @@ func (d *Notifier) EventExpired(userId string, eventId string) {
 
 	logCtx.Debug("EventExpired notification enqueued")
 }
+
+// SeriesOccurrenceExpired notifies the owner that one occurrence of their series expired without participants.
+// Other occurrences of the series are not affected, so the owner is pointed to the series rather than asked to create a new event.
+func (d *Notifier) SeriesOccurrenceExpired(userId string, eventId string, seriesId string) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", userId, "eventId", eventId, "seriesId", seriesId)
+
+	notificationData := db.NotificationQueueData{
+		Topic:        "Event Expired",
+		Message:      "One occurrence of your recurring event has expired without any participants joining. The other occurrences of the series are still open.",
+		TemplateType: TemplateEventExpired,
+		TemplateData: map[string]interface{}{
+			TemplateDataKeys.RecipientName: "",
+			TemplateDataKeys.EventId:       eventId,
+			TemplateDataKeys.SeriesId:      seriesId,
+		},
+	}
+
+	err := d.queue.Enqueue(ctx, userId, notificationData)
+	if err != nil {
+		logCtx.Error("Failed to enqueue series occurrence expired notification", "error", err)
+		return
+	}
+
+	logCtx.Debug("SeriesOccurrenceExpired notification enqueued")
+}
+
+// EventsCancelled notifies the players whose active join requests were cancelled with the events,
+// players who were rejected or left before are not notified.
+// Occurrences of a series are grouped so that each joiner receives a single notification.
+// wasConfirmed tells that a single confirmed event was cancelled and players should drop it from their calendars.
+func (d *Notifier) EventsCancelled(hostUserId string, seriesId string, events []db.CancelledEvent, reason string, wasConfirmed bool) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "seriesId", seriesId)
+
+	if len(events) == 0 {
+		return
+	}
+
+	// joiner id -> cancelled events they joined, in the given order
+	joined := map[string][]string{}
+	var joinerIds []string
+	for _, event := range events {
+		for _, userId := range event.JoinerIds {
+			if userId == hostUserId {
+				continue
+			}
+			if _, ok := joined[userId]; !ok {
+				joinerIds = append(joinerIds, userId)
+			}
+			joined[userId] = append(joined[userId], event.EventId)
+		}
+	}
+
+	if len(joinerIds) == 0 {
+		logCtx.Debug("No joiners to notify about cancellation")
+		return
+	}
+
+	userNames, err := d.db.GetUserNames(ctx, append(joinerIds, hostUserId))
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	hostName := userNames[hostUserId]
+	if hostName == "" {
+		hostName = "The host"
+	}
+
+	for _, userId := range joinerIds {
+		cancelled := joined[userId]
+
+		msg := fmt.Sprintf("Hello %s, %s has cancelled the event you asked to join.", userNames[userId], hostName)
+		if seriesId != "" && len(cancelled) > 1 {
+			msg = fmt.Sprintf("Hello %s, %s has cancelled %d occurrences of the recurring event you asked to join.", userNames[userId], hostName, len(cancelled))
+		}
+		if reason != "" {
+			msg += " Reason: " + reason
+		}
+
+		notificationData := db.NotificationQueueData{
+			Topic:        "Event Cancelled",
+			Message:      msg,
+			TemplateType: TemplateEventCancelled,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName: userNames[userId],
+				TemplateDataKeys.HostName:      hostName,
+				TemplateDataKeys.EventId:       cancelled[0],
+				TemplateDataKeys.SeriesId:      seriesId,
+				TemplateDataKeys.Occurrences:   len(cancelled),
+				TemplateDataKeys.Reason:        reason,
+				TemplateDataKeys.WasConfirmed:  wasConfirmed,
+			},
+		}
+
+		err = d.queue.Enqueue(ctx, userId, notificationData)
+		if err != nil {
+			logCtx.Error("Failed to enqueue event cancelled notification", "error", err, "userId", userId)
+		}
+	}
+
+	logCtx.Debug("EventsCancelled notifications enqueued", "recipients", len(joinerIds))
+}
+
+// EventUpdated notifies joiners whose join request lost locations or time slots because the host edited the event
+func (d *Notifier) EventUpdated(hostUserId string, eventId string, updates []db.JoinRequestUpdate) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", eventId)
+
+	if len(updates) == 0 {
+		return
+	}
+
+	userIds := []string{hostUserId}
+	for _, u := range updates {
+		userIds = append(userIds, u.UserId)
+	}
+
+	userNames, err := d.db.GetUserNames(ctx, userIds)
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	hostName := userNames[hostUserId]
+	if hostName == "" {
+		hostName = "The host"
+	}
+
+	facilityNames := map[string]string{}
+	for _, u := range updates {
+		removedLocations := make([]string, len(u.RemovedLocations))
+		for i, locationId := range u.RemovedLocations {
+			name, ok := facilityNames[locationId]
+			if !ok {
+				name, err = d.db.GetFacilityName(ctx, locationId)
+				if err != nil {
+					logCtx.Error("Error getting facility name", "error", err, "locationId", locationId)
+					name = locationId
+				}
+				facilityNames[locationId] = name
+			}
+			removedLocations[i] = name
+		}
+
+		msg := fmt.Sprintf("Hello %s, %s has changed the event you asked to join.", userNames[u.UserId], hostName)
+		if u.Removed {
+			msg += " None of the times or places you chose are offered anymore, so your join request was cancelled. You can join again with the new options."
+		} else {
+			msg += " Some of the times or places you chose are no longer offered and were removed from your join request."
+		}
+
+		notificationData := db.NotificationQueueData{
+			Topic:        "Event Updated",
+			Message:      msg,
+			TemplateType: TemplateEventUpdated,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName:    userNames[u.UserId],
+				TemplateDataKeys.HostName:         hostName,
+				TemplateDataKeys.EventId:          eventId,
+				TemplateDataKeys.RequestRemoved:   u.Removed,
+				TemplateDataKeys.RemovedLocations: removedLocations,
+				TemplateDataKeys.RemovedTimeSlots: api.DtToIsoArray(u.RemovedTimeSlots),
+			},
+		}
+
+		err = d.queue.Enqueue(ctx, u.UserId, notificationData)
+		if err != nil {
+			logCtx.Error("Failed to enqueue event updated notification", "error", err, "userId", u.UserId)
+		}
+	}
+
+	logCtx.Debug("EventUpdated notifications enqueued", "recipients", len(updates))
+}
+
+// EventCompleted invites the host and the accepted players of a played event to report the result,
+// leave feedback and report no-shows before the post-match window closes
+func (d *Notifier) EventCompleted(hostUserId string, eventId string, postMatchUntil time.Time) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", eventId)
+
+	notifPrefs, err := d.db.GetUsersNotificationSettings(eventId)
+	if err != nil {
+		logCtx.Error("Error getting notification settings for completed event", "error", err)
+		return
+	}
+
+	var playerIds []string
+	for userId, prefs := range notifPrefs {
+		if prefs.IsHost == 1 || prefs.IsAccepted == 1 {
+			playerIds = append(playerIds, userId)
+		}
+	}
+
+	if len(playerIds) == 0 {
+		logCtx.Debug("No players to notify about completion")
+		return
+	}
+
+	userNames, err := d.db.GetUserNames(ctx, playerIds)
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	until := api.DtToIso(postMatchUntil)
+	for _, userId := range playerIds {
+		msg := fmt.Sprintf("Hello %s, how was your game? Report the result, leave feedback or report players who did not show up until %s.", userNames[userId], until)
+
+		notificationData := db.NotificationQueueData{
+			Topic:        "How was your game?",
+			Message:      msg,
+			TemplateType: TemplateEventCompleted,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName:  userNames[userId],
+				TemplateDataKeys.IsHost:         userId == hostUserId,
+				TemplateDataKeys.EventId:        eventId,
+				TemplateDataKeys.PostMatchUntil: until,
+			},
+		}
+
+		err = d.queue.Enqueue(ctx, userId, notificationData)
+		if err != nil {
+			logCtx.Error("Failed to enqueue event completed notification", "error", err, "userId", userId)
+		}
+	}
+
+	logCtx.Debug("EventCompleted notifications enqueued", "recipients", len(playerIds))
+}
+
+// WaitlistPromoted tells a joiner that they moved up from the waitlist after another player cancelled
+func (d *Notifier) WaitlistPromoted(promotion db.WaitlistPromotion) {
+	ctx := context.Background()
+	logCtx := slog.With("eventId", promotion.EventId, "userId", promotion.UserId, "joinRequestId", promotion.JoinRequestId)
+
+	userNames, err := d.db.GetUserNames(ctx, []string{promotion.UserId, promotion.HostUserId})
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	templateData := map[string]interface{}{
+		TemplateDataKeys.RecipientName: userNames[promotion.UserId],
+		TemplateDataKeys.HostName:      userNames[promotion.HostUserId],
+		TemplateDataKeys.EventId:       promotion.EventId,
+		TemplateDataKeys.Accepted:      promotion.Accepted,
+	}
+
+	msg := fmt.Sprintf("Hello %s, a spot opened up in %s's event and you moved up from the waitlist. Your join request now waits for the host's confirmation.",
+		userNames[promotion.UserId], userNames[promotion.HostUserId])
+	if promotion.Accepted {
+		facilityName, err := d.db.GetFacilityName(ctx, promotion.LocationId)
+		if err != nil {
+			logCtx.Error("Error getting facility name", "error", err, "locationId", promotion.LocationId)
+			facilityName = "Unknown Location"
+		}
+		dateTime := api.DtToIso(promotion.DateTime)
+		templateData[TemplateDataKeys.DateTime] = dateTime
+		templateData[TemplateDataKeys.Location] = facilityName
+
+		msg = fmt.Sprintf("Hello %s, a spot opened up in %s's event and you moved up from the waitlist. You are playing on %s at %s. Have a great time!",
+			userNames[promotion.UserId], userNames[promotion.HostUserId], dateTime, facilityName)
+	}
+
+	notificationData := db.NotificationQueueData{
+		Topic:        "A spot opened up for you",
+		Message:      msg,
+		TemplateType: TemplateWaitlistPromoted,
+		TemplateData: templateData,
+	}
+
+	if err := d.queue.Enqueue(ctx, promotion.UserId, notificationData); err != nil {
+		logCtx.Error("Failed to enqueue waitlist promotion notification", "error", err)
+	}
+}
+
+// JoinRequestAnswered tells the requester that the host accepted or rejected their join request
+func (d *Notifier) JoinRequestAnswered(hostUserId string, joinRequest api.JoinRequest, accepted bool) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", joinRequest.EventId, "joinRequestId", joinRequest.Id, "accepted", accepted)
+
+	userNames, err := d.db.GetUserNames(ctx, []string{joinRequest.UserId, hostUserId})
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	notificationData := db.NotificationQueueData{
+		Topic: "Your join request has been declined",
+		Message: fmt.Sprintf("Hello %s, %s has declined your request to join the event.",
+			userNames[joinRequest.UserId], userNames[hostUserId]),
+		TemplateType: TemplateJoinRequestRejected,
+		TemplateData: map[string]interface{}{
+			TemplateDataKeys.RecipientName: userNames[joinRequest.UserId],
+			TemplateDataKeys.HostName:      userNames[hostUserId],
+			TemplateDataKeys.EventId:       joinRequest.EventId,
+			TemplateDataKeys.HostMessage:   joinRequest.HostMessage,
+		},
+	}
+	if accepted {
+		notificationData.Topic = "Your join request has been accepted"
+		notificationData.Message = fmt.Sprintf("Hello %s, %s has accepted your request to join the event. You will be notified once the place and time are confirmed.",
+			userNames[joinRequest.UserId], userNames[hostUserId])
+		notificationData.TemplateType = TemplateJoinRequestAccepted
+	}
+	if joinRequest.HostMessage != "" {
+		notificationData.Message += fmt.Sprintf(" Message from the host: \"%s\"", joinRequest.HostMessage)
+	}
+
+	if err := d.queue.Enqueue(ctx, joinRequest.UserId, notificationData); err != nil {
+		logCtx.Error("Failed to enqueue join request answer notification", "error", err)
+	}
+}
+
+// UsersInvited tells the users the host invited them to a private event
+func (d *Notifier) UsersInvited(hostUserId string, eventId string, userIds []string) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", eventId)
+
+	userNames, err := d.db.GetUserNames(ctx, append([]string{hostUserId}, userIds...))
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	for _, userId := range userIds {
+		notificationData := db.NotificationQueueData{
+			Topic: "You have been invited to an event",
+			Message: fmt.Sprintf("Hello %s, %s has invited you to a private event. Open the event to join it.",
+				userNames[userId], userNames[hostUserId]),
+			TemplateType: TemplateEventInvitation,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName: userNames[userId],
+				TemplateDataKeys.HostName:      userNames[hostUserId],
+				TemplateDataKeys.EventId:       eventId,
+			},
+		}
+
+		if err := d.queue.Enqueue(ctx, userId, notificationData); err != nil {
+			logCtx.Error("Failed to enqueue invitation notification", "error", err, "invitedUserId", userId)
+		}
+	}
+}
+
+// EventRescheduled notifies the accepted players of a confirmed event that the host moved it to another time or court,
+// they are asked to acknowledge the new confirmation or to cancel their join request
+func (d *Notifier) EventRescheduled(hostUserId string, eventId string, confirmation api.Confirmation, reason string) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", eventId, "version", confirmation.Version)
+
+	notifPrefs, err := d.db.GetUsersNotificationSettings(eventId)
+	if err != nil {
+		logCtx.Error("Error getting notification settings for users", "error", err)
+		return
+	}
+
+	playerIds := []string{}
+	for userId, prefs := range notifPrefs {
+		if prefs.IsHost != 1 && prefs.IsAccepted == 1 {
+			playerIds = append(playerIds, userId)
+		}
+	}
+	if len(playerIds) == 0 {
+		logCtx.Debug("No players to notify about rescheduling")
+		return
+	}
+
+	userNames, err := d.db.GetUserNames(ctx, append(playerIds, hostUserId))
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	facilityName, err := d.db.GetFacilityName(ctx, confirmation.LocationId)
+	if err != nil {
+		logCtx.Error("Error getting facility name", "error", err, "locationId", confirmation.LocationId)
+		facilityName = "Unknown Location"
+	}
+
+	for _, userId := range playerIds {
+		msg := fmt.Sprintf("Hello %s, %s has moved the event to %s at %s. Please confirm you can still play or cancel your join request.",
+			userNames[userId], userNames[hostUserId], confirmation.Datetime, facilityName)
+		if reason != "" {
+			msg += " Reason: " + reason
+		}
+
+		notificationData := db.NotificationQueueData{
+			Topic:        "Your event has been rescheduled",
+			Message:      msg,
+			TemplateType: TemplateEventRescheduled,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName: userNames[userId],
+				TemplateDataKeys.HostName:      userNames[hostUserId],
+				TemplateDataKeys.EventId:       eventId,
+				TemplateDataKeys.DateTime:      confirmation.Datetime,
+				TemplateDataKeys.Location:      facilityName,
+				TemplateDataKeys.Reason:        reason,
+			},
+		}
+
+		if err := d.queue.Enqueue(ctx, userId, notificationData); err != nil {
+			logCtx.Error("Failed to enqueue event rescheduled notification", "error", err, "userId", userId)
+		}
+	}
+}
+
+// RescheduleReminder reminds an accepted player who has not acknowledged the rescheduled event yet to confirm they
+// can still play or to cancel their join request before the session starts
+func (d *Notifier) RescheduleReminder(reminder db.RescheduleReminder) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", reminder.UserId, "eventId", reminder.EventId, "version", reminder.Version)
+
+	userNames, err := d.db.GetUserNames(ctx, []string{reminder.UserId, reminder.HostUserId})
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	facilityName, err := d.db.GetFacilityName(ctx, reminder.LocationId)
+	if err != nil {
+		logCtx.Error("Error getting facility name", "error", err, "locationId", reminder.LocationId)
+		facilityName = "Unknown Location"
+	}
+
+	dateTime := api.DtToIso(reminder.DateTime)
+	notificationData := db.NotificationQueueData{
+		Topic: "Reminder: please confirm the rescheduled event",
+		Message: fmt.Sprintf("Hello %s, %s has moved the event to %s at %s and you have not confirmed it yet. Please confirm you can still play or cancel your join request.",
+			userNames[reminder.UserId], userNames[reminder.HostUserId], dateTime, facilityName),
+		TemplateType: TemplateEventRescheduled,
+		TemplateData: map[string]interface{}{
+			TemplateDataKeys.RecipientName: userNames[reminder.UserId],
+			TemplateDataKeys.HostName:      userNames[reminder.HostUserId],
+			TemplateDataKeys.EventId:       reminder.EventId,
+			TemplateDataKeys.DateTime:      dateTime,
+			TemplateDataKeys.Location:      facilityName,
+			TemplateDataKeys.IsReminder:    true,
+		},
+	}
+
+	if err := d.queue.Enqueue(ctx, reminder.UserId, notificationData); err != nil {
+		logCtx.Error("Failed to enqueue reschedule reminder notification", "error", err)
+	}
+}
+
+// ReservationFailed notifies the host and the accepted players that the court of the confirmed event could not be
+// booked. The host can reschedule the event or cancel it.
+func (d *Notifier) ReservationFailed(hostUserId string, eventId string, reason string) {
+	ctx := context.Background()
+	logCtx := slog.With("hostUserId", hostUserId, "eventId", eventId)
+
+	notifPrefs, err := d.db.GetUsersNotificationSettings(eventId)
+	if err != nil {
+		logCtx.Error("Error getting notification settings for users", "error", err)
+		return
+	}
+
+	recipientIds := []string{}
+	for userId, prefs := range notifPrefs {
+		if prefs.IsHost == 1 || prefs.IsAccepted == 1 {
+			recipientIds = append(recipientIds, userId)
+		}
+	}
+
+	userNames, err := d.db.GetUserNames(ctx, recipientIds)
+	if err != nil {
+		logCtx.Error("Error getting user names", "error", err)
+		return
+	}
+
+	for _, userId := range recipientIds {
+		isHost := userId == hostUserId
+		msg := fmt.Sprintf("Hello %s, the court for the event hosted by %s could not be booked: %s. The host will reschedule or cancel the event.",
+			userNames[userId], userNames[hostUserId], reason)
+		if isHost {
+			msg = fmt.Sprintf("Hello %s, the court for your confirmed event could not be booked: %s. Please reschedule the event to another time or court, or cancel it.",
+				userNames[userId], reason)
+		}
+
+		notificationData := db.NotificationQueueData{
+			Topic:        "Court reservation failed",
+			Message:      msg,
+			TemplateType: TemplateReservationFailed,
+			TemplateData: map[string]interface{}{
+				TemplateDataKeys.RecipientName: userNames[userId],
+				TemplateDataKeys.IsHost:        isHost,
+				TemplateDataKeys.HostName:      userNames[hostUserId],
+				TemplateDataKeys.EventId:       eventId,
+				TemplateDataKeys.Reason:        reason,
+			},
+		}
+
+		if err := d.queue.Enqueue(ctx, userId, notificationData); err != nil {
+			logCtx.Error("Failed to enqueue reservation failed notification", "error", err, "userId", userId)
+		}
+	}
+}
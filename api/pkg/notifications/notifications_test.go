@@ -3,7 +3,9 @@ package notifications
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/xtp-tour/xtp-tour/api/pkg/api"
 	"github.com/xtp-tour/xtp-tour/api/pkg/db"
@@ -326,7 +328,7 @@ func Test_EventConfirmed_MultipleAcceptedPlayers(t *testing.T) {
 	}
 
 	logCtx := slog.With("test", true)
-	notifier.EventConfirmed(logCtx, eventId, []string{"jr1", "jr2", "jr3"}, "2024-06-01T10:00:00Z", "loc1", hostId)
+	notifier.EventConfirmed(logCtx, eventId, []string{"jr1", "jr2", "jr3"}, "2024-06-01T10:00:00Z", "loc1", hostId, nil, nil)
 
 	// Should have 4 notifications: host + 3 accepted players (not the rejected one)
 	if len(enqueuedUserIds) != 4 {
@@ -359,6 +361,90 @@ func Test_EventConfirmed_MultipleAcceptedPlayers(t *testing.T) {
 	}
 }
 
+func Test_EventConfirmed_IncludesTeams(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUsersNotificationSettingsFunc = func(eid string) (map[string]db.EventNotifSettingsResult, error) {
+		return map[string]db.EventNotifSettingsResult{
+			"host": {UserId: "host", IsHost: 1, IsAccepted: -1},
+			"p1":   {UserId: "p1", IsAccepted: 1},
+			"p2":   {UserId: "p2", IsAccepted: 1},
+			"p3":   {UserId: "p3", IsAccepted: 1},
+		}, nil
+	}
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Alice", "p1": "Bob", "p2": "Carol", "p3": "Dave"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		return "Court", nil
+	}
+
+	enqueued := []db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued = append(enqueued, data)
+		return nil
+	}
+
+	teams := [][]string{{"host", "p2"}, {"p1", "p3"}}
+	notifier.EventConfirmed(slog.With("test", true), "event1", []string{"jr1", "jr2", "jr3"}, "2024-06-01T10:00:00Z", "loc1", "host", teams, nil)
+
+	if len(enqueued) != 4 {
+		t.Fatalf("Expected 4 notifications, got %d", len(enqueued))
+	}
+	for _, data := range enqueued {
+		teamNames, ok := data.TemplateData[TemplateDataKeys.Teams].([]string)
+		if !ok || len(teamNames) != 2 || teamNames[0] != "Alice & Carol" || teamNames[1] != "Bob & Dave" {
+			t.Errorf("Unexpected teams %v", data.TemplateData[TemplateDataKeys.Teams])
+		}
+		if !strings.Contains(data.Message, "Alice & Carol vs Bob & Dave") {
+			t.Errorf("Message should include the teams, got %s", data.Message)
+		}
+	}
+}
+
+func Test_EventConfirmed_IncludesCourtCost(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUsersNotificationSettingsFunc = func(eid string) (map[string]db.EventNotifSettingsResult, error) {
+		return map[string]db.EventNotifSettingsResult{
+			"host": {UserId: "host", IsHost: 1, IsAccepted: -1},
+			"p1":   {UserId: "p1", IsAccepted: 1},
+			"p2":   {UserId: "p2", IsAccepted: 1},
+		}, nil
+	}
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Alice", "p1": "Bob", "p2": "Carol"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		return "Court", nil
+	}
+
+	enqueued := []db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued = append(enqueued, data)
+		return nil
+	}
+
+	courtCost := &api.CourtCost{Total: 100, Players: 3, PerPlayer: 33.34}
+	notifier.EventConfirmed(slog.With("test", true), "event1", []string{"jr1", "jr2"}, "2024-06-01T10:00:00Z", "loc1", "host", nil, courtCost)
+
+	if len(enqueued) != 3 {
+		t.Fatalf("Expected 3 notifications, got %d", len(enqueued))
+	}
+	for _, data := range enqueued {
+		if data.TemplateData[TemplateDataKeys.CourtCost] != "100.00" || data.TemplateData[TemplateDataKeys.CostPerPlayer] != "33.34" {
+			t.Errorf("Unexpected court cost %v / %v", data.TemplateData[TemplateDataKeys.CourtCost], data.TemplateData[TemplateDataKeys.CostPerPlayer])
+		}
+		if !strings.Contains(data.Message, "Court cost: 100.00, 33.34 per player.") {
+			t.Errorf("Message should include the court cost, got %s", data.Message)
+		}
+	}
+}
+
 func Test_EventConfirmed_SinglePlayer(t *testing.T) {
 	mockDb := new(MockNotifierDb)
 	mockQueue := new(MockQueue)
@@ -390,7 +476,7 @@ func Test_EventConfirmed_SinglePlayer(t *testing.T) {
 	}
 
 	logCtx := slog.With("test", true)
-	notifier.EventConfirmed(logCtx, "event1", []string{"jr1"}, "2024-06-01T10:00:00Z", "loc1", hostId)
+	notifier.EventConfirmed(logCtx, "event1", []string{"jr1"}, "2024-06-01T10:00:00Z", "loc1", hostId, nil, nil)
 
 	if len(enqueuedUserIds) != 2 {
 		t.Fatalf("Expected 2 notifications (host + 1 player), got %d", len(enqueuedUserIds))
@@ -443,7 +529,501 @@ func Test_ChatMessagePosted_NotifiesAllParticipants(t *testing.T) {
 	}
 }
 
+func Test_EventsCancelled_GroupsSeriesOccurrencesPerJoiner(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	hostId := "host_1"
+	regular := "regular_player"
+	once := "one_time_player"
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{hostId: "Host", regular: "Regular", once: "Once"}, nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		if _, ok := enqueued[userId]; ok {
+			t.Errorf("User %s notified more than once", userId)
+		}
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.EventsCancelled(hostId, "series_1", []db.CancelledEvent{
+		{EventId: "event_1", JoinerIds: []string{regular}},
+		{EventId: "event_2", JoinerIds: []string{regular, once}},
+		{EventId: "event_3", JoinerIds: []string{regular}},
+	}, "", false)
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications (host excluded), got %d", len(enqueued))
+	}
+	if _, ok := enqueued[hostId]; ok {
+		t.Error("Host should not be notified about their own cancellation")
+	}
+
+	regularData := enqueued[regular]
+	if regularData.TemplateType != TemplateEventCancelled {
+		t.Errorf("Expected template %s, got %s", TemplateEventCancelled, regularData.TemplateType)
+	}
+	if regularData.TemplateData[TemplateDataKeys.Occurrences] != 3 {
+		t.Errorf("Expected 3 occurrences for regular player, got %v", regularData.TemplateData[TemplateDataKeys.Occurrences])
+	}
+	if regularData.TemplateData[TemplateDataKeys.SeriesId] != "series_1" {
+		t.Errorf("Expected series id in template data, got %v", regularData.TemplateData[TemplateDataKeys.SeriesId])
+	}
+
+	onceData := enqueued[once]
+	if onceData.TemplateData[TemplateDataKeys.Occurrences] != 1 || onceData.TemplateData[TemplateDataKeys.EventId] != "event_2" {
+		t.Errorf("Expected a single cancelled occurrence event_2, got %v", onceData.TemplateData)
+	}
+}
+
+func Test_EventsCancelled_IncludesReason(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "player": "Player"}, nil
+	}
+
+	var enqueued []db.NotificationQueueData
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued = append(enqueued, data)
+		return nil
+	}
+
+	notifier.EventsCancelled("host", "", []db.CancelledEvent{{EventId: "event_1", JoinerIds: []string{"player"}}}, "Court is flooded", true)
+
+	if len(enqueued) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(enqueued))
+	}
+	data := enqueued[0]
+	if data.TemplateData[TemplateDataKeys.Reason] != "Court is flooded" {
+		t.Errorf("Expected reason in template data, got %v", data.TemplateData[TemplateDataKeys.Reason])
+	}
+	if data.TemplateData[TemplateDataKeys.WasConfirmed] != true {
+		t.Error("Expected WasConfirmed in template data")
+	}
+	if !strings.Contains(data.Message, "Reason: Court is flooded") {
+		t.Errorf("Expected reason in message, got %s", data.Message)
+	}
+}
+
+func Test_EventsCancelled_SkipsEventsWithoutActiveJoiners(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		t.Error("User names should not be loaded when nobody is notified")
+		return nil, nil
+	}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		t.Errorf("User %s should not be notified", userId)
+		return nil
+	}
+
+	notifier.EventsCancelled("host", "", []db.CancelledEvent{{EventId: "event_1"}}, "", false)
+}
+
+func Test_EventCompleted_NotifiesHostAndAcceptedPlayers(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUsersNotificationSettingsFunc = func(eid string) (map[string]db.EventNotifSettingsResult, error) {
+		return map[string]db.EventNotifSettingsResult{
+			"host":     {UserId: "host", IsHost: 1, IsAccepted: -1},
+			"accepted": {UserId: "accepted", IsHost: 0, IsAccepted: 1},
+			"waiting":  {UserId: "waiting", IsHost: 0, IsAccepted: 0},
+		}, nil
+	}
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "accepted": "Accepted"}, nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.EventCompleted("host", "event_1", time.Date(2025, 6, 10, 20, 0, 0, 0, time.UTC))
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(enqueued))
+	}
+	if _, ok := enqueued["waiting"]; ok {
+		t.Error("Players who were not accepted should not be notified")
+	}
+	if enqueued["host"].TemplateData[TemplateDataKeys.IsHost] != true {
+		t.Error("Host should be marked as host")
+	}
+	accepted := enqueued["accepted"]
+	if accepted.TemplateType != TemplateEventCompleted {
+		t.Errorf("Expected template %s, got %s", TemplateEventCompleted, accepted.TemplateType)
+	}
+	if accepted.TemplateData[TemplateDataKeys.PostMatchUntil] != "2025-06-10T20:00:00Z" {
+		t.Errorf("Expected post-match window end, got %v", accepted.TemplateData[TemplateDataKeys.PostMatchUntil])
+	}
+}
+
+func Test_WaitlistPromoted_AcceptedIncludesConfirmedSession(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "next": "Next"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		return "Central Courts", nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.WaitlistPromoted(db.WaitlistPromotion{
+		EventId:       "event_1",
+		HostUserId:    "host",
+		JoinRequestId: "jr_1",
+		UserId:        "next",
+		Accepted:      true,
+		LocationId:    "loc_1",
+		DateTime:      time.Date(2025, 6, 10, 18, 0, 0, 0, time.UTC),
+	})
+
+	if len(enqueued) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(enqueued))
+	}
+	data := enqueued["next"]
+	if data.TemplateType != TemplateWaitlistPromoted {
+		t.Errorf("Expected template %s, got %s", TemplateWaitlistPromoted, data.TemplateType)
+	}
+	if data.TemplateData[TemplateDataKeys.Accepted] != true {
+		t.Error("Promotion should be marked as accepted")
+	}
+	if data.TemplateData[TemplateDataKeys.Location] != "Central Courts" {
+		t.Errorf("Expected location name, got %v", data.TemplateData[TemplateDataKeys.Location])
+	}
+	if data.TemplateData[TemplateDataKeys.DateTime] != "2025-06-10T18:00:00Z" {
+		t.Errorf("Expected confirmed date time, got %v", data.TemplateData[TemplateDataKeys.DateTime])
+	}
+}
+
+func Test_WaitlistPromoted_OpenEventAwaitsConfirmation(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "next": "Next"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		t.Error("Facility should not be looked up for open events")
+		return "", nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.WaitlistPromoted(db.WaitlistPromotion{EventId: "event_1", HostUserId: "host", JoinRequestId: "jr_1", UserId: "next"})
+
+	data, ok := enqueued["next"]
+	if !ok {
+		t.Fatal("Promoted joiner should be notified")
+	}
+	if data.TemplateData[TemplateDataKeys.Accepted] != false {
+		t.Error("Promotion should not be marked as accepted")
+	}
+	if _, ok := data.TemplateData[TemplateDataKeys.DateTime]; ok {
+		t.Error("Date time should be set only for accepted promotions")
+	}
+}
+
+func Test_JoinRequestAnswered_UsesTemplateOfDecision(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "joiner": "Joiner"}, nil
+	}
+
+	enqueued := []db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		if userId != "joiner" {
+			t.Errorf("Expected notification for the joiner, got %s", userId)
+		}
+		enqueued = append(enqueued, data)
+		return nil
+	}
+
+	joinRequest := api.JoinRequest{
+		JoinRequestData: api.JoinRequestData{Id: "jr_1", EventId: "event_1"},
+		UserId:          "joiner",
+		HostMessage:     "Sorry, looking for a stronger partner",
+	}
+	notifier.JoinRequestAnswered("host", joinRequest, true)
+	notifier.JoinRequestAnswered("host", joinRequest, false)
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(enqueued))
+	}
+	if enqueued[0].TemplateType != TemplateJoinRequestAccepted {
+		t.Errorf("Expected template %s, got %s", TemplateJoinRequestAccepted, enqueued[0].TemplateType)
+	}
+	if enqueued[1].TemplateType != TemplateJoinRequestRejected {
+		t.Errorf("Expected template %s, got %s", TemplateJoinRequestRejected, enqueued[1].TemplateType)
+	}
+	if enqueued[1].TemplateData[TemplateDataKeys.HostMessage] != "Sorry, looking for a stronger partner" {
+		t.Errorf("Expected host message, got %v", enqueued[1].TemplateData[TemplateDataKeys.HostMessage])
+	}
+	if !strings.Contains(enqueued[1].Message, "Sorry, looking for a stronger partner") {
+		t.Errorf("Message should include the host message, got %s", enqueued[1].Message)
+	}
+}
+
+func Test_UsersInvited_NotifiesEachInvitedUser(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "u1": "User One", "u2": "User Two"}, nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.UsersInvited("host", "event_1", []string{"u1", "u2"})
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(enqueued))
+	}
+	for userId, data := range enqueued {
+		if data.TemplateType != TemplateEventInvitation {
+			t.Errorf("Expected template %s for %s, got %s", TemplateEventInvitation, userId, data.TemplateType)
+		}
+		if data.TemplateData[TemplateDataKeys.EventId] != "event_1" {
+			t.Errorf("Expected event id event_1 for %s, got %v", userId, data.TemplateData[TemplateDataKeys.EventId])
+		}
+		if data.TemplateData[TemplateDataKeys.HostName] != "Host" {
+			t.Errorf("Expected host name Host for %s, got %v", userId, data.TemplateData[TemplateDataKeys.HostName])
+		}
+	}
+	if enqueued["u2"].TemplateData[TemplateDataKeys.RecipientName] != "User Two" {
+		t.Errorf("Expected recipient name User Two, got %v", enqueued["u2"].TemplateData[TemplateDataKeys.RecipientName])
+	}
+}
+
+func Test_EventUpdated_NotifiesAffectedJoiners(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "trimmed": "Trimmed", "removed": "Removed"}, nil
+	}
+	facilityLookups := 0
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		facilityLookups++
+		return "Court " + facilityId, nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	removedSlot := time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+	notifier.EventUpdated("host", "event_1", []db.JoinRequestUpdate{
+		{JoinRequestId: "jr1", UserId: "trimmed", RemovedLocations: []string{"spartan"}, RemovedTimeSlots: []time.Time{removedSlot}},
+		{JoinRequestId: "jr2", UserId: "removed", RemovedLocations: []string{"spartan"}, Removed: true},
+	})
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(enqueued))
+	}
+	if facilityLookups != 1 {
+		t.Errorf("Expected facility name to be looked up once, got %d", facilityLookups)
+	}
+
+	trimmed := enqueued["trimmed"]
+	if trimmed.TemplateType != TemplateEventUpdated {
+		t.Errorf("Expected template %s, got %s", TemplateEventUpdated, trimmed.TemplateType)
+	}
+	if trimmed.TemplateData[TemplateDataKeys.RequestRemoved] != false {
+		t.Error("Trimmed join request should not be marked as removed")
+	}
+	locations := trimmed.TemplateData[TemplateDataKeys.RemovedLocations].([]string)
+	if len(locations) != 1 || locations[0] != "Court spartan" {
+		t.Errorf("Expected removed location name, got %v", locations)
+	}
+	slots := trimmed.TemplateData[TemplateDataKeys.RemovedTimeSlots].([]string)
+	if len(slots) != 1 || slots[0] != "2025-06-02T18:00:00Z" {
+		t.Errorf("Expected removed time slot, got %v", slots)
+	}
+
+	if enqueued["removed"].TemplateData[TemplateDataKeys.RequestRemoved] != true {
+		t.Error("Removed join request should be marked as removed")
+	}
+}
+
 // Helper function to create a test logger
 func testLogger(t *testing.T) slog.Logger {
 	return *slog.Default()
 }
+
+func Test_EventRescheduled_NotifiesAcceptedPlayers(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUsersNotificationSettingsFunc = func(eid string) (map[string]db.EventNotifSettingsResult, error) {
+		return map[string]db.EventNotifSettingsResult{
+			"host":       {UserId: "host", IsHost: 1, IsAccepted: -1},
+			"player":     {UserId: "player", IsHost: 0, IsAccepted: 1},
+			"waitlisted": {UserId: "waitlisted", IsHost: 0, IsAccepted: -1},
+		}, nil
+	}
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "player": "Player"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		return "Court 2", nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.EventRescheduled("host", "event_1", api.Confirmation{
+		EventId:    "event_1",
+		LocationId: "court-2",
+		Datetime:   "2025-06-03T18:00:00Z",
+		Version:    2,
+	}, "Court 1 is flooded")
+
+	if len(enqueued) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(enqueued))
+	}
+	data, ok := enqueued["player"]
+	if !ok {
+		t.Fatal("Expected the accepted player to be notified")
+	}
+	if data.TemplateType != TemplateEventRescheduled {
+		t.Errorf("Expected template %s, got %s", TemplateEventRescheduled, data.TemplateType)
+	}
+	if data.TemplateData[TemplateDataKeys.Location] != "Court 2" {
+		t.Errorf("Expected new location in template data, got %v", data.TemplateData[TemplateDataKeys.Location])
+	}
+	if !strings.Contains(data.Message, "Reason: Court 1 is flooded") {
+		t.Errorf("Expected reason in message, got %s", data.Message)
+	}
+}
+
+func Test_RescheduleReminder_RemindsPlayer(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "player": "Player"}, nil
+	}
+	mockDb.GetFacilityNameFunc = func(ctx context.Context, facilityId string) (string, error) {
+		return "Court 2", nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.RescheduleReminder(db.RescheduleReminder{
+		JoinRequestId: "jr_1",
+		UserId:        "player",
+		EventId:       "event_1",
+		HostUserId:    "host",
+		LocationId:    "court-2",
+		DateTime:      time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC),
+		Version:       2,
+	})
+
+	if len(enqueued) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(enqueued))
+	}
+	data, ok := enqueued["player"]
+	if !ok {
+		t.Fatal("Expected the player to be reminded")
+	}
+	if data.TemplateType != TemplateEventRescheduled {
+		t.Errorf("Expected template %s, got %s", TemplateEventRescheduled, data.TemplateType)
+	}
+	if data.TemplateData[TemplateDataKeys.IsReminder] != true {
+		t.Errorf("Expected reminder flag in template data, got %v", data.TemplateData[TemplateDataKeys.IsReminder])
+	}
+	if !strings.Contains(data.Message, "Court 2") {
+		t.Errorf("Expected location in message, got %s", data.Message)
+	}
+}
+
+func Test_ReservationFailed_NotifiesHostAndAcceptedPlayers(t *testing.T) {
+	mockDb := new(MockNotifierDb)
+	mockQueue := new(MockQueue)
+	notifier := NewNotifier(mockDb, mockQueue)
+
+	mockDb.GetUsersNotificationSettingsFunc = func(eid string) (map[string]db.EventNotifSettingsResult, error) {
+		return map[string]db.EventNotifSettingsResult{
+			"host":       {UserId: "host", IsHost: 1, IsAccepted: -1},
+			"player":     {UserId: "player", IsHost: 0, IsAccepted: 1},
+			"waitlisted": {UserId: "waitlisted", IsHost: 0, IsAccepted: 0},
+		}, nil
+	}
+	mockDb.GetUserNamesFunc = func(ctx context.Context, userIds []string) (map[string]string, error) {
+		return map[string]string{"host": "Host", "player": "Player"}, nil
+	}
+
+	enqueued := map[string]db.NotificationQueueData{}
+	mockQueue.EnqueueFunc = func(ctx context.Context, userId string, data db.NotificationQueueData) error {
+		enqueued[userId] = data
+		return nil
+	}
+
+	notifier.ReservationFailed("host", "event_1", "No court is available at the confirmed time")
+
+	if len(enqueued) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(enqueued))
+	}
+	if enqueued["host"].TemplateData[TemplateDataKeys.IsHost] != true {
+		t.Error("Expected the host notification to be marked as host")
+	}
+	if enqueued["player"].TemplateData[TemplateDataKeys.IsHost] != false {
+		t.Error("Expected the player notification not to be marked as host")
+	}
+	for userId, data := range enqueued {
+		if data.TemplateType != TemplateReservationFailed {
+			t.Errorf("Expected template %s for %s, got %s", TemplateReservationFailed, userId, data.TemplateType)
+		}
+		if !strings.Contains(data.Message, "No court is available") {
+			t.Errorf("Expected reason in message for %s, got %s", userId, data.Message)
+		}
+	}
+}
@@ -14,6 +14,33 @@ const (
 
 	// TemplateChatMessage is sent to the event owner when someone posts in the event chat
 	TemplateChatMessage = "chat_message"
+
+	// TemplateEventUpdated is sent to joiners whose join request was changed by an update of the event
+	TemplateEventUpdated = "event_updated"
+
+	// TemplateEventCancelled is sent to joiners when the host cancels an event or occurrences of a series
+	TemplateEventCancelled = "event_cancelled"
+
+	// TemplateEventCompleted is sent to the players of a played event when its post-match window opens
+	TemplateEventCompleted = "event_completed"
+
+	// TemplateWaitlistPromoted is sent to a joiner moved up from the waitlist after another player cancelled
+	TemplateWaitlistPromoted = "waitlist_promoted"
+
+	// TemplateJoinRequestAccepted is sent to a joiner whose request was accepted by the host before confirmation
+	TemplateJoinRequestAccepted = "join_request_accepted"
+
+	// TemplateJoinRequestRejected is sent to a joiner whose request was rejected by the host
+	TemplateJoinRequestRejected = "join_request_rejected"
+
+	// TemplateEventInvitation is sent to users the host invited to a private event
+	TemplateEventInvitation = "event_invitation"
+
+	// TemplateEventRescheduled is sent to accepted players when the host moves a confirmed event to another time or court
+	TemplateEventRescheduled = "event_rescheduled"
+
+	// TemplateReservationFailed is sent to the host and accepted players when the court of a confirmed event could not be booked
+	TemplateReservationFailed = "reservation_failed"
 )
 
 // Template data field conventions for NotificationQueueData.TemplateData
@@ -32,6 +59,9 @@ const (
 //   - Location (string): Name of the event location/facility
 //   - ConfirmedPlayers ([]string): List of confirmed player names
 //   - EventId (string): Event identifier for deep linking
+//   - Teams ([]string): Player names of each team joined with " & ", empty when no teams were set
+//   - CourtCost (string): Court price of the session formatted with two decimals, empty when not known
+//   - CostPerPlayer (string): Share of each player formatted with two decimals, empty when not known
 //
 // UserJoined template fields:
 //   - HostName (string): Name of the event host receiving the notification
@@ -42,6 +72,65 @@ const (
 // EventExpired template fields:
 //   - RecipientName (string): Name of the event owner
 //   - EventId (string): Expired event identifier
+//   - SeriesId (string): Series identifier, set only when the event is an occurrence of a series
+//
+// EventUpdated template fields:
+//   - RecipientName (string): Name of the joiner
+//   - HostName (string): Name of the event host
+//   - EventId (string): Updated event identifier
+//   - RequestRemoved (bool): Whether the join request was cancelled because none of its choices is offered anymore
+//   - RemovedLocations ([]string): Names of the chosen locations the event no longer offers
+//   - RemovedTimeSlots ([]string): Chosen time slots the event no longer offers, in ISO 8601 format
+//
+// EventCancelled template fields:
+//   - RecipientName (string): Name of the joiner
+//   - HostName (string): Name of the event host
+//   - EventId (string): Cancelled event identifier, the first one for a series
+//   - SeriesId (string): Series identifier, empty for one-off events
+//   - Occurrences (int): Number of cancelled events the recipient had joined
+//   - Reason (string): Optional reason given by the host
+//   - WasConfirmed (bool): Whether the event was confirmed, calendars then need a cancellation
+//
+// EventCompleted template fields:
+//   - RecipientName (string): Name of the player
+//   - IsHost (bool): Whether the recipient is the event host
+//   - EventId (string): Completed event identifier
+//   - PostMatchUntil (string): End of the post-match window in ISO 8601 format
+//
+// WaitlistPromoted template fields:
+//   - RecipientName (string): Name of the promoted joiner
+//   - HostName (string): Name of the event host
+//   - EventId (string): Event identifier
+//   - Accepted (bool): Whether the joiner now plays in the confirmed event, otherwise the request awaits the host's confirmation
+//   - DateTime (string): Confirmed date and time in ISO 8601 format, set only when accepted
+//   - Location (string): Name of the confirmed location, set only when accepted
+//
+// JoinRequestAccepted and JoinRequestRejected template fields:
+//   - RecipientName (string): Name of the joiner
+//   - HostName (string): Name of the event host
+//   - EventId (string): Event identifier
+//   - HostMessage (string): Optional message of the host
+//
+// EventInvitation template fields:
+//   - RecipientName (string): Name of the invited user
+//   - HostName (string): Name of the event host
+//   - EventId (string): Event identifier for deep linking
+//
+// EventRescheduled template fields:
+//   - RecipientName (string): Name of the accepted player
+//   - HostName (string): Name of the event host
+//   - EventId (string): Event identifier for deep linking
+//   - DateTime (string): New date and time in ISO 8601 format
+//   - Location (string): Name of the new location
+//   - Reason (string): Optional reason given by the host
+//   - IsReminder (bool): Whether the player is reminded shortly before the session to acknowledge the new time
+//
+// ReservationFailed template fields:
+//   - RecipientName (string): Name of the player
+//   - IsHost (bool): Whether the recipient is the event host
+//   - HostName (string): Name of the event host
+//   - EventId (string): Event identifier for deep linking
+//   - Reason (string): Why the court could not be booked
 //
 // ChatMessage template fields:
 //   - SenderName (string): Name of the user who posted the message
@@ -70,6 +159,34 @@ var TemplateDataKeys = struct {
 
 	// Event confirmed fields
 	ConfirmedPlayers string
+	Teams            string
+	CourtCost        string
+	CostPerPlayer    string
+
+	// Series fields
+	SeriesId    string
+	Occurrences string
+
+	// Event updated fields
+	RequestRemoved   string
+	RemovedLocations string
+	RemovedTimeSlots string
+
+	// Event cancelled fields
+	Reason       string
+	WasConfirmed string
+
+	// Event completed fields
+	PostMatchUntil string
+
+	// Waitlist promoted fields
+	Accepted string
+
+	// Join request answer fields
+	HostMessage string
+
+	// Event rescheduled fields
+	IsReminder string
 }{
 	RecipientName:    "RecipientName",
 	ActionURL:        "ActionURL",
@@ -82,5 +199,19 @@ var TemplateDataKeys = struct {
 	Comment:          "Comment",
 	SenderName:       "SenderName",
 	ConfirmedPlayers: "ConfirmedPlayers",
+	Teams:            "Teams",
+	CourtCost:        "CourtCost",
+	CostPerPlayer:    "CostPerPlayer",
+	SeriesId:         "SeriesId",
+	Occurrences:      "Occurrences",
+	RequestRemoved:   "RequestRemoved",
+	RemovedLocations: "RemovedLocations",
+	RemovedTimeSlots: "RemovedTimeSlots",
+	Reason:           "Reason",
+	WasConfirmed:     "WasConfirmed",
+	PostMatchUntil:   "PostMatchUntil",
+	Accepted:         "Accepted",
+	HostMessage:      "HostMessage",
+	IsReminder:       "IsReminder",
 }
 
@@ -0,0 +1,112 @@
+package places
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	results []PlaceResult
+	expires time.Time
+}
+
+// CachedProvider keeps the results of another provider for a while, so repeated searches do not hit its API. Only
+// providers whose content may be stored can be cached, the Google Maps Platform terms allow caching place ids but not
+// the names and addresses of places.
+type CachedProvider struct {
+	provider   Provider
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewCachedProvider caches up to maxEntries responses of the provider for ttl
+func NewCachedProvider(provider Provider, ttl time.Duration, maxEntries int) *CachedProvider {
+	return &CachedProvider{
+		provider:   provider,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    map[string]cacheEntry{},
+	}
+}
+
+func (c *CachedProvider) Name() string {
+	return c.provider.Name()
+}
+
+// SearchPlaces returns the cached results of the query, coordinates are rounded to about a kilometer
+func (c *CachedProvider) SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error) {
+	key := fmt.Sprintf("search:%.2f,%.2f:%s", lat, lng, strings.Join(strings.Fields(strings.ToLower(query)), " "))
+	if results, ok := c.get(key); ok {
+		return results, nil
+	}
+
+	results, err := c.provider.SearchPlaces(ctx, query, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, results)
+	return results, nil
+}
+
+// GetPlaceDetails returns the cached details of the place
+func (c *CachedProvider) GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error) {
+	key := "details:" + placeID
+	if results, ok := c.get(key); ok {
+		result := results[0]
+		return &result, nil
+	}
+
+	result, err := c.provider.GetPlaceDetails(ctx, placeID)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, []PlaceResult{*result})
+	return result, nil
+}
+
+func (c *CachedProvider) get(key string) ([]PlaceResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return append([]PlaceResult(nil), entry.results...), true
+}
+
+func (c *CachedProvider) put(key string, results []PlaceResult) {
+	if c.maxEntries <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// still full of fresh entries, drop the one expiring first
+	if len(c.entries) >= c.maxEntries {
+		oldest := ""
+		for k, entry := range c.entries {
+			if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = cacheEntry{results: append([]PlaceResult(nil), results...), expires: now.Add(c.ttl)}
+}
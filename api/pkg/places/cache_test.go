@@ -0,0 +1,74 @@
+package places
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingProvider struct {
+	searches int
+	details  int
+	err      error
+}
+
+func (p *countingProvider) Name() string {
+	return "counting"
+}
+
+func (p *countingProvider) SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error) {
+	p.searches++
+	return []PlaceResult{{PlaceID: "place_1", Name: query}}, p.err
+}
+
+func (p *countingProvider) GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error) {
+	p.details++
+	return &PlaceResult{PlaceID: placeID}, p.err
+}
+
+func TestCachedProvider(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	provider := &countingProvider{}
+	cache := NewCachedProvider(provider, time.Hour, 2)
+	cache.now = func() time.Time { return now }
+
+	for _, query := range []string{"Matchpoint", " matchpoint  ", "MATCHPOINT"} {
+		results, err := cache.SearchPlaces(ctx, query, 51.1425, 17.0913)
+		require.NoError(t, err)
+		assert.Equal(t, "Matchpoint", results[0].Name)
+	}
+	assert.Equal(t, 1, provider.searches)
+
+	_, _ = cache.SearchPlaces(ctx, "matchpoint", 52.2297, 21.0122)
+	assert.Equal(t, 2, provider.searches, "other coordinates")
+
+	for i := 0; i < 2; i++ {
+		details, err := cache.GetPlaceDetails(ctx, "place_1")
+		require.NoError(t, err)
+		assert.Equal(t, "place_1", details.PlaceID)
+	}
+	assert.Equal(t, 1, provider.details)
+
+	now = now.Add(time.Hour)
+	_, _ = cache.GetPlaceDetails(ctx, "place_1")
+	assert.Equal(t, 2, provider.details, "expired")
+	assert.LessOrEqual(t, len(cache.entries), 2)
+}
+
+func TestCachedProvider_ErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	provider := &countingProvider{err: fmt.Errorf("quota exceeded")}
+	cache := NewCachedProvider(provider, time.Hour, 10)
+
+	_, err := cache.SearchPlaces(ctx, "matchpoint", 0, 0)
+	assert.Error(t, err)
+	provider.err = nil
+	_, err = cache.SearchPlaces(ctx, "matchpoint", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, provider.searches)
+}
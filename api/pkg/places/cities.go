@@ -0,0 +1,88 @@
+package places
+
+import (
+	"context"
+	"strings"
+)
+
+// CityCenter is the center of a city users can pick in their profile
+type CityCenter struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// cityCenters maps folded city names, with their English exonyms, to their centers
+var cityCenters = map[string]CityCenter{
+	"wroclaw":      {Latitude: 51.1079, Longitude: 17.0385},
+	"warszawa":     {Latitude: 52.2297, Longitude: 21.0122},
+	"warsaw":       {Latitude: 52.2297, Longitude: 21.0122},
+	"krakow":       {Latitude: 50.0647, Longitude: 19.9450},
+	"cracow":       {Latitude: 50.0647, Longitude: 19.9450},
+	"poznan":       {Latitude: 52.4064, Longitude: 16.9252},
+	"gdansk":       {Latitude: 54.3520, Longitude: 18.6466},
+	"gdynia":       {Latitude: 54.5189, Longitude: 18.5305},
+	"sopot":        {Latitude: 54.4418, Longitude: 18.5601},
+	"lodz":         {Latitude: 51.7592, Longitude: 19.4560},
+	"katowice":     {Latitude: 50.2649, Longitude: 19.0238},
+	"szczecin":     {Latitude: 53.4285, Longitude: 14.5528},
+	"lublin":       {Latitude: 51.2465, Longitude: 22.5684},
+	"bydgoszcz":    {Latitude: 53.1235, Longitude: 18.0084},
+	"bialystok":    {Latitude: 53.1325, Longitude: 23.1688},
+	"torun":        {Latitude: 53.0138, Longitude: 18.5984},
+	"rzeszow":      {Latitude: 50.0412, Longitude: 21.9991},
+	"opole":        {Latitude: 50.6751, Longitude: 17.9213},
+	"kielce":       {Latitude: 50.8661, Longitude: 20.6286},
+	"olsztyn":      {Latitude: 53.7784, Longitude: 20.4801},
+	"olesnica":     {Latitude: 51.2094, Longitude: 17.3826},
+	"zielona gora": {Latitude: 51.9356, Longitude: 15.5062},
+}
+
+var diacritics = strings.NewReplacer(
+	"ą", "a", "ć", "c", "ę", "e", "ł", "l", "ń", "n", "ó", "o", "ś", "s", "ź", "z", "ż", "z",
+)
+
+// FindCityCenter returns the center of the city, ok is false for cities that are not known.
+// Names are matched case-insensitively and without Polish diacritics.
+func FindCityCenter(city string) (center CityCenter, ok bool) {
+	center, ok = cityCenters[foldCity(city)]
+	return center, ok
+}
+
+// GeocodeCity returns the center of the city. Cities missing from the table are located with the places the provider
+// finds in them, the center is the average position of those places then. ok is false when the city is not known
+// and the provider, which may be nil, finds no place in it.
+func GeocodeCity(ctx context.Context, provider Provider, city string) (center CityCenter, ok bool, err error) {
+	if center, ok := FindCityCenter(city); ok {
+		return center, true, nil
+	}
+	name := foldCity(city)
+	if provider == nil || name == "" {
+		return CityCenter{}, false, nil
+	}
+
+	results, err := provider.SearchPlaces(ctx, name, 0, 0)
+	if err != nil {
+		return CityCenter{}, false, err
+	}
+
+	found := 0
+	for _, place := range results {
+		// the query may match the name of a place elsewhere
+		if !strings.Contains(foldText(place.Address), name) {
+			continue
+		}
+		center.Latitude += place.Latitude
+		center.Longitude += place.Longitude
+		found++
+	}
+	if found == 0 {
+		return CityCenter{}, false, nil
+	}
+	center.Latitude /= float64(found)
+	center.Longitude /= float64(found)
+	return center, true, nil
+}
+
+func foldCity(city string) string {
+	return strings.Join(strings.Fields(foldText(city)), " ")
+}
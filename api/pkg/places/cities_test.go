@@ -0,0 +1,61 @@
+package places
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCityCenter(t *testing.T) {
+	wroclaw, ok := FindCityCenter("Wroclaw")
+	assert.True(t, ok)
+
+	for _, name := range []string{"Wrocław", " WROCŁAW ", "wroclaw"} {
+		center, ok := FindCityCenter(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, wroclaw, center, name)
+	}
+
+	_, ok = FindCityCenter("Zielona  Góra")
+	assert.True(t, ok)
+
+	_, ok = FindCityCenter("Iktslan")
+	assert.False(t, ok)
+}
+
+func TestGeocodeCity(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewOsmProvider(strings.NewReader(`{"elements": [
+		{"type": "node", "id": 1, "lat": 51.30, "lon": 17.06,
+		 "tags": {"leisure": "pitch", "sport": "tennis", "name": "Korty Miejskie", "addr:city": "Trzebnica"}},
+		{"type": "node", "id": 2, "lat": 51.32, "lon": 17.08,
+		 "tags": {"leisure": "pitch", "sport": "tennis", "name": "Trzebnica Tenis", "addr:city": "Trzebnica"}},
+		{"type": "node", "id": 3, "lat": 50.00, "lon": 20.00,
+		 "tags": {"leisure": "pitch", "sport": "tennis", "name": "Klub Trzebnica", "addr:city": "Kraków"}}
+	]}`), "PL")
+	require.NoError(t, err)
+
+	// cities of the table are not searched
+	center, ok, err := GeocodeCity(ctx, provider, "Wrocław")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, cityCenters["wroclaw"], center)
+
+	// places named after the city elsewhere are left out
+	center, ok, err = GeocodeCity(ctx, provider, " TRZEBNICA ")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 51.31, center.Latitude, 1e-9)
+	assert.InDelta(t, 17.07, center.Longitude, 1e-9)
+
+	_, ok, err = GeocodeCity(ctx, provider, "Iktslan")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = GeocodeCity(ctx, nil, "Trzebnica")
+	require.NoError(t, err)
+	assert.False(t, ok, "cities are only looked up in the table without a provider")
+}
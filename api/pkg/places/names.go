@@ -0,0 +1,68 @@
+package places
+
+import (
+	"strings"
+	"unicode"
+)
+
+// genericNameWords say that a place is about tennis but not which one, they are ignored when comparing names
+var genericNameWords = map[string]bool{
+	"tennis": true, "tenis": true, "tenisowe": true, "tenisowy": true, "tenisa": true,
+	"korty": true, "kort": true, "court": true, "courts": true,
+	"klub": true, "club": true, "centrum": true, "center": true, "centre": true,
+	"sports": true, "sport": true, "sportowy": true, "sportowe": true, "ks": true, "tc": true,
+}
+
+// NameSimilarity compares names of places from 0 for unrelated names to 1 for the same name. Case, diacritics,
+// punctuation and generic words like "tennis club" are ignored, the rest is compared by the Dice coefficient of
+// letter pairs, so that "Match Point" and "Matchpoint Wrocław" are similar.
+func NameSimilarity(a, b string) float64 {
+	pairsA, pairsB := letterPairs(significantName(a)), letterPairs(significantName(b))
+	if len(pairsA) == 0 || len(pairsB) == 0 {
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, pair := range pairsA {
+		counts[pair]++
+	}
+	common := 0
+	for _, pair := range pairsB {
+		if counts[pair] > 0 {
+			counts[pair]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(pairsA)+len(pairsB))
+}
+
+// significantName folds the name and removes punctuation, spaces and generic words
+func significantName(name string) string {
+	words := strings.FieldsFunc(foldText(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var significant strings.Builder
+	for _, word := range words {
+		if !genericNameWords[word] {
+			significant.WriteString(word)
+		}
+	}
+	// a name made of generic words only is all there is to compare
+	if significant.Len() == 0 {
+		return strings.Join(words, "")
+	}
+	return significant.String()
+}
+
+func letterPairs(s string) []string {
+	runes := []rune(s)
+	if len(runes) == 1 {
+		return []string{s}
+	}
+	pairs := make([]string, 0, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		pairs = append(pairs, string(runes[i:i+2]))
+	}
+	return pairs
+}
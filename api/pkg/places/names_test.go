@@ -0,0 +1,19 @@
+package places
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, NameSimilarity("Matchpoint", "MATCHPOINT"))
+	assert.Equal(t, 1.0, NameSimilarity("Korty Tenisowe Pafawag", "Pafawag Tennis Club"))
+	assert.Greater(t, NameSimilarity("Kort tenisowy", "Korty tenisowe"), 0.7, "generic names are compared as they are")
+	assert.Equal(t, 0.0, NameSimilarity("", "Matchpoint"))
+
+	assert.Greater(t, NameSimilarity("Match Point", "Matchpoint Wrocław"), 0.6)
+	assert.Greater(t, NameSimilarity("KS Olimpia Poznań", "Olimpia Poznan"), 0.9)
+	assert.Less(t, NameSimilarity("Matchpoint", "Park Szczytnicki"), 0.3)
+	assert.Less(t, NameSimilarity("Tenis Arena", "Tennis Club Olimpia"), 0.3)
+}
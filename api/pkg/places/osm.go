@@ -0,0 +1,194 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type overpassResponse struct {
+	Elements []overpassElement `json:"elements"`
+}
+
+type overpassElement struct {
+	Type   string            `json:"type"`
+	ID     int64             `json:"id"`
+	Lat    float64           `json:"lat"`
+	Lon    float64           `json:"lon"`
+	Center *overpassCenter   `json:"center"`
+	Tags   map[string]string `json:"tags"`
+}
+
+type overpassCenter struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+type osmPlace struct {
+	PlaceResult
+	// text is the folded name and address matched against queries
+	text string
+}
+
+// OsmProvider searches tennis places of an OpenStreetMap extract loaded in memory, so that place search works
+// without the Google Places API. The extract is an Overpass JSON dump, e.g. of the query
+// `[out:json]; nwr[sport~"tennis"](area); out center;`.
+type OsmProvider struct {
+	places []osmPlace
+	byID   map[string]*osmPlace
+}
+
+// NewOsmProviderFromFile loads the Overpass JSON dump from the file
+func NewOsmProviderFromFile(path string, defaultCountry string) (*OsmProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OpenStreetMap extract: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	return NewOsmProvider(file, defaultCountry)
+}
+
+// NewOsmProvider loads the Overpass JSON dump. Named tennis pitches and sports centres (leisure=pitch or
+// sports_centre with sport=tennis) are kept, unnamed pitches are usually single courts of a named club and are
+// skipped. Places without addr:country get the default country.
+func NewOsmProvider(r io.Reader, defaultCountry string) (*OsmProvider, error) {
+	var dump overpassResponse
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenStreetMap extract: %w", err)
+	}
+
+	p := &OsmProvider{byID: map[string]*osmPlace{}}
+	for _, e := range dump.Elements {
+		if !isTennisPlace(e.Tags) {
+			continue
+		}
+		lat, lng := e.Lat, e.Lon
+		if e.Center != nil {
+			lat, lng = e.Center.Lat, e.Center.Lon
+		}
+		if lat == 0 && lng == 0 {
+			continue
+		}
+
+		country := e.Tags["addr:country"]
+		if country == "" {
+			country = defaultCountry
+		}
+		place := osmPlace{
+			PlaceResult: PlaceResult{
+				PlaceID:        fmt.Sprintf("osm-%s-%d", e.Type, e.ID),
+				Name:           e.Tags["name"],
+				Address:        osmAddress(e.Tags),
+				Country:        strings.ToUpper(country),
+				Latitude:       lat,
+				Longitude:      lng,
+				GoogleMapsLink: "https://www.google.com/maps/search/?api=1&query=" + strconv.FormatFloat(lat, 'f', 6, 64) + "," + strconv.FormatFloat(lng, 'f', 6, 64),
+				Website:        e.Tags["website"],
+			},
+		}
+		place.text = foldText(place.Name + " " + place.Address)
+		p.places = append(p.places, place)
+	}
+	for i := range p.places {
+		p.byID[p.places[i].PlaceID] = &p.places[i]
+	}
+	return p, nil
+}
+
+func (p *OsmProvider) Name() string {
+	return ProviderOsm
+}
+
+// Count returns the number of loaded places
+func (p *OsmProvider) Count() int {
+	return len(p.places)
+}
+
+// SearchPlaces returns places whose name or address contains every word of the query
+func (p *OsmProvider) SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error) {
+	words := strings.Fields(foldText(query))
+
+	var matches []osmPlace
+	for _, place := range p.places {
+		matched := true
+		for _, word := range words {
+			matched = matched && strings.Contains(place.text, word)
+		}
+		if matched {
+			matches = append(matches, place)
+		}
+	}
+
+	if lat != 0 || lng != 0 {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return squaredDistance(matches[i].PlaceResult, lat, lng) < squaredDistance(matches[j].PlaceResult, lat, lng)
+		})
+	} else {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	}
+
+	results := make([]PlaceResult, 0, maxResults)
+	for i := 0; i < len(matches) && i < maxResults; i++ {
+		results = append(results, matches[i].PlaceResult)
+	}
+	return results, nil
+}
+
+// GetPlaceDetails returns the loaded place
+func (p *OsmProvider) GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error) {
+	place, ok := p.byID[placeID]
+	if !ok {
+		return nil, fmt.Errorf("place %s not found", placeID)
+	}
+	result := place.PlaceResult
+	return &result, nil
+}
+
+func isTennisPlace(tags map[string]string) bool {
+	if tags["name"] == "" {
+		return false
+	}
+	if leisure := tags["leisure"]; leisure != "pitch" && leisure != "sports_centre" {
+		return false
+	}
+	// sport may list several sports, e.g. tennis;padel
+	for _, sport := range strings.Split(tags["sport"], ";") {
+		if strings.TrimSpace(sport) == "tennis" {
+			return true
+		}
+	}
+	return false
+}
+
+// osmAddress formats the addr:* tags as "street number, postcode city"
+func osmAddress(tags map[string]string) string {
+	if full := tags["addr:full"]; full != "" {
+		return full
+	}
+	street := strings.TrimSpace(tags["addr:street"] + " " + tags["addr:housenumber"])
+	if street == "" {
+		street = tags["addr:place"]
+	}
+	city := strings.TrimSpace(tags["addr:postcode"] + " " + tags["addr:city"])
+	if street != "" && city != "" {
+		return street + ", " + city
+	}
+	return street + city
+}
+
+func foldText(s string) string {
+	return diacritics.Replace(strings.ToLower(s))
+}
+
+// squaredDistance is only good for ordering places by distance from a nearby point
+func squaredDistance(p PlaceResult, lat, lng float64) float64 {
+	dLat := p.Latitude - lat
+	dLng := (p.Longitude - lng) * math.Cos(lat*math.Pi/180)
+	return dLat*dLat + dLng*dLng
+}
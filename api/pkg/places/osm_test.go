@@ -0,0 +1,60 @@
+package places
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOverpassDump = `{
+  "version": 0.6,
+  "elements": [
+    {"type": "way", "id": 101, "center": {"lat": 51.1425, "lon": 17.0913},
+     "tags": {"leisure": "sports_centre", "sport": "tennis;padel", "name": "Matchpoint",
+              "addr:street": "Kiełczowska", "addr:housenumber": "70", "addr:postcode": "51-315", "addr:city": "Wrocław",
+              "website": "https://matchpoint.com.pl"}},
+    {"type": "node", "id": 202, "lat": 52.2297, "lon": 21.0122,
+     "tags": {"leisure": "pitch", "sport": "tennis", "name": "Korty Warszawa", "addr:country": "pl", "addr:city": "Warszawa"}},
+    {"type": "node", "id": 203, "lat": 51.11, "lon": 17.03,
+     "tags": {"leisure": "pitch", "sport": "tennis", "name": "Korty Park Szczytnicki", "addr:city": "Wrocław"}},
+    {"type": "way", "id": 303, "center": {"lat": 51.1, "lon": 17.0}, "tags": {"leisure": "pitch", "sport": "tennis"}},
+    {"type": "way", "id": 404, "center": {"lat": 51.1, "lon": 17.0}, "tags": {"leisure": "pitch", "sport": "soccer", "name": "Stadion"}}
+  ]
+}`
+
+func TestOsmProvider(t *testing.T) {
+	p, err := NewOsmProvider(strings.NewReader(testOverpassDump), "PL")
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Count(), "unnamed and non tennis places are skipped")
+
+	ctx := context.Background()
+	results, err := p.SearchPlaces(ctx, "matchpoint", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "osm-way-101", results[0].PlaceID)
+	assert.Equal(t, "Kiełczowska 70, 51-315 Wrocław", results[0].Address)
+	assert.Equal(t, "PL", results[0].Country)
+	assert.Equal(t, 51.1425, results[0].Latitude)
+
+	// words are matched in the name and address without diacritics, closest places first
+	results, err = p.SearchPlaces(ctx, "korty WROCLAW", 51.11, 17.03)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Korty Park Szczytnicki", results[0].Name)
+
+	results, err = p.SearchPlaces(ctx, "korty", 52.2, 21.0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Korty Warszawa", results[0].Name)
+
+	details, err := p.GetPlaceDetails(ctx, "osm-node-202")
+	require.NoError(t, err)
+	assert.Equal(t, "Warszawa", details.Address)
+	assert.NoError(t, ValidatePlaceID(details.PlaceID))
+
+	_, err = p.GetPlaceDetails(ctx, "osm-way-303")
+	assert.Error(t, err)
+}
@@ -16,6 +16,12 @@ const (
 	maxResults = 10
 )
 
+// Names of the providers, a place id is unique only together with the name of its provider
+const (
+	ProviderGoogle = "google"
+	ProviderOsm    = "osm"
+)
+
 var validPlaceID = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 func ValidatePlaceID(placeID string) error {
@@ -25,6 +31,16 @@ func ValidatePlaceID(placeID string) error {
 	return nil
 }
 
+// Provider searches sports facilities users can add as places to play at
+type Provider interface {
+	// Name tells which provider the place ids come from
+	Name() string
+	// SearchPlaces returns places matching the query, the ones closest to the coordinates first when they are set
+	SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error)
+	// GetPlaceDetails returns the place found by SearchPlaces
+	GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error)
+}
+
 type PlaceResult struct {
 	PlaceID        string
 	Name           string
@@ -36,19 +52,24 @@ type PlaceResult struct {
 	Website        string
 }
 
-type Service struct {
+// GoogleProvider searches places with the Google Places API
+type GoogleProvider struct {
 	apiKey string
 	client *http.Client
 }
 
-func NewService(apiKey string) *Service {
-	return &Service{
+func NewGoogleProvider(apiKey string) *GoogleProvider {
+	return &GoogleProvider{
 		apiKey: apiKey,
 		client: &http.Client{},
 	}
 }
 
-func (s *Service) IsConfigured() bool {
+func (s *GoogleProvider) Name() string {
+	return ProviderGoogle
+}
+
+func (s *GoogleProvider) IsConfigured() bool {
 	return s.apiKey != ""
 }
 
@@ -98,7 +119,7 @@ type addressComponent struct {
 }
 
 // SearchPlaces searches for sports facilities near the given coordinates
-func (s *Service) SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error) {
+func (s *GoogleProvider) SearchPlaces(ctx context.Context, query string, lat, lng float64) ([]PlaceResult, error) {
 	logCtx := slog.With("method", "SearchPlaces", "query", query, "lat", lat, "lng", lng)
 
 	if !s.IsConfigured() {
@@ -162,7 +183,7 @@ func (s *Service) SearchPlaces(ctx context.Context, query string, lat, lng float
 }
 
 // GetPlaceDetails fetches details for a specific place by its ID
-func (s *Service) GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error) {
+func (s *GoogleProvider) GetPlaceDetails(ctx context.Context, placeID string) (*PlaceResult, error) {
 	logCtx := slog.With("method", "GetPlaceDetails", "placeID", placeID)
 
 	if err := ValidatePlaceID(placeID); err != nil {
@@ -0,0 +1,280 @@
+// Package pricing resolves court prices from the price lists published by facilities
+package pricing
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // facilities are priced in their local time, the server image has no zone database
+)
+
+// Day patterns of price rules
+const (
+	// DayPatternEveryday applies on days without rules of a more specific pattern
+	DayPatternEveryday = "*"
+	DayPatternSaturday = "st"
+	DayPatternSunday   = "su"
+	// DayPatternHoliday applies on public holidays instead of the weekday and weekend rules
+	DayPatternHoliday = "hl"
+	// DayPatternForce applies every day and wins over all other rules of the price period
+	DayPatternForce = "!"
+)
+
+// ErrNoPrice is returned when the price list does not cover the whole session
+var ErrNoPrice = errors.New("no price for the whole session")
+
+const minutesPerDay = 24 * 60
+
+// Rule is the hourly price of a court between two clock times, in minutes since midnight. A rule ending before it
+// starts spans midnight and belongs to the day it starts on, a rule ending when it starts lasts the whole day.
+type Rule struct {
+	DayPattern string
+	Start      int
+	End        int
+	Price      float64
+}
+
+// Period is a price list valid between two dates, both inclusive
+type Period struct {
+	ValidFrom time.Time
+	ValidTo   time.Time
+	Rules     []Rule
+}
+
+// CourtGroup is a group of courts of the facility sharing prices
+type CourtGroup struct {
+	Id      int
+	Surface string
+	Type    string
+	Periods []Period
+}
+
+// HolidayFunc tells whether the day is a public holiday at the facility
+type HolidayFunc func(day time.Time) bool
+
+// PriceList holds the prices of all court groups of a facility
+type PriceList struct {
+	FacilityId  string
+	Country     string
+	Location    *time.Location // time zone the rules are expressed in
+	IsHoliday   HolidayFunc    // optional, "hl" rules never apply without it
+	CourtGroups []CourtGroup
+}
+
+// Segment is a part of the session charged at the same hourly price
+type Segment struct {
+	Start       time.Time
+	End         time.Time
+	HourlyPrice float64
+	Price       float64
+}
+
+// Quote is the price of a session on the courts of a group
+type Quote struct {
+	CourtGroup CourtGroup
+	Price      float64
+	Segments   []Segment
+}
+
+var countryTimeZones = map[string]string{
+	"PL":  "Europe/Warsaw",
+	"POL": "Europe/Warsaw",
+}
+
+// TimeZone returns the time zone prices of facilities in the country are expressed in, UTC when it is not known
+func TimeZone(country string) *time.Location {
+	name, ok := countryTimeZones[strings.ToUpper(country)]
+	if !ok {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Quote returns the price of the session for every court group with a price during the whole session,
+// the cheapest first
+func (l *PriceList) Quote(start time.Time, duration time.Duration) []Quote {
+	quotes := []Quote{}
+	for _, group := range l.CourtGroups {
+		quote, err := l.QuoteCourtGroup(group, start, duration)
+		if err != nil {
+			continue
+		}
+		quotes = append(quotes, *quote)
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Price < quotes[j].Price
+	})
+	return quotes
+}
+
+// Cheapest returns the lowest price of the session, ok is false when no court group has a price for it
+func (l *PriceList) Cheapest(start time.Time, duration time.Duration) (float64, bool) {
+	quotes := l.Quote(start, duration)
+	if len(quotes) == 0 {
+		return 0, false
+	}
+	return quotes[0].Price, true
+}
+
+// QuoteCourtGroup returns the price of the session on the courts of the group. The session is split into segments
+// wherever the applicable rule may change: at rule boundaries and at midnight.
+func (l *PriceList) QuoteCourtGroup(group CourtGroup, start time.Time, duration time.Duration) (*Quote, error) {
+	if duration <= 0 {
+		return nil, ErrNoPrice
+	}
+	loc := l.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	quote := &Quote{CourtGroup: group}
+	end := start.Add(duration).In(loc)
+	for current := start.In(loc); current.Before(end); {
+		day := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, loc)
+		minute := current.Hour()*60 + current.Minute()
+
+		rule := l.ruleAt(group, day, minute)
+		if rule == nil {
+			return nil, ErrNoPrice
+		}
+
+		next := nextBoundary(group, day, minute)
+		if next.After(end) {
+			next = end
+		}
+		price := rule.Price * next.Sub(current).Hours()
+		quote.Segments = append(quote.Segments, Segment{
+			Start:       current,
+			End:         next,
+			HourlyPrice: rule.Price,
+			Price:       roundPrice(price),
+		})
+		quote.Price += price
+		current = next
+	}
+	quote.Price = roundPrice(quote.Price)
+	return quote, nil
+}
+
+// ruleAt returns the rule deciding the price at the minute of the day. A rule of the day itself wins over a rule
+// of the previous day spanning midnight unless only the latter is forced.
+func (l *PriceList) ruleAt(group CourtGroup, day time.Time, minute int) *Rule {
+	today := l.resolve(group, day, minute)
+	yesterday := l.resolve(group, day.AddDate(0, 0, -1), minute+minutesPerDay)
+	if yesterday != nil && yesterday.DayPattern == DayPatternForce && (today == nil || today.DayPattern != DayPatternForce) {
+		return yesterday
+	}
+	if today != nil {
+		return today
+	}
+	return yesterday
+}
+
+// resolve returns the rule of the day covering the minute, which exceeds a day for rules spanning midnight.
+// Periods starting later override the ones they overlap, the next period is used when they do not cover the minute.
+func (l *PriceList) resolve(group CourtGroup, day time.Time, minute int) *Rule {
+	for _, period := range validPeriods(group, day) {
+		pattern := l.dayPattern(period, day)
+
+		var found *Rule
+		for i := range period.Rules {
+			rule := &period.Rules[i]
+			if rule.DayPattern != pattern && rule.DayPattern != DayPatternForce {
+				continue
+			}
+			if !rule.covers(minute) {
+				continue
+			}
+			if found == nil || rule.DayPattern == DayPatternForce {
+				found = rule
+			}
+		}
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// dayPattern returns the pattern of the rules pricing the day in the period: everyday rules only apply on days
+// without rules of their own
+func (l *PriceList) dayPattern(period Period, day time.Time) string {
+	candidates := []string{}
+	if l.IsHoliday != nil && l.IsHoliday(day) {
+		candidates = append(candidates, DayPatternHoliday)
+	}
+	switch day.Weekday() {
+	case time.Saturday:
+		candidates = append(candidates, DayPatternSaturday)
+	case time.Sunday:
+		candidates = append(candidates, DayPatternSunday)
+	}
+
+	for _, pattern := range candidates {
+		for _, rule := range period.Rules {
+			if rule.DayPattern == pattern {
+				return pattern
+			}
+		}
+	}
+	return DayPatternEveryday
+}
+
+func (r *Rule) covers(minute int) bool {
+	if minute >= minutesPerDay {
+		// the part of the previous day's rule after midnight
+		minute -= minutesPerDay
+		return (r.Start > r.End && minute < r.End) || (r.Start == r.End && minute < r.Start)
+	}
+	if r.Start < r.End {
+		return minute >= r.Start && minute < r.End
+	}
+	return minute >= r.Start
+}
+
+// validPeriods returns periods of the group valid on the day, the latest starting first
+func validPeriods(group CourtGroup, day time.Time) []Period {
+	date := day.Format(time.DateOnly)
+	periods := []Period{}
+	for _, period := range group.Periods {
+		if period.ValidFrom.Format(time.DateOnly) <= date && date <= period.ValidTo.Format(time.DateOnly) {
+			periods = append(periods, period)
+		}
+	}
+	sort.SliceStable(periods, func(i, j int) bool {
+		if !periods[i].ValidFrom.Equal(periods[j].ValidFrom) {
+			return periods[i].ValidFrom.After(periods[j].ValidFrom)
+		}
+		return periods[i].ValidTo.Before(periods[j].ValidTo)
+	})
+	return periods
+}
+
+// nextBoundary returns the first time after the minute of the day at which a rule of the group starts or ends,
+// or the next midnight
+func nextBoundary(group CourtGroup, day time.Time, minute int) time.Time {
+	next := minutesPerDay
+	for _, period := range group.Periods {
+		for _, rule := range period.Rules {
+			for _, boundary := range []int{rule.Start, rule.End} {
+				if boundary > minute && boundary < next {
+					next = boundary
+				}
+			}
+		}
+	}
+	if next == minutesPerDay {
+		return day.AddDate(0, 0, 1)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, next, 0, 0, day.Location())
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
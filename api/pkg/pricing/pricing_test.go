@@ -0,0 +1,162 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func clock(h, m int) int {
+	return h*60 + m
+}
+
+// matchpointIndoor mirrors the seeded price list of an indoor court group
+func matchpointIndoor() CourtGroup {
+	return CourtGroup{
+		Id: 1,
+		Periods: []Period{{
+			ValidFrom: date("2024-05-01"),
+			ValidTo:   date("2024-10-01"),
+			Rules: []Rule{
+				{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(15, 0), Price: 60},
+				{DayPattern: DayPatternEveryday, Start: clock(15, 0), End: clock(23, 0), Price: 80},
+				{DayPattern: DayPatternForce, Start: clock(23, 0), End: clock(6, 0), Price: 50},
+				{DayPattern: DayPatternSaturday, Start: clock(7, 0), End: clock(15, 0), Price: 80},
+				{DayPattern: DayPatternSaturday, Start: clock(15, 0), End: clock(23, 0), Price: 60},
+				{DayPattern: DayPatternSunday, Start: clock(0, 0), End: clock(23, 0), Price: 50},
+			},
+		}},
+	}
+}
+
+func TestQuoteCourtGroup(t *testing.T) {
+	priceList := &PriceList{Location: time.UTC, CourtGroups: []CourtGroup{matchpointIndoor()}}
+
+	tests := []struct {
+		name     string
+		start    string
+		duration time.Duration
+		price    float64
+		segments int
+	}{
+		{"WithinRule", "2024-06-04T08:00:00Z", 90 * time.Minute, 90, 1},
+		{"SpansRuleBoundary", "2024-06-04T14:30:00Z", time.Hour, 70, 2},
+		{"SaturdayReplacesEverydayRules", "2024-06-08T14:00:00Z", 2 * time.Hour, 140, 2},
+		{"ForcedRuleSpansMidnight", "2024-06-04T22:30:00Z", 2 * time.Hour, 115, 3},
+		{"ForcedRuleWinsOverSunday", "2024-06-09T05:00:00Z", 2 * time.Hour, 100, 2},
+		{"ForcedRuleFromSaturdayNight", "2024-06-08T23:00:00Z", time.Hour, 50, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			start, _ := time.Parse(time.RFC3339, tc.start)
+			quote, err := priceList.QuoteCourtGroup(priceList.CourtGroups[0], start, tc.duration)
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, tc.price, quote.Price)
+				assert.Len(tt, quote.Segments, tc.segments)
+				assert.Equal(tt, start, quote.Segments[0].Start.UTC())
+				assert.Equal(tt, start.Add(tc.duration), quote.Segments[len(quote.Segments)-1].End.UTC())
+			}
+		})
+	}
+
+	t.Run("NotCoveredAtNight", func(tt *testing.T) {
+		start := time.Date(2024, 6, 4, 6, 0, 0, 0, time.UTC)
+		_, err := priceList.QuoteCourtGroup(priceList.CourtGroups[0], start, 2*time.Hour)
+		assert.ErrorIs(tt, err, ErrNoPrice)
+	})
+
+	t.Run("OutsideValidity", func(tt *testing.T) {
+		start := time.Date(2024, 10, 2, 10, 0, 0, 0, time.UTC)
+		_, err := priceList.QuoteCourtGroup(priceList.CourtGroups[0], start, time.Hour)
+		assert.ErrorIs(tt, err, ErrNoPrice)
+	})
+}
+
+func TestQuoteCourtGroup_OverridingPeriod(t *testing.T) {
+	group := CourtGroup{
+		Id: 1,
+		Periods: []Period{
+			{
+				ValidFrom: date("2024-01-01"),
+				ValidTo:   date("2024-12-31"),
+				Rules:     []Rule{{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(23, 0), Price: 100}},
+			},
+			{
+				// summer prices only cover the mornings
+				ValidFrom: date("2024-07-01"),
+				ValidTo:   date("2024-07-31"),
+				Rules:     []Rule{{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(12, 0), Price: 40}},
+			},
+		},
+	}
+	priceList := &PriceList{Location: time.UTC, CourtGroups: []CourtGroup{group}}
+
+	quote, err := priceList.QuoteCourtGroup(group, time.Date(2024, 7, 10, 11, 0, 0, 0, time.UTC), 2*time.Hour)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 140.0, quote.Price)
+	}
+
+	quote, err = priceList.QuoteCourtGroup(group, time.Date(2024, 8, 10, 11, 0, 0, 0, time.UTC), time.Hour)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 100.0, quote.Price)
+	}
+}
+
+func TestQuoteCourtGroup_Holiday(t *testing.T) {
+	group := CourtGroup{
+		Id: 1,
+		Periods: []Period{{
+			ValidFrom: date("2024-01-01"),
+			ValidTo:   date("2024-12-31"),
+			Rules: []Rule{
+				{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(23, 0), Price: 60},
+				{DayPattern: DayPatternHoliday, Start: clock(7, 0), End: clock(23, 0), Price: 90},
+			},
+		}},
+	}
+	start := time.Date(2024, 12, 25, 10, 0, 0, 0, time.UTC)
+
+	withoutHolidays := &PriceList{Location: time.UTC, CourtGroups: []CourtGroup{group}}
+	price, ok := withoutHolidays.Cheapest(start, time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 60.0, price)
+
+	withHolidays := &PriceList{
+		Location:    time.UTC,
+		CourtGroups: []CourtGroup{group},
+		IsHoliday: func(day time.Time) bool {
+			return day.Month() == time.December && day.Day() == 25
+		},
+	}
+	price, ok = withHolidays.Cheapest(start, time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 90.0, price)
+}
+
+func TestQuote_LocalTimeAndCheapestFirst(t *testing.T) {
+	tent := matchpointIndoor()
+	tent.Id = 2
+	for i := range tent.Periods[0].Rules {
+		tent.Periods[0].Rules[i].Price *= 2
+	}
+	priceList := &PriceList{Location: TimeZone("POL"), CourtGroups: []CourtGroup{tent, matchpointIndoor()}}
+
+	// 12:30 UTC is 14:30 in Warsaw in summer
+	quotes := priceList.Quote(time.Date(2024, 6, 4, 12, 30, 0, 0, time.UTC), time.Hour)
+	if assert.Len(t, quotes, 2) {
+		assert.Equal(t, 1, quotes[0].CourtGroup.Id)
+		assert.Equal(t, 70.0, quotes[0].Price)
+		assert.Equal(t, 140.0, quotes[1].Price)
+	}
+
+	assert.Equal(t, time.UTC, TimeZone("unknown"))
+}
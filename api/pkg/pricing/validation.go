@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"fmt"
+	"time"
+)
+
+var dayPatterns = map[string]bool{
+	DayPatternEveryday: true,
+	DayPatternSaturday: true,
+	DayPatternSunday:   true,
+	DayPatternHoliday:  true,
+	DayPatternForce:    true,
+}
+
+// ParseClock parses a clock time in HH:MM format to minutes since midnight
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes since midnight as a clock time in HH:MM format
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// ValidateRules checks the rules of a price period: known day patterns, clock times within a day, non-negative
+// prices and no two rules of the same day pattern covering the same time of the day. Rules spanning midnight are
+// checked against the early hours of their pattern as well.
+func ValidateRules(rules []Rule) error {
+	if len(rules) == 0 {
+		return fmt.Errorf("price period must have at least one rule")
+	}
+	for i, rule := range rules {
+		if !dayPatterns[rule.DayPattern] {
+			return fmt.Errorf("invalid day pattern %q", rule.DayPattern)
+		}
+		if rule.Start < 0 || rule.Start >= minutesPerDay || rule.End < 0 || rule.End >= minutesPerDay {
+			return fmt.Errorf("rule times must be within a day")
+		}
+		if rule.Price < 0 {
+			return fmt.Errorf("rule price must not be negative")
+		}
+		for _, other := range rules[:i] {
+			if other.DayPattern == rule.DayPattern && overlaps(other.intervals(), rule.intervals()) {
+				return fmt.Errorf("rules %s %s-%s and %s-%s overlap", rule.DayPattern,
+					FormatClock(other.Start), FormatClock(other.End), FormatClock(rule.Start), FormatClock(rule.End))
+			}
+		}
+	}
+	return nil
+}
+
+// ValidatePeriod checks the dates of the period, both inclusive, against the other periods of its court group.
+// A period may lie within another one to override its prices, periods overlapping only partially or covering the
+// same dates are rejected.
+func ValidatePeriod(period Period, others []Period) error {
+	from, to := period.ValidFrom.Format(time.DateOnly), period.ValidTo.Format(time.DateOnly)
+	if to < from {
+		return fmt.Errorf("price period must not end before it starts")
+	}
+	for _, other := range others {
+		otherFrom, otherTo := other.ValidFrom.Format(time.DateOnly), other.ValidTo.Format(time.DateOnly)
+		if to < otherFrom || otherTo < from {
+			continue
+		}
+		if from == otherFrom && to == otherTo {
+			return fmt.Errorf("price period %s - %s already exists", otherFrom, otherTo)
+		}
+		within := from >= otherFrom && to <= otherTo
+		contains := otherFrom >= from && otherTo <= to
+		if !within && !contains {
+			return fmt.Errorf("price period overlaps period %s - %s, dates are inclusive", otherFrom, otherTo)
+		}
+	}
+	return nil
+}
+
+// intervals returns the parts of the day covered by the rule as half-open minute ranges
+func (r *Rule) intervals() [][2]int {
+	switch {
+	case r.Start < r.End:
+		return [][2]int{{r.Start, r.End}}
+	case r.Start > r.End:
+		return [][2]int{{r.Start, minutesPerDay}, {0, r.End}}
+	default:
+		return [][2]int{{0, minutesPerDay}}
+	}
+}
+
+func overlaps(a [][2]int, b [][2]int) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x[0] < y[1] && y[0] < x[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
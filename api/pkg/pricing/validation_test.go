@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClock(t *testing.T) {
+	minute, err := ParseClock("07:30")
+	if assert.NoError(t, err) {
+		assert.Equal(t, clock(7, 30), minute)
+	}
+	assert.Equal(t, "07:30", FormatClock(minute))
+
+	for _, s := range []string{"24:00", "7", "07:60", ""} {
+		_, err := ParseClock(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	assert.NoError(t, ValidateRules(matchpointIndoor().Periods[0].Rules))
+
+	tests := map[string][]Rule{
+		"no rules":        {},
+		"unknown pattern": {{DayPattern: "mo", Start: clock(7, 0), End: clock(8, 0), Price: 10}},
+		"negative price":  {{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(8, 0), Price: -1}},
+		"outside of day":  {{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(24, 0), Price: 10}},
+		"overlap": {
+			{DayPattern: DayPatternEveryday, Start: clock(7, 0), End: clock(15, 0), Price: 60},
+			{DayPattern: DayPatternEveryday, Start: clock(14, 0), End: clock(23, 0), Price: 80},
+		},
+		"overlap after midnight": {
+			{DayPattern: DayPatternEveryday, Start: clock(22, 0), End: clock(2, 0), Price: 60},
+			{DayPattern: DayPatternEveryday, Start: clock(1, 0), End: clock(7, 0), Price: 80},
+		},
+		"whole day overlap": {
+			{DayPattern: DayPatternSunday, Start: clock(0, 0), End: clock(0, 0), Price: 60},
+			{DayPattern: DayPatternSunday, Start: clock(8, 0), End: clock(9, 0), Price: 80},
+		},
+	}
+	for name, rules := range tests {
+		assert.Error(t, ValidateRules(rules), name)
+	}
+}
+
+func TestValidatePeriod(t *testing.T) {
+	season := Period{ValidFrom: date("2024-05-01"), ValidTo: date("2024-09-30")}
+
+	tests := []struct {
+		name   string
+		period Period
+		valid  bool
+	}{
+		{"before", Period{ValidFrom: date("2024-01-01"), ValidTo: date("2024-04-30")}, true},
+		{"within", Period{ValidFrom: date("2024-07-01"), ValidTo: date("2024-07-31")}, true},
+		{"containing", Period{ValidFrom: date("2024-01-01"), ValidTo: date("2024-12-31")}, true},
+		{"same dates", Period{ValidFrom: date("2024-05-01"), ValidTo: date("2024-09-30")}, false},
+		{"sharing the last day", Period{ValidFrom: date("2024-09-30"), ValidTo: date("2025-04-30")}, false},
+		{"ending before start", Period{ValidFrom: date("2025-05-01"), ValidTo: date("2025-04-30")}, false},
+	}
+	for _, tt := range tests {
+		err := ValidatePeriod(tt.period, []Period{season})
+		assert.Equal(t, tt.valid, err == nil, "%s: %v", tt.name, err)
+	}
+}
@@ -0,0 +1,81 @@
+// Package rating computes player ratings from confirmed match results using the Elo system
+package rating
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// DefaultRating is given to players who did not declare their NTRP level
+	DefaultRating = 1500.0
+
+	// ProvisionalMatches is the number of matches during which ratings move faster
+	ProvisionalMatches = 10
+
+	provisionalK = 40.0
+	establishedK = 20.0
+
+	// rating points per NTRP level when seeding ratings from self-declared levels
+	pointsPerNtrpLevel = 200.0
+	ntrpMidLevel       = 3.5
+)
+
+// Player is a rating of a player together with the number of rated matches it is based on
+type Player struct {
+	Rating        float64
+	MatchesPlayed int
+}
+
+// InitialRating seeds the rating of a player without rated matches from the self-declared NTRP level
+func InitialRating(ntrpLevel float64) float64 {
+	if ntrpLevel <= 0 {
+		return DefaultRating
+	}
+	return DefaultRating + (ntrpLevel-ntrpMidLevel)*pointsPerNtrpLevel
+}
+
+// ExpectedScore is the probability of the player beating the opponent
+func ExpectedScore(player float64, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-player)/400))
+}
+
+// Update returns ratings of the winner and the loser after the match between them
+func Update(winner Player, loser Player) (Player, Player) {
+	winners, losers := UpdateTeams([]Player{winner}, []Player{loser})
+	return winners[0], losers[0]
+}
+
+// UpdateTeams returns ratings of the players of the winning and the losing team after the match between them.
+// A team is rated as the average of its players, every player moves by the team's surprise scaled by their own K.
+func UpdateTeams(winners []Player, losers []Player) ([]Player, []Player) {
+	expected := ExpectedScore(teamRating(winners), teamRating(losers))
+
+	winners = slices.Clone(winners)
+	for i := range winners {
+		winners[i].Rating += kFactor(winners[i]) * (1 - expected)
+		winners[i].MatchesPlayed++
+	}
+	losers = slices.Clone(losers)
+	for i := range losers {
+		losers[i].Rating -= kFactor(losers[i]) * (1 - expected)
+		losers[i].MatchesPlayed++
+	}
+
+	return winners, losers
+}
+
+func teamRating(team []Player) float64 {
+	total := 0.0
+	for _, p := range team {
+		total += p.Rating
+	}
+	return total / float64(len(team))
+}
+
+func kFactor(p Player) float64 {
+	if p.MatchesPlayed < ProvisionalMatches {
+		return provisionalK
+	}
+	return establishedK
+}
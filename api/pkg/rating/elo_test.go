@@ -0,0 +1,58 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitialRating(t *testing.T) {
+	assert.Equal(t, DefaultRating, InitialRating(0))
+	assert.Equal(t, DefaultRating, InitialRating(3.5))
+	assert.Equal(t, 1200.0, InitialRating(2.0))
+	assert.Equal(t, 1800.0, InitialRating(5.0))
+}
+
+func TestExpectedScore(t *testing.T) {
+	assert.InDelta(t, 0.5, ExpectedScore(1500, 1500), 0.0001)
+	assert.InDelta(t, 0.909, ExpectedScore(1800, 1400), 0.001)
+	assert.InDelta(t, 1, ExpectedScore(1500, 1400)+ExpectedScore(1400, 1500), 0.0001)
+}
+
+func TestUpdate_EqualPlayers(t *testing.T) {
+	winner, loser := Update(Player{Rating: 1500}, Player{Rating: 1500})
+
+	assert.InDelta(t, 1520, winner.Rating, 0.0001)
+	assert.InDelta(t, 1480, loser.Rating, 0.0001)
+	assert.Equal(t, 1, winner.MatchesPlayed)
+	assert.Equal(t, 1, loser.MatchesPlayed)
+}
+
+func TestUpdate_UpsetMovesRatingsMore(t *testing.T) {
+	favourite, _ := Update(Player{Rating: 1800, MatchesPlayed: 20}, Player{Rating: 1400, MatchesPlayed: 20})
+	underdog, _ := Update(Player{Rating: 1400, MatchesPlayed: 20}, Player{Rating: 1800, MatchesPlayed: 20})
+
+	assert.Less(t, favourite.Rating-1800, underdog.Rating-1400)
+}
+
+func TestUpdate_ProvisionalPlayersMoveFaster(t *testing.T) {
+	provisional, established := Update(Player{Rating: 1500}, Player{Rating: 1500, MatchesPlayed: ProvisionalMatches})
+
+	assert.InDelta(t, 1520, provisional.Rating, 0.0001)
+	assert.InDelta(t, 1490, established.Rating, 0.0001)
+}
+
+func TestUpdateTeams_RatesTeamsByAverage(t *testing.T) {
+	winners, losers := UpdateTeams(
+		[]Player{{Rating: 1600, MatchesPlayed: 20}, {Rating: 1400}},
+		[]Player{{Rating: 1500, MatchesPlayed: 20}, {Rating: 1500, MatchesPlayed: 20}})
+
+	// equal averages, so every player moves by half of their K
+	assert.InDelta(t, 1610, winners[0].Rating, 0.0001)
+	assert.InDelta(t, 1420, winners[1].Rating, 0.0001)
+	assert.InDelta(t, 1490, losers[0].Rating, 0.0001)
+	assert.InDelta(t, 1490, losers[1].Rating, 0.0001)
+	assert.Equal(t, 21, winners[0].MatchesPlayed)
+	assert.Equal(t, 1, winners[1].MatchesPlayed)
+	assert.Equal(t, 21, losers[0].MatchesPlayed)
+}
@@ -0,0 +1,82 @@
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakeProvider is an in-process provider with a fixed number of courts, used in tests and local development
+type FakeProvider struct {
+	mu       sync.Mutex
+	courts   int
+	bookings map[string]fakeBooking // by reference
+	next     int
+	// cancelled are the references of the cancelled bookings in the order they were cancelled
+	cancelled []string
+	// Err is returned by Reserve instead of booking a court when set
+	Err error
+}
+
+type fakeBooking struct {
+	court int
+	start time.Time
+	end   time.Time
+}
+
+// NewFakeProvider creates a fake provider of a facility with courts numbered from 1
+func NewFakeProvider(courts int) *FakeProvider {
+	return &FakeProvider{
+		courts:   courts,
+		bookings: map[string]fakeBooking{},
+	}
+}
+
+// Reserve books the lowest numbered court free during the whole session
+func (p *FakeProvider) Reserve(ctx context.Context, req Request) (*Booking, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	end := req.Start.Add(req.Duration)
+	for court := 1; court <= p.courts; court++ {
+		if !p.isTaken(court, req.Start, end) {
+			p.next++
+			reference := fmt.Sprintf("fake-%d", p.next)
+			p.bookings[reference] = fakeBooking{court: court, start: req.Start, end: end}
+			return &Booking{CourtNumber: strconv.Itoa(court), Reference: reference}, nil
+		}
+	}
+	return nil, ErrNoCourtAvailable
+}
+
+// Cancel releases the court, unknown bookings are ignored
+func (p *FakeProvider) Cancel(ctx context.Context, booking Booking) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.bookings, booking.Reference)
+	p.cancelled = append(p.cancelled, booking.Reference)
+	return nil
+}
+
+// Cancelled returns the references of the bookings cancelled so far
+func (p *FakeProvider) Cancelled() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.cancelled)
+}
+
+func (p *FakeProvider) isTaken(court int, start time.Time, end time.Time) bool {
+	for _, b := range p.bookings {
+		if b.court == court && b.start.Before(end) && b.end.After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+// Package reservation books courts in the booking systems of facilities once an event is confirmed
+package reservation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoCourtAvailable is returned by providers when every court of the facility is taken during the session
+var ErrNoCourtAvailable = errors.New("no court available")
+
+// Request describes the session to book a court for
+type Request struct {
+	EventId    string
+	FacilityId string
+	Start      time.Time
+	Duration   time.Duration
+	Players    int
+}
+
+// Booking is a court booked in the reservation system of a facility
+type Booking struct {
+	CourtNumber string
+	Reference   string // id of the booking in the reservation system, used to cancel it
+}
+
+// ReservationProvider talks to the booking system of a facility, e.g. the one behind court_groups.reservation_link
+type ReservationProvider interface {
+	// Reserve books a court for the session, returns ErrNoCourtAvailable when the facility is fully booked
+	Reserve(ctx context.Context, req Request) (*Booking, error)
+	// Cancel releases a court booked by Reserve
+	Cancel(ctx context.Context, booking Booking) error
+}
+
+// Registry holds the reservation provider of each facility, facilities without one are booked by the host
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]ReservationProvider
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: map[string]ReservationProvider{},
+	}
+}
+
+// Register sets the provider of the facility replacing the previous one
+func (r *Registry) Register(facilityId string, provider ReservationProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[facilityId] = provider
+}
+
+// Get returns the provider of the facility, ok is false when courts of the facility are not booked automatically
+func (r *Registry) Get(facilityId string) (provider ReservationProvider, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok = r.providers[facilityId]
+	return provider, ok
+}
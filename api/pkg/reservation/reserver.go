@@ -0,0 +1,128 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// ReservationDb defines the database operations needed to move the reservation of a confirmation version
+// through its states. Each one returns false when the confirmation is no longer in the expected state,
+// e.g. because the event was cancelled or rescheduled in the meantime.
+type ReservationDb interface {
+	StartReservation(ctx context.Context, eventId string, version int) (bool, error)
+	CompleteReservation(ctx context.Context, eventId string, version int, courtNumber string, reference string) (bool, error)
+	FailReservation(ctx context.Context, eventId string, version int, reason string) (bool, error)
+}
+
+// ReservationNotifier defines the notification operations needed by the reserver
+type ReservationNotifier interface {
+	ReservationFailed(hostUserId string, eventId string, reason string)
+}
+
+// Reserver books the court of a confirmed event. The reservation starts NOT_REQUIRED and stays so when the
+// facility has no provider. Otherwise it moves to PENDING and then to RESERVED with the booked court, or to FAILED
+// in which case the event and its accepted join requests move to RESERVATION_FAILED and the players are notified.
+type Reserver struct {
+	registry *Registry
+	db       ReservationDb
+	notifier ReservationNotifier
+	logger   *slog.Logger
+}
+
+// NewReserver creates a reserver booking courts with the providers of the registry
+func NewReserver(registry *Registry, database ReservationDb, notifier ReservationNotifier) *Reserver {
+	return &Reserver{
+		registry: registry,
+		db:       database,
+		notifier: notifier,
+		logger:   slog.With("service", "reservation"),
+	}
+}
+
+// Reserve runs the reservation of the confirmation version and returns its final state
+func (r *Reserver) Reserve(ctx context.Context, hostUserId string, version int, req Request) (api.ReservationStatus, *Booking) {
+	logCtx := r.logger.With("eventId", req.EventId, "facilityId", req.FacilityId, "version", version)
+
+	provider, ok := r.registry.Get(req.FacilityId)
+	if !ok {
+		logCtx.Debug("Facility has no reservation provider")
+		return api.ReservationStatusNotRequired, nil
+	}
+
+	started, err := r.db.StartReservation(ctx, req.EventId, version)
+	if err != nil {
+		// the court is needed but the outcome is unknown, the host must not be told that no reservation is required
+		logCtx.Error("Failed to start reservation", "error", err)
+		return api.ReservationStatusPending, nil
+	}
+	if !started {
+		logCtx.Info("Confirmation changed before the reservation started")
+		return api.ReservationStatusNotRequired, nil
+	}
+
+	booking, err := provider.Reserve(ctx, req)
+	if err != nil {
+		logCtx.Warn("Failed to book a court", "error", err)
+
+		reason := "The booking system of the facility could not book a court"
+		if errors.Is(err, ErrNoCourtAvailable) {
+			reason = "No court is available at the confirmed time"
+		}
+		return r.fail(ctx, logCtx, hostUserId, version, req.EventId, reason)
+	}
+
+	completed, err := r.db.CompleteReservation(ctx, req.EventId, version, booking.CourtNumber, booking.Reference)
+	if err != nil {
+		// a booking that is not recorded could never be released, so it is given up and the host reschedules
+		logCtx.Error("Failed to record reservation, releasing the court", "error", err, "reference", booking.Reference)
+		if err := provider.Cancel(ctx, *booking); err != nil {
+			logCtx.Error("Failed to cancel booking", "error", err, "reference", booking.Reference)
+		}
+		return r.fail(ctx, logCtx, hostUserId, version, req.EventId, "The booked court could not be recorded and was released")
+	}
+	if !completed {
+		// nobody is going to play on the court anymore
+		logCtx.Info("Confirmation changed while booking, releasing the court", "reference", booking.Reference)
+		if err := provider.Cancel(ctx, *booking); err != nil {
+			logCtx.Error("Failed to cancel booking", "error", err, "reference", booking.Reference)
+		}
+		return api.ReservationStatusNotRequired, nil
+	}
+
+	logCtx.Info("Court reserved", "courtNumber", booking.CourtNumber)
+	return api.ReservationStatusReserved, booking
+}
+
+// fail records the failed reservation of the confirmation version and notifies the players when it was still pending
+func (r *Reserver) fail(ctx context.Context, logCtx *slog.Logger, hostUserId string, version int, eventId string, reason string) (api.ReservationStatus, *Booking) {
+	failed, err := r.db.FailReservation(ctx, eventId, version, reason)
+	if err != nil {
+		logCtx.Error("Failed to record failed reservation", "error", err)
+		return api.ReservationStatusPending, nil
+	}
+	if failed {
+		r.notifier.ReservationFailed(hostUserId, eventId, reason)
+	}
+	return api.ReservationStatusFailed, nil
+}
+
+// Release cancels the court booked for a confirmation that is no longer played, e.g. because the event was
+// cancelled or moved to another time. Failures are logged, the booking system keeps the court then.
+func (r *Reserver) Release(ctx context.Context, facilityId string, booking Booking) {
+	logCtx := r.logger.With("facilityId", facilityId, "reference", booking.Reference)
+
+	provider, ok := r.registry.Get(facilityId)
+	if !ok {
+		logCtx.Warn("Facility has no reservation provider anymore, the court cannot be released")
+		return
+	}
+
+	if err := provider.Cancel(ctx, booking); err != nil {
+		logCtx.Error("Failed to cancel booking", "error", err)
+		return
+	}
+	logCtx.Info("Court released", "courtNumber", booking.CourtNumber)
+}
@@ -0,0 +1,241 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/reservation/mocks"
+)
+
+func testRequest(eventId string) Request {
+	return Request{
+		EventId:    eventId,
+		FacilityId: "facility_1",
+		Start:      time.Date(2025, 6, 10, 18, 0, 0, 0, time.UTC),
+		Duration:   90 * time.Minute,
+		Players:    2,
+	}
+}
+
+func TestReserve_FacilityWithoutProvider(t *testing.T) {
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+
+	reserver := NewReserver(NewRegistry(), mockDb, mockNotifier)
+	status, booking := reserver.Reserve(context.Background(), "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusNotRequired, status)
+	assert.Nil(t, booking)
+}
+
+func TestReserve_BooksCourt(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(true, nil).Once()
+	mockDb.EXPECT().CompleteReservation(ctx, "event_1", 1, "1", "fake-1").Return(true, nil).Once()
+
+	registry := NewRegistry()
+	registry.Register("facility_1", NewFakeProvider(1))
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusReserved, status)
+	assert.Equal(t, &Booking{CourtNumber: "1", Reference: "fake-1"}, booking)
+}
+
+func TestReserve_NoCourtAvailable(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_2", 1).Return(true, nil).Once()
+	mockDb.EXPECT().FailReservation(ctx, "event_2", 1, "No court is available at the confirmed time").Return(true, nil).Once()
+	mockNotifier.EXPECT().ReservationFailed("host_1", "event_2", "No court is available at the confirmed time").Return().Once()
+
+	provider := NewFakeProvider(1)
+	_, err := provider.Reserve(ctx, testRequest("event_1"))
+	assert.NoError(t, err)
+
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_2"))
+
+	assert.Equal(t, api.ReservationStatusFailed, status)
+	assert.Nil(t, booking)
+}
+
+func TestReserve_ProviderError(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 2).Return(true, nil).Once()
+	mockDb.EXPECT().FailReservation(ctx, "event_1", 2, "The booking system of the facility could not book a court").Return(true, nil).Once()
+	mockNotifier.EXPECT().ReservationFailed("host_1", "event_1", "The booking system of the facility could not book a court").Return().Once()
+
+	provider := NewFakeProvider(1)
+	provider.Err = errors.New("booking system unavailable")
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, _ := reserver.Reserve(ctx, "host_1", 2, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusFailed, status)
+}
+
+func TestReserve_FailureAlreadyRecorded(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(true, nil).Once()
+	mockDb.EXPECT().FailReservation(ctx, "event_1", 1, "No court is available at the confirmed time").Return(false, nil).Once()
+
+	registry := NewRegistry()
+	registry.Register("facility_1", NewFakeProvider(0))
+
+	// the event was rescheduled meanwhile, players must not be told about the outdated version
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, _ := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusFailed, status)
+}
+
+func TestReserve_ConfirmationChangedBeforeStart(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(false, nil).Once()
+
+	provider := NewFakeProvider(1)
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusNotRequired, status)
+	assert.Nil(t, booking)
+	assert.Empty(t, provider.bookings)
+}
+
+func TestReserve_StartFails(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(false, errors.New("connection lost")).Once()
+
+	provider := NewFakeProvider(1)
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusPending, status)
+	assert.Nil(t, booking)
+	assert.Empty(t, provider.bookings, "no court should be booked without a pending reservation")
+}
+
+func TestReserve_ConfirmationChangedWhileBooking(t *testing.T) {
+	ctx := context.Background()
+
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(true, nil).Once()
+	mockDb.EXPECT().CompleteReservation(ctx, "event_1", 1, "1", "fake-1").Return(false, nil).Once()
+
+	provider := NewFakeProvider(1)
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusNotRequired, status)
+	assert.Nil(t, booking)
+	assert.Empty(t, provider.bookings, "the court should be released")
+	assert.Equal(t, []string{"fake-1"}, provider.Cancelled())
+}
+
+func TestReserve_RecordingFails(t *testing.T) {
+	ctx := context.Background()
+
+	const reason = "The booked court could not be recorded and was released"
+	mockDb := mocks.NewMockReservationDb(t)
+	mockNotifier := mocks.NewMockReservationNotifier(t)
+	mockDb.EXPECT().StartReservation(ctx, "event_1", 1).Return(true, nil).Once()
+	mockDb.EXPECT().CompleteReservation(ctx, "event_1", 1, "1", "fake-1").Return(false, errors.New("connection lost")).Once()
+	mockDb.EXPECT().FailReservation(ctx, "event_1", 1, reason).Return(true, nil).Once()
+	mockNotifier.EXPECT().ReservationFailed("host_1", "event_1", reason).Return().Once()
+
+	provider := NewFakeProvider(1)
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mockDb, mockNotifier)
+	status, booking := reserver.Reserve(ctx, "host_1", 1, testRequest("event_1"))
+
+	assert.Equal(t, api.ReservationStatusFailed, status)
+	assert.Nil(t, booking)
+	assert.Empty(t, provider.bookings, "the court should be released")
+	assert.Equal(t, []string{"fake-1"}, provider.Cancelled())
+}
+
+func TestRelease(t *testing.T) {
+	ctx := context.Background()
+
+	provider := NewFakeProvider(1)
+	booking, err := provider.Reserve(ctx, testRequest("event_1"))
+	assert.NoError(t, err)
+
+	registry := NewRegistry()
+	registry.Register("facility_1", provider)
+
+	reserver := NewReserver(registry, mocks.NewMockReservationDb(t), mocks.NewMockReservationNotifier(t))
+	reserver.Release(ctx, "facility_1", *booking)
+	reserver.Release(ctx, "facility_2", Booking{CourtNumber: "1", Reference: "other-1"})
+
+	assert.Empty(t, provider.bookings)
+	assert.Equal(t, []string{"fake-1"}, provider.Cancelled())
+}
+
+func TestFakeProvider_BooksFreeCourts(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider(2)
+
+	first, err := provider.Reserve(ctx, testRequest("event_1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", first.CourtNumber)
+
+	second, err := provider.Reserve(ctx, testRequest("event_2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", second.CourtNumber)
+
+	_, err = provider.Reserve(ctx, testRequest("event_3"))
+	assert.ErrorIs(t, err, ErrNoCourtAvailable)
+
+	// a session starting when the first one ends does not overlap it
+	later := testRequest("event_4")
+	later.Start = later.Start.Add(later.Duration)
+	booking, err := provider.Reserve(ctx, later)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", booking.CourtNumber)
+
+	assert.NoError(t, provider.Cancel(ctx, *second))
+	booking, err = provider.Reserve(ctx, testRequest("event_5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", booking.CourtNumber)
+}
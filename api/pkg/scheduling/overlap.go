@@ -0,0 +1,159 @@
+// Package scheduling finds the place and time combinations an event can be confirmed with
+package scheduling
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Interval is a period a player is busy according to their calendar
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Request is a join request which can still be confirmed
+type Request struct {
+	Id        string
+	UserId    string
+	Locations []string
+	TimeSlots []time.Time
+	Accepted  bool // accepted by the host before the confirmation
+	CreatedAt time.Time
+}
+
+// PriceFunc returns the court price of the session at the location, ok is false when the price is unknown
+type PriceFunc func(locationId string, start time.Time) (price float64, ok bool)
+
+// Event describes the event being confirmed and its join requests
+type Event struct {
+	HostUserId string
+	Locations  []string
+	TimeSlots  []time.Time
+	Duration   time.Duration
+	// Capacity is the number of join requests needed to confirm the event
+	Capacity  int
+	Requests  []Request
+	BusyTimes map[string][]Interval // by user id
+	Price     PriceFunc             // optional
+}
+
+// Candidate is a location and time with the join requests to confirm the event with
+type Candidate struct {
+	LocationId     string
+	DateTime       time.Time
+	JoinRequestIds []string
+	BusyUserIds    []string // players whose calendar is busy during the session, the host included
+	Price          float64
+	HasPrice       bool
+}
+
+// Complete tells whether the candidate has enough players to confirm the event
+func (c *Candidate) Complete(capacity int) bool {
+	return len(c.JoinRequestIds) == capacity
+}
+
+// Solve returns candidates of every location and time slot offered by at least one join request. Candidates with
+// more players come first, ties are broken by fewer calendar conflicts, then by cheaper court, then by earlier time.
+func Solve(event Event) []*Candidate {
+	candidates := []*Candidate{}
+	for _, slot := range event.TimeSlots {
+		for _, locationId := range event.Locations {
+			candidate := solveSlot(event, locationId, slot)
+			if candidate != nil {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if len(a.JoinRequestIds) != len(b.JoinRequestIds) {
+			return len(a.JoinRequestIds) > len(b.JoinRequestIds)
+		}
+		if len(a.BusyUserIds) != len(b.BusyUserIds) {
+			return len(a.BusyUserIds) < len(b.BusyUserIds)
+		}
+		if a.HasPrice != b.HasPrice {
+			return a.HasPrice
+		}
+		if a.HasPrice && a.Price != b.Price {
+			return a.Price < b.Price
+		}
+		if !a.DateTime.Equal(b.DateTime) {
+			return a.DateTime.Before(b.DateTime)
+		}
+		return a.LocationId < b.LocationId
+	})
+
+	return candidates
+}
+
+func solveSlot(event Event, locationId string, slot time.Time) *Candidate {
+	end := slot.Add(event.Duration)
+
+	type option struct {
+		request Request
+		busy    bool
+	}
+	options := []option{}
+	for _, request := range event.Requests {
+		if !slices.Contains(request.Locations, locationId) || !slices.ContainsFunc(request.TimeSlots, slot.Equal) {
+			continue
+		}
+		options = append(options, option{
+			request: request,
+			busy:    isBusy(event.BusyTimes[request.UserId], slot, end),
+		})
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	// players already accepted by the host keep their place, free players are preferred over busy ones,
+	// and earlier requests over later ones
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if a.request.Accepted != b.request.Accepted {
+			return a.request.Accepted
+		}
+		if a.busy != b.busy {
+			return !a.busy
+		}
+		return a.request.CreatedAt.Before(b.request.CreatedAt)
+	})
+	if len(options) > event.Capacity {
+		options = options[:event.Capacity]
+	}
+
+	candidate := &Candidate{
+		LocationId:     locationId,
+		DateTime:       slot,
+		JoinRequestIds: make([]string, 0, len(options)),
+		BusyUserIds:    []string{},
+	}
+	if isBusy(event.BusyTimes[event.HostUserId], slot, end) {
+		candidate.BusyUserIds = append(candidate.BusyUserIds, event.HostUserId)
+	}
+	for _, o := range options {
+		candidate.JoinRequestIds = append(candidate.JoinRequestIds, o.request.Id)
+		if o.busy {
+			candidate.BusyUserIds = append(candidate.BusyUserIds, o.request.UserId)
+		}
+	}
+	if event.Price != nil {
+		candidate.Price, candidate.HasPrice = event.Price(locationId, slot)
+	}
+
+	return candidate
+}
+
+func isBusy(intervals []Interval, start time.Time, end time.Time) bool {
+	for _, interval := range intervals {
+		if interval.Start.Before(end) && interval.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
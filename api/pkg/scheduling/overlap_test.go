@@ -0,0 +1,112 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	monday  = time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	tuesday = monday.AddDate(0, 0, 1)
+)
+
+func TestSolve_MaximisesAttendance(t *testing.T) {
+	candidates := Solve(Event{
+		HostUserId: "host",
+		Locations:  []string{"club-a", "club-b"},
+		TimeSlots:  []time.Time{monday, tuesday},
+		Duration:   time.Hour,
+		Capacity:   3,
+		Requests: []Request{
+			{Id: "jr1", UserId: "u1", Locations: []string{"club-a", "club-b"}, TimeSlots: []time.Time{monday, tuesday}},
+			{Id: "jr2", UserId: "u2", Locations: []string{"club-b"}, TimeSlots: []time.Time{tuesday}},
+			{Id: "jr3", UserId: "u3", Locations: []string{"club-a", "club-b"}, TimeSlots: []time.Time{tuesday}},
+		},
+	})
+
+	require.Len(t, candidates, 4)
+	assert.Equal(t, "club-b", candidates[0].LocationId)
+	assert.Equal(t, tuesday, candidates[0].DateTime)
+	assert.Equal(t, []string{"jr1", "jr2", "jr3"}, candidates[0].JoinRequestIds)
+	assert.True(t, candidates[0].Complete(3))
+	assert.Equal(t, []string{"jr1", "jr3"}, candidates[1].JoinRequestIds)
+	assert.False(t, candidates[1].Complete(3))
+}
+
+func TestSolve_SkipsCombinationsWithoutPlayers(t *testing.T) {
+	candidates := Solve(Event{
+		Locations: []string{"club-a", "club-b"},
+		TimeSlots: []time.Time{monday},
+		Duration:  time.Hour,
+		Capacity:  1,
+		Requests: []Request{
+			{Id: "jr1", UserId: "u1", Locations: []string{"club-a"}, TimeSlots: []time.Time{monday}},
+		},
+	})
+
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "club-a", candidates[0].LocationId)
+}
+
+func TestSolve_PrefersAcceptedThenFreeThenEarlierRequests(t *testing.T) {
+	candidates := Solve(Event{
+		Locations: []string{"club-a"},
+		TimeSlots: []time.Time{monday},
+		Duration:  time.Hour,
+		Capacity:  2,
+		Requests: []Request{
+			{Id: "early-busy", UserId: "u1", Locations: []string{"club-a"}, TimeSlots: []time.Time{monday}, CreatedAt: monday.AddDate(0, 0, -5)},
+			{Id: "early-free", UserId: "u2", Locations: []string{"club-a"}, TimeSlots: []time.Time{monday}, CreatedAt: monday.AddDate(0, 0, -4)},
+			{Id: "late-free", UserId: "u3", Locations: []string{"club-a"}, TimeSlots: []time.Time{monday}, CreatedAt: monday.AddDate(0, 0, -3)},
+			{Id: "accepted", UserId: "u4", Locations: []string{"club-a"}, TimeSlots: []time.Time{monday}, CreatedAt: monday.AddDate(0, 0, -1), Accepted: true},
+		},
+		BusyTimes: map[string][]Interval{
+			"u1": {{Start: monday.Add(30 * time.Minute), End: monday.Add(2 * time.Hour)}},
+		},
+	})
+
+	require.Len(t, candidates, 1)
+	assert.Equal(t, []string{"accepted", "early-free"}, candidates[0].JoinRequestIds)
+	assert.Empty(t, candidates[0].BusyUserIds)
+}
+
+func TestSolve_BreaksTiesByConflictsThenPrice(t *testing.T) {
+	prices := map[string]float64{"club-a": 30, "club-b": 20}
+	candidates := Solve(Event{
+		HostUserId: "host",
+		Locations:  []string{"club-a", "club-b", "club-c"},
+		TimeSlots:  []time.Time{monday, tuesday},
+		Duration:   time.Hour,
+		Capacity:   1,
+		Requests: []Request{
+			{Id: "jr1", UserId: "u1", Locations: []string{"club-a", "club-b", "club-c"}, TimeSlots: []time.Time{monday, tuesday}},
+		},
+		BusyTimes: map[string][]Interval{
+			// ends exactly when the monday session starts, so it is not a conflict
+			"u1": {{Start: monday.Add(-time.Hour), End: monday}},
+			// the host is busy on tuesday
+			"host": {{Start: tuesday.Add(-30 * time.Minute), End: tuesday.Add(30 * time.Minute)}},
+		},
+		Price: func(locationId string, start time.Time) (float64, bool) {
+			price, ok := prices[locationId]
+			return price, ok
+		},
+	})
+
+	require.Len(t, candidates, 6)
+	got := []string{}
+	for _, c := range candidates {
+		got = append(got, c.LocationId+"@"+c.DateTime.Weekday().String())
+	}
+	assert.Equal(t, []string{
+		"club-b@Monday", "club-a@Monday", "club-c@Monday",
+		"club-b@Tuesday", "club-a@Tuesday", "club-c@Tuesday",
+	}, got)
+	assert.Equal(t, []string{"host"}, candidates[3].BusyUserIds)
+	assert.True(t, candidates[0].HasPrice)
+	assert.Equal(t, 20.0, candidates[0].Price)
+	assert.False(t, candidates[2].HasPrice)
+}
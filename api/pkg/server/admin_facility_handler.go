@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/places"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const (
+	maxDuplicateRadiusM = 5000
+	// sameSpotM is how close facilities are listed as duplicates whatever their names
+	sameSpotM = 30
+)
+
+func (r *Router) adminCreateFacilityHandler(c *gin.Context, req *api.AdminCreateFacilityRequest) (*api.AdminFacilityDetails, error) {
+	ctx := context.Background()
+	userId := c.GetString(auth.USER_ID_CONTEXT_KEY)
+
+	if err := validateFacilityData(&req.AdminFacilityData); err != nil {
+		return nil, err
+	}
+
+	facilityId := uuid.New().String()
+	logCtx := slog.With("userId", userId, "facilityId", facilityId)
+	if err := r.db.CreateFacility(ctx, userId, facilityId, &req.AdminFacilityData); err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to create facility")
+	}
+	logCtx.Info("Facility created", "name", req.Name)
+
+	details, err := r.db.GetFacilityAdminDetails(ctx, facilityId)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get facility")
+	}
+	return details, nil
+}
+
+func (r *Router) adminGetFacilityHandler(c *gin.Context, req *api.AdminGetFacilityRequest) (*api.AdminFacilityDetails, error) {
+	ctx := context.Background()
+
+	details, err := r.db.GetFacilityAdminDetails(ctx, req.FacilityID)
+	if err != nil {
+		return nil, adminFacilityError(slog.With("facilityId", req.FacilityID), err, "Failed to get facility")
+	}
+	return details, nil
+}
+
+func (r *Router) adminUpdateFacilityDetailsHandler(c *gin.Context, req *api.AdminUpdateFacilityDetailsRequest) (*api.AdminFacilityDetails, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "facilityId", req.FacilityID)
+
+	if err := validateFacilityData(&req.AdminFacilityData); err != nil {
+		return nil, err
+	}
+
+	if err := r.db.UpdateFacility(ctx, req.FacilityID, &req.AdminFacilityData); err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to update facility")
+	}
+	logCtx.Info("Facility updated")
+
+	details, err := r.db.GetFacilityAdminDetails(ctx, req.FacilityID)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get facility")
+	}
+	return details, nil
+}
+
+func (r *Router) adminCreateCourtGroupHandler(c *gin.Context, req *api.AdminCreateCourtGroupRequest) (*api.CourtGroup, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "facilityId", req.FacilityID)
+
+	if err := validateCourtGroupData(&req.AdminCourtGroupData); err != nil {
+		return nil, err
+	}
+
+	courtGroupId, err := r.db.CreateCourtGroup(ctx, req.FacilityID, &req.AdminCourtGroupData)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to create court group")
+	}
+	logCtx.Info("Court group created", "courtGroupId", courtGroupId)
+
+	group, err := r.db.GetCourtGroup(ctx, courtGroupId)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get court group")
+	}
+	return group, nil
+}
+
+func (r *Router) adminUpdateCourtGroupHandler(c *gin.Context, req *api.AdminUpdateCourtGroupRequest) (*api.CourtGroup, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "courtGroupId", req.CourtGroupId)
+
+	if err := validateCourtGroupData(&req.AdminCourtGroupData); err != nil {
+		return nil, err
+	}
+
+	if err := r.db.UpdateCourtGroup(ctx, req.CourtGroupId, &req.AdminCourtGroupData); err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to update court group")
+	}
+	logCtx.Info("Court group updated")
+
+	group, err := r.db.GetCourtGroup(ctx, req.CourtGroupId)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get court group")
+	}
+	return group, nil
+}
+
+func (r *Router) adminDeleteCourtGroupHandler(c *gin.Context, req *api.AdminDeleteCourtGroupRequest) error {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "courtGroupId", req.CourtGroupId)
+
+	if err := r.db.DeleteCourtGroup(ctx, req.CourtGroupId); err != nil {
+		return adminFacilityError(logCtx, err, "Failed to delete court group")
+	}
+	logCtx.Info("Court group deleted")
+	return nil
+}
+
+func (r *Router) adminCreatePricePeriodHandler(c *gin.Context, req *api.AdminCreatePricePeriodRequest) (*api.AdminPricePeriod, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "courtGroupId", req.CourtGroupId)
+
+	period, err := pricePeriodFromRequest(&req.AdminPricePeriodData)
+	if err != nil {
+		return nil, err
+	}
+
+	pricePeriodId, err := r.db.CreatePricePeriod(ctx, req.CourtGroupId, period)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to create price period")
+	}
+	logCtx.Info("Price period created", "pricePeriodId", pricePeriodId)
+
+	created, err := r.db.GetPricePeriod(ctx, pricePeriodId)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get price period")
+	}
+	return created, nil
+}
+
+func (r *Router) adminUpdatePricePeriodHandler(c *gin.Context, req *api.AdminUpdatePricePeriodRequest) (*api.AdminPricePeriod, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "pricePeriodId", req.PricePeriodId)
+
+	period, err := pricePeriodFromRequest(&req.AdminPricePeriodData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.UpdatePricePeriod(ctx, req.PricePeriodId, period); err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to update price period")
+	}
+	logCtx.Info("Price period updated")
+
+	updated, err := r.db.GetPricePeriod(ctx, req.PricePeriodId)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get price period")
+	}
+	return updated, nil
+}
+
+func (r *Router) adminDeletePricePeriodHandler(c *gin.Context, req *api.AdminDeletePricePeriodRequest) error {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "pricePeriodId", req.PricePeriodId)
+
+	if err := r.db.DeletePricePeriod(ctx, req.PricePeriodId); err != nil {
+		return adminFacilityError(logCtx, err, "Failed to delete price period")
+	}
+	logCtx.Info("Price period deleted")
+	return nil
+}
+
+func (r *Router) adminListFacilityDuplicatesHandler(c *gin.Context, req *api.AdminListFacilityDuplicatesRequest) (*api.AdminListFacilityDuplicatesResponse, error) {
+	ctx := context.Background()
+
+	if req.RadiusM <= 0 || req.RadiusM > maxDuplicateRadiusM {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  fmt.Sprintf("Radius must be between 0 and %d meters", maxDuplicateRadiusM),
+		}
+	}
+
+	pairs, err := r.db.GetNearbyFacilityPairs(ctx, req.RadiusM)
+	if err != nil {
+		return nil, adminFacilityError(slog.Default(), err, "Failed to get facility duplicates")
+	}
+	return &api.AdminListFacilityDuplicatesResponse{Duplicates: facilityDuplicates(pairs, req.MinSimilarity)}, nil
+}
+
+func (r *Router) adminMergeFacilityHandler(c *gin.Context, req *api.AdminMergeFacilityRequest) (*api.AdminFacilityDetails, error) {
+	ctx := context.Background()
+	logCtx := slog.With("userId", c.GetString(auth.USER_ID_CONTEXT_KEY), "facilityId", req.FacilityID, "duplicateId", req.DuplicateID)
+
+	if err := r.db.MergeFacilities(ctx, req.FacilityID, req.DuplicateID); err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to merge facilities")
+	}
+	logCtx.Info("Facilities merged")
+
+	details, err := r.db.GetFacilityAdminDetails(ctx, req.FacilityID)
+	if err != nil {
+		return nil, adminFacilityError(logCtx, err, "Failed to get facility")
+	}
+	return details, nil
+}
+
+// facilityDuplicates picks the probable duplicates among nearby facilities: similar names or the same spot.
+// The facility to keep comes first, the most similar pairs are listed first.
+func facilityDuplicates(pairs []db.FacilityPair, minSimilarity float64) []api.AdminFacilityDuplicate {
+	duplicates := []api.AdminFacilityDuplicate{}
+	for _, pair := range pairs {
+		similarity := places.NameSimilarity(pair.First.Name, pair.Second.Name)
+		if similarity < minSimilarity && pair.DistanceM > sameSpotM {
+			continue
+		}
+
+		facility, duplicate := pair.First, pair.Second
+		if !keepFirst(facility, duplicate) {
+			facility, duplicate = duplicate, facility
+		}
+		duplicates = append(duplicates, api.AdminFacilityDuplicate{
+			Facility:       facility,
+			Duplicate:      duplicate,
+			DistanceM:      math.Round(pair.DistanceM),
+			NameSimilarity: math.Round(similarity*100) / 100,
+		})
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].NameSimilarity > duplicates[j].NameSimilarity
+	})
+	return duplicates
+}
+
+// keepFirst tells whether facility a is worth keeping over b: curated facilities win over user suggested ones, then
+// the ones with a place id, then the ones added first
+func keepFirst(a, b api.AdminFacility) bool {
+	if curatedA, curatedB := a.Source != "user", b.Source != "user"; curatedA != curatedB {
+		return curatedA
+	}
+	if placeA, placeB := a.PlaceID != "", b.PlaceID != ""; placeA != placeB {
+		return placeA
+	}
+	return a.CreatedAt <= b.CreatedAt
+}
+
+func validateFacilityData(data *api.AdminFacilityData) error {
+	if err := api.NormalizeFacilityData(data); err != nil {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  err.Error(),
+		}
+	}
+	return nil
+}
+
+func validateCourtGroupData(data *api.AdminCourtGroupData) error {
+	if err := api.NormalizeCourtGroupData(data); err != nil {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  err.Error(),
+		}
+	}
+	return nil
+}
+
+// pricePeriodFromRequest parses the period and checks its rules, overlaps with other periods are checked by the db
+func pricePeriodFromRequest(data *api.AdminPricePeriodData) (*pricing.Period, error) {
+	validFrom, err := time.Parse(time.DateOnly, data.ValidFrom)
+	if err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid validFrom date format, expected YYYY-MM-DD",
+		}
+	}
+	validTo, err := time.Parse(time.DateOnly, data.ValidTo)
+	if err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid validTo date format, expected YYYY-MM-DD",
+		}
+	}
+
+	period := &pricing.Period{ValidFrom: validFrom, ValidTo: validTo}
+	for _, r := range data.Rules {
+		start, err := pricing.ParseClock(r.Start)
+		if err != nil {
+			return nil, HttpError{HttpCode: http.StatusBadRequest, Message: err.Error()}
+		}
+		end, err := pricing.ParseClock(r.End)
+		if err != nil {
+			return nil, HttpError{HttpCode: http.StatusBadRequest, Message: err.Error()}
+		}
+		period.Rules = append(period.Rules, pricing.Rule{
+			DayPattern: r.DayPattern,
+			Start:      start,
+			End:        end,
+			Price:      r.Price,
+		})
+	}
+
+	if err := pricing.ValidateRules(period.Rules); err != nil {
+		return nil, HttpError{HttpCode: http.StatusBadRequest, Message: err.Error()}
+	}
+	if err := pricing.ValidatePeriod(*period, nil); err != nil {
+		return nil, HttpError{HttpCode: http.StatusBadRequest, Message: err.Error()}
+	}
+	return period, nil
+}
+
+func adminFacilityError(logCtx *slog.Logger, err error, message string) error {
+	if e, ok := err.(db.DbObjectNotFoundError); ok {
+		return HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  e.Message,
+		}
+	}
+	if e, ok := err.(*db.ValidationError); ok {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  e.Message,
+		}
+	}
+	logCtx.Error(message, "error", err)
+	return HttpError{
+		HttpCode: http.StatusInternalServerError,
+		Message:  message,
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+)
+
+func Test_validateCourtGroupData(t *testing.T) {
+	data := &api.AdminCourtGroupData{Surface: api.CourtSurfaceClay, Type: "tent", CourtNames: []string{" 1", "2 "}}
+	assert.NoError(t, validateCourtGroupData(data))
+	assert.Equal(t, []string{"1", "2"}, data.CourtNames)
+
+	assert.Error(t, validateCourtGroupData(&api.AdminCourtGroupData{Surface: "ice", Type: "tent"}))
+	assert.Error(t, validateCourtGroupData(&api.AdminCourtGroupData{Surface: api.CourtSurfaceClay, Type: "baloon"}))
+	assert.Error(t, validateCourtGroupData(&api.AdminCourtGroupData{Surface: api.CourtSurfaceClay, Type: "tent", CourtNames: []string{"1,2"}}))
+	assert.Error(t, validateCourtGroupData(&api.AdminCourtGroupData{Surface: api.CourtSurfaceClay, Type: "tent", ReservationLink: "javascript:alert(1)"}))
+}
+
+func Test_pricePeriodFromRequest(t *testing.T) {
+	period, err := pricePeriodFromRequest(&api.AdminPricePeriodData{
+		ValidFrom: "2026-05-01",
+		ValidTo:   "2026-09-30",
+		Rules: []api.AdminPriceRule{
+			{DayPattern: "*", Start: "07:00", End: "15:00", Price: 60},
+			{DayPattern: "!", Start: "23:00", End: "06:00", Price: 50},
+		},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 7*60, period.Rules[0].Start)
+		assert.Equal(t, 6*60, period.Rules[1].End)
+	}
+
+	tests := map[string]*api.AdminPricePeriodData{
+		"invalid date": {ValidFrom: "2026-5-1", ValidTo: "2026-09-30", Rules: []api.AdminPriceRule{{DayPattern: "*", Start: "07:00", End: "15:00"}}},
+		"reversed":     {ValidFrom: "2026-09-30", ValidTo: "2026-05-01", Rules: []api.AdminPriceRule{{DayPattern: "*", Start: "07:00", End: "15:00"}}},
+		"invalid time": {ValidFrom: "2026-05-01", ValidTo: "2026-09-30", Rules: []api.AdminPriceRule{{DayPattern: "*", Start: "7am", End: "15:00"}}},
+		"no rules":     {ValidFrom: "2026-05-01", ValidTo: "2026-09-30"},
+	}
+	for name, data := range tests {
+		_, err := pricePeriodFromRequest(data)
+		assert.Error(t, err, name)
+	}
+}
+
+func Test_validateFacilityData(t *testing.T) {
+	data := &api.AdminFacilityData{
+		Name:        " Club ",
+		Address:     "Street 1",
+		Country:     "pl",
+		Coordinates: api.Coordinates{Latitude: 51.1, Longitude: 17.03},
+	}
+	assert.NoError(t, validateFacilityData(data))
+	assert.Equal(t, "Club", data.Name)
+	assert.Equal(t, "PL", data.Country)
+
+	data.Coordinates = api.Coordinates{}
+	assert.Error(t, validateFacilityData(data))
+}
+
+func Test_facilityDuplicates(t *testing.T) {
+	seeded := api.AdminFacility{Location: api.Location{ID: "seeded", Name: "Matchpoint"}, Source: "seed", CreatedAt: "2024-01-01T00:00:00Z"}
+	suggested := api.AdminFacility{Location: api.Location{ID: "suggested", Name: "Match Point Wrocław"}, Source: "user", PlaceProvider: "google", PlaceID: "place_1", CreatedAt: "2023-01-01T00:00:00Z"}
+	other := api.AdminFacility{Location: api.Location{ID: "other", Name: "Park Szczytnicki"}, Source: "seed", CreatedAt: "2024-01-01T00:00:00Z"}
+	sameSpot := api.AdminFacility{Location: api.Location{ID: "same-spot", Name: "Korty Miejskie"}, Source: "user", CreatedAt: "2025-01-01T00:00:00Z"}
+
+	duplicates := facilityDuplicates([]db.FacilityPair{
+		{First: suggested, Second: seeded, DistanceM: 40.4},
+		{First: seeded, Second: other, DistanceM: 120},
+		{First: other, Second: sameSpot, DistanceM: 12},
+	}, 0.5)
+
+	if assert.Len(t, duplicates, 2) {
+		assert.Equal(t, "seeded", duplicates[0].Facility.ID, "seeded facility is kept")
+		assert.Equal(t, "suggested", duplicates[0].Duplicate.ID)
+		assert.Equal(t, 40.0, duplicates[0].DistanceM)
+		assert.Greater(t, duplicates[0].NameSimilarity, 0.5)
+
+		assert.Equal(t, "other", duplicates[1].Facility.ID, "facilities at the same spot are listed whatever their names")
+		assert.Equal(t, "same-spot", duplicates[1].Duplicate.ID)
+	}
+}
+
+func Test_keepFirst(t *testing.T) {
+	older := api.AdminFacility{Source: "user", CreatedAt: "2024-01-01T00:00:00Z"}
+	newer := api.AdminFacility{Source: "user", CreatedAt: "2025-01-01T00:00:00Z"}
+	assert.True(t, keepFirst(older, newer))
+	assert.False(t, keepFirst(newer, older))
+
+	newer.PlaceProvider = "google"
+	newer.PlaceID = "place_1"
+	assert.True(t, keepFirst(newer, older), "facility with a place id is kept")
+
+	older.Source = "admin"
+	assert.True(t, keepFirst(older, newer), "curated facility is kept")
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -12,20 +13,53 @@ const USER_ID_CONTEXT_KEY = "userId"
 
 // CreateAuthMiddleware creates an auth middleware based on the auth config
 func CreateAuthMiddleware(authConfig pkg.AuthConfig) gin.HandlerFunc {
+	return createAuthMiddleware(authConfig, false)
+}
+
+// CreateOptionalAuthMiddleware creates an auth middleware that sets the user id when the request is authenticated
+// and lets anonymous requests through
+func CreateOptionalAuthMiddleware(authConfig pkg.AuthConfig) gin.HandlerFunc {
+	return createAuthMiddleware(authConfig, true)
+}
+
+// RoleDb defines the database operations needed by the admin middleware
+type RoleDb interface {
+	GetUserRole(ctx context.Context, userId string) (string, error)
+}
+
+// CreateAdminMiddleware creates a middleware hiding endpoints from users without the admin role,
+// it runs after the auth middleware
+func CreateAdminMiddleware(roles RoleDb) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userId := c.GetString(USER_ID_CONTEXT_KEY); userId != "" {
+			role, err := roles.GetUserRole(c.Request.Context(), userId)
+			if err != nil {
+				slog.Warn("Failed to get user role", "error", err, "userId", userId)
+			} else if role == "admin" {
+				c.Next()
+				return
+			}
+		}
+		c.Abort()
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	}
+}
+
+func createAuthMiddleware(authConfig pkg.AuthConfig, optional bool) gin.HandlerFunc {
 
 	if authConfig.Type == "clerk" {
-		return CreateClerkAuthMiddleware(authConfig.Config)
+		return CreateClerkAuthMiddleware(authConfig.Config, optional)
 	}
 
 	if authConfig.Type == "debug" {
 		return func(c *gin.Context) {
 			slog.Debug("!!!! Debug auth middleware")
 			userId := c.GetHeader("Authentication")
-			if userId == "" {
+			if userId == "" && !optional {
 				c.Abort()
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 				return
-			} else {
+			} else if userId != "" {
 				c.Set(USER_ID_CONTEXT_KEY, userId)
 			}
 			c.Next()
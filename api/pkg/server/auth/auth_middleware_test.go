@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg"
+)
+
+func Test_DebugAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authConfig := pkg.AuthConfig{Type: "debug"}
+
+	request := func(middleware gin.HandlerFunc, userId string) (int, string) {
+		engine := gin.New()
+		engine.GET("/", middleware, func(c *gin.Context) {
+			c.String(http.StatusOK, c.GetString(USER_ID_CONTEXT_KEY))
+		})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if userId != "" {
+			req.Header.Set("Authentication", userId)
+		}
+		engine.ServeHTTP(w, req)
+		return w.Code, w.Body.String()
+	}
+
+	code, body := request(CreateAuthMiddleware(authConfig), "user-1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "user-1", body)
+
+	code, _ = request(CreateAuthMiddleware(authConfig), "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, body = request(CreateOptionalAuthMiddleware(authConfig), "")
+	assert.Equal(t, http.StatusOK, code, "anonymous requests are let through")
+	assert.Empty(t, body)
+
+	_, body = request(CreateOptionalAuthMiddleware(authConfig), "user-1")
+	assert.Equal(t, "user-1", body)
+}
+
+type fakeRoleDb map[string]string
+
+func (f fakeRoleDb) GetUserRole(ctx context.Context, userId string) (string, error) {
+	role, ok := f[userId]
+	if !ok {
+		return "", errors.New("user not found")
+	}
+	return role, nil
+}
+
+func Test_AdminMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authConfig := pkg.AuthConfig{Type: "debug"}
+	roles := fakeRoleDb{"admin-1": "admin", "user-1": "user"}
+
+	request := func(userId string) int {
+		engine := gin.New()
+		engine.GET("/", CreateOptionalAuthMiddleware(authConfig), CreateAdminMiddleware(roles), func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if userId != "" {
+			req.Header.Set("Authentication", userId)
+		}
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("admin-1"))
+	assert.Equal(t, http.StatusNotFound, request("user-1"), "admin endpoints are hidden from other users")
+	assert.Equal(t, http.StatusNotFound, request("unknown-user"))
+	assert.Equal(t, http.StatusNotFound, request(""))
+}
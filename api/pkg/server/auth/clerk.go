@@ -35,7 +35,9 @@ func NewJWKStore() JWKStore {
 	return &jwkStore{}
 }
 
-func CreateClerkAuthMiddleware(clerkConfig string) func(c *gin.Context) {
+// CreateClerkAuthMiddleware verifies the Clerk session token. Optional middleware lets requests without a valid
+// token through as anonymous instead of rejecting them.
+func CreateClerkAuthMiddleware(clerkConfig string, optional bool) func(c *gin.Context) {
 
 	config := &clerk.ClientConfig{}
 
@@ -46,7 +48,19 @@ func CreateClerkAuthMiddleware(clerkConfig string) func(c *gin.Context) {
 	jwkStore := NewJWKStore()
 
 	return func(c *gin.Context) {
+		reject := func(status int, message string) {
+			if optional {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(status, api.ErrorResponse{Error: message})
+		}
+
 		sessionToken := strings.TrimPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+		if optional && sessionToken == "" {
+			c.Next()
+			return
+		}
 
 		// Attempt to get the JSON Web Key from your store.
 		jwk := jwkStore.GetJWK()
@@ -57,7 +71,7 @@ func CreateClerkAuthMiddleware(clerkConfig string) func(c *gin.Context) {
 			})
 			if err != nil {
 				slog.Error("Error decoding JWT", "error", err)
-				c.AbortWithStatusJSON(http.StatusForbidden, api.ErrorResponse{Error: "Unauthorized"})
+				reject(http.StatusForbidden, "Unauthorized")
 				return
 			}
 
@@ -68,7 +82,7 @@ func CreateClerkAuthMiddleware(clerkConfig string) func(c *gin.Context) {
 			})
 			if err != nil {
 				slog.Error("Error fetching JWK", "error", err)
-				c.AbortWithStatusJSON(http.StatusInternalServerError, api.ErrorResponse{Error: "Error fetching JWK"})
+				reject(http.StatusInternalServerError, "Error fetching JWK")
 				return
 			}
 		}
@@ -85,14 +99,14 @@ func CreateClerkAuthMiddleware(clerkConfig string) func(c *gin.Context) {
 		})
 		if err != nil {
 			slog.Error("Error verifying JWT", "error", err)
-			c.AbortWithStatusJSON(http.StatusForbidden, api.ErrorResponse{Error: "Unauthorized"})
+			reject(http.StatusForbidden, "Unauthorized")
 			return
 		}
 
 		usr, err := userClient.Get(c.Request.Context(), claims.Subject)
 		if err != nil {
 			slog.Error("Error getting user", "error", err)
-			c.AbortWithStatusJSON(http.StatusForbidden, api.ErrorResponse{Error: "Unauthorized"})
+			reject(http.StatusForbidden, "Unauthorized")
 			return
 		}
 		c.Set("user", usr)
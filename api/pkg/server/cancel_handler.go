@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const maxCancelReasonLength = 500
+
+func (r *Router) cancelEventHandler(c *gin.Context, req *api.CancelEventRequest) (*api.CancelEventResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	if err := validateCancelReason(req.Reason); err != nil {
+		return nil, err
+	}
+
+	event, err := r.db.GetMyEvent(context.Background(), userId.(string), req.EventId)
+	if err != nil {
+		logCtx.Error("Failed to get my event for cancellation", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	cancelled, err := r.db.CancelEvents(context.Background(), userId.(string), []string{event.Id}, req.Reason)
+	if err != nil {
+		logCtx.Error("Failed to cancel event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to cancel event",
+		}
+	}
+
+	if len(cancelled) == 0 {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Cannot cancel " + string(event.Status) + " event",
+		}
+	}
+
+	for _, cancelledEvent := range cancelled {
+		r.releaseCourt(cancelledEvent.Booking)
+	}
+	go r.notifier.EventsCancelled(userId.(string), "", cancelled, req.Reason, event.Status == api.EventStatusConfirmed)
+
+	event, err = r.db.GetMyEvent(context.Background(), userId.(string), req.EventId)
+	if err != nil || event == nil {
+		logCtx.Error("Failed to get cancelled event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	return &api.CancelEventResponse{
+		Event: event,
+	}, nil
+}
+
+func validateCancelReason(reason string) error {
+	if utf8.RuneCountInString(reason) > maxCancelReasonLength {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Cancellation reason is too long",
+		}
+	}
+	return nil
+}
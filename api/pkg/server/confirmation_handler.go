@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/holidays"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+	"github.com/xtp-tour/xtp-tour/api/pkg/scheduling"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const (
+	defaultCandidatesLimit = 10
+	maxCandidatesLimit     = 100
+)
+
+// getConfirmationCandidatesHandler ranks location and time slot combinations the host can confirm the event with
+func (r *Router) getConfirmationCandidatesHandler(c *gin.Context, req *api.ConfirmationCandidatesRequest) (*api.ConfirmationCandidatesResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+	ctx := context.Background()
+
+	event, err := r.db.GetMyEvent(ctx, userId.(string), req.EventId)
+	if err != nil {
+		logCtx.Error("Failed to get my event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if event.Status != api.EventStatusOpen {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Only open events can be confirmed",
+		}
+	}
+
+	joinRequests, err := r.db.GetJoinRequests(ctx, event.Id)
+	if err != nil {
+		logCtx.Error("Failed to get join requests", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get join requests",
+		}
+	}
+
+	duration := time.Duration(event.SessionDuration) * time.Minute
+	priceLists := map[string]*pricing.PriceList{} // by location id, nil when unavailable
+	input := scheduling.Event{
+		HostUserId: event.UserId,
+		Locations:  event.Locations,
+		Duration:   duration,
+		Capacity:   event.ExpectedPlayers - 1,
+		BusyTimes:  map[string][]scheduling.Interval{},
+		Price: func(locationId string, start time.Time) (float64, bool) {
+			priceList, ok := priceLists[locationId]
+			if !ok {
+				var err error
+				priceList, err = r.getPriceList(ctx, logCtx, locationId)
+				if err != nil {
+					logCtx.Warn("Failed to get price list", "error", err, "locationId", locationId)
+				}
+				priceLists[locationId] = priceList
+			}
+			if priceList == nil {
+				return 0, false
+			}
+			return priceList.Cheapest(start, duration)
+		},
+	}
+
+	// slots already in the past can no longer be played
+	now := time.Now().UTC()
+	for _, slot := range event.TimeSlots {
+		if dt := api.ParseDt(slot); dt.After(now) {
+			input.TimeSlots = append(input.TimeSlots, dt)
+		}
+	}
+
+	userIds := []string{event.UserId}
+	for _, jr := range joinRequests[event.Id] {
+		switch jr.Status {
+		case api.JoinRequestStatusWaiting, api.JoinRequestStatusAccepted, api.JoinRequestStatusWaitlisted:
+		default:
+			continue
+		}
+		input.Requests = append(input.Requests, scheduling.Request{
+			Id:        jr.Id,
+			UserId:    jr.UserId,
+			Locations: jr.Locations,
+			TimeSlots: api.DtFromIsoArray(jr.TimeSlots),
+			Accepted:  jr.Status == api.JoinRequestStatusAccepted,
+			CreatedAt: api.ParseDt(jr.CreatedAt),
+		})
+		userIds = append(userIds, jr.UserId)
+	}
+
+	if len(input.TimeSlots) > 0 {
+		from, to := input.TimeSlots[0], input.TimeSlots[0]
+		for _, slot := range input.TimeSlots {
+			if slot.Before(from) {
+				from = slot
+			}
+			if slot.After(to) {
+				to = slot
+			}
+		}
+		for _, id := range userIds {
+			busyTimes, err := r.db.GetCachedBusyTimes(ctx, id, from, to.Add(duration))
+			if err != nil {
+				logCtx.Warn("Failed to get cached busy times", "error", err, "busyUserId", id)
+				continue
+			}
+			for _, busy := range busyTimes {
+				input.BusyTimes[id] = append(input.BusyTimes[id], scheduling.Interval{Start: busy.StartTime, End: busy.EndTime})
+			}
+		}
+	}
+
+	limit := candidatesLimit(req.Limit)
+	candidates := scheduling.Solve(input)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	resp := &api.ConfirmationCandidatesResponse{
+		Candidates: make([]*api.ConfirmationCandidate, len(candidates)),
+	}
+	holidayCalendars := map[string]*holidays.CountryCalendar{} // by country
+	for i, candidate := range candidates {
+		resp.Candidates[i] = &api.ConfirmationCandidate{
+			LocationId:      candidate.LocationId,
+			DateTime:        api.DtToIso(candidate.DateTime),
+			JoinRequestsIds: candidate.JoinRequestIds,
+			Players:         len(candidate.JoinRequestIds) + 1,
+			Complete:        candidate.Complete(input.Capacity),
+			BusyUserIds:     candidate.BusyUserIds,
+		}
+		if candidate.HasPrice {
+			price := candidate.Price
+			resp.Candidates[i].Price = &price
+		}
+		if priceList := priceLists[candidate.LocationId]; priceList != nil {
+			countryHolidays, ok := holidayCalendars[priceList.Country]
+			if !ok {
+				countryHolidays = r.countryHolidays(ctx, logCtx, priceList.Country)
+				holidayCalendars[priceList.Country] = countryHolidays
+			}
+			resp.Candidates[i].Holiday, _ = countryHolidays.Name(candidate.DateTime.In(priceList.Location))
+		}
+	}
+
+	return resp, nil
+}
+
+// candidatesLimit returns the default limit when none is requested and caps larger ones at the maximum
+func candidatesLimit(limit int) int {
+	if limit <= 0 {
+		return defaultCandidatesLimit
+	}
+	return min(limit, maxCandidatesLimit)
+}
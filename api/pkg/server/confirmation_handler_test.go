@@ -0,0 +1,15 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_candidatesLimit(t *testing.T) {
+	assert.Equal(t, defaultCandidatesLimit, candidatesLimit(0))
+	assert.Equal(t, defaultCandidatesLimit, candidatesLimit(-5))
+	assert.Equal(t, 25, candidatesLimit(25))
+	assert.Equal(t, maxCandidatesLimit, candidatesLimit(maxCandidatesLimit))
+	assert.Equal(t, maxCandidatesLimit, candidatesLimit(1000))
+}
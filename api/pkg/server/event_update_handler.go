@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+func (r *Router) updateEventHandler(c *gin.Context, req *api.UpdateEventRequest) (*api.UpdateEventResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	if err := validateUpdateEventData(&req.Event); err != nil {
+		return nil, err
+	}
+
+	updates, promotions, err := r.db.UpdateEvent(context.Background(), userId.(string), req.EventId, &req.Event)
+	if err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Event not found",
+			}
+		}
+		if validationErr, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  validationErr.Message,
+			}
+		}
+
+		logCtx.Error("Failed to update event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to update event",
+		}
+	}
+
+	if len(updates) > 0 {
+		go r.notifier.EventUpdated(userId.(string), req.EventId, updates)
+	}
+	for _, promotion := range promotions {
+		go r.notifier.WaitlistPromoted(*promotion)
+	}
+
+	event, err := r.db.GetMyEvent(context.Background(), userId.(string), req.EventId)
+	if err != nil || event == nil {
+		logCtx.Error("Failed to get updated event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	joinRequests, err := r.db.GetJoinRequests(context.Background(), event.Id)
+	if err != nil {
+		logCtx.Error("Failed to get join requests", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get join requests",
+		}
+	}
+	event.JoinRequests = joinRequests[event.Id]
+
+	return &api.UpdateEventResponse{
+		Event: event,
+	}, nil
+}
+
+func validateUpdateEventData(data *api.UpdateEventData) error {
+	switch data.SkillLevel {
+	case api.SkillLevelAny, api.SkillLevelBeginner, api.SkillLevelIntermediate, api.SkillLevelAdvanced:
+	default:
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid skill level",
+		}
+	}
+
+	var earliest time.Time
+	for _, slot := range data.TimeSlots {
+		dt, err := api.TryParseDt(slot)
+		if err != nil {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid time slot: " + slot,
+			}
+		}
+		if earliest.IsZero() || dt.Before(earliest) {
+			earliest = dt
+		}
+	}
+
+	if data.ExpirationTime != "" {
+		expiration, err := api.TryParseDt(data.ExpirationTime)
+		if err != nil {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid expiration time",
+			}
+		}
+		if !expiration.After(time.Now()) || expiration.After(earliest) {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Expiration time must be in the future and not after the earliest time slot",
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+func (r *Router) listFavouriteFacilitiesHandler(c *gin.Context, req *api.ListFavouriteFacilitiesRequest) (*api.FavouriteFacilitiesResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	return r.favouriteFacilities(slog.With("userId", userId), userId.(string))
+}
+
+func (r *Router) addFavouriteFacilityHandler(c *gin.Context, req *api.FavouriteFacilityRequest) (*api.FavouriteFacilitiesResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "facilityId", req.FacilityId)
+
+	if err := r.db.AddFavouriteFacility(context.Background(), userId.(string), req.FacilityId); err != nil {
+		return nil, favouriteFacilityError(logCtx, err, "Failed to add favourite facility")
+	}
+
+	return r.favouriteFacilities(logCtx, userId.(string))
+}
+
+func (r *Router) removeFavouriteFacilityHandler(c *gin.Context, req *api.FavouriteFacilityRequest) (*api.FavouriteFacilitiesResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "facilityId", req.FacilityId)
+
+	if err := r.db.RemoveFavouriteFacility(context.Background(), userId.(string), req.FacilityId); err != nil {
+		return nil, favouriteFacilityError(logCtx, err, "Failed to remove favourite facility")
+	}
+
+	return r.favouriteFacilities(logCtx, userId.(string))
+}
+
+func (r *Router) setHomeFacilityHandler(c *gin.Context, req *api.SetHomeFacilityRequest) (*api.FavouriteFacilitiesResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	facilityId := strings.TrimSpace(req.FacilityId)
+	logCtx := slog.With("userId", userId, "facilityId", facilityId)
+
+	if err := r.db.SetHomeFacility(context.Background(), userId.(string), facilityId); err != nil {
+		return nil, favouriteFacilityError(logCtx, err, "Failed to set home facility")
+	}
+
+	return r.favouriteFacilities(logCtx, userId.(string))
+}
+
+func (r *Router) favouriteFacilities(logCtx *slog.Logger, userId string) (*api.FavouriteFacilitiesResponse, error) {
+	homeFacilityId, favourites, err := r.db.GetFavouriteFacilities(context.Background(), userId)
+	if err != nil {
+		logCtx.Error("Failed to get favourite facilities", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get favourite facilities",
+		}
+	}
+
+	return &api.FavouriteFacilitiesResponse{
+		HomeFacilityId: homeFacilityId,
+		Favourites:     favourites,
+	}, nil
+}
+
+// favouriteFacilityError maps errors of favourite facility changes to HTTP errors
+func favouriteFacilityError(logCtx *slog.Logger, err error, message string) error {
+	if e, ok := err.(db.DbObjectNotFoundError); ok {
+		return HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  e.Message,
+		}
+	}
+	logCtx.Error(message, "error", err)
+	return HttpError{
+		HttpCode: http.StatusInternalServerError,
+		Message:  message,
+	}
+}
+
+// defaultEventLocations sets the locations of an event created without them to the favourite facilities of the host
+func (r *Router) defaultEventLocations(logCtx *slog.Logger, event *api.EventData) error {
+	if len(event.Locations) > 0 {
+		return nil
+	}
+
+	_, favourites, err := r.db.GetFavouriteFacilities(context.Background(), event.UserId)
+	if err != nil {
+		logCtx.Error("Failed to get favourite facilities", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to create event",
+		}
+	}
+	if len(favourites) == 0 {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "At least one location is required, or favourite facilities to use by default",
+		}
+	}
+
+	for _, facility := range favourites {
+		event.Locations = append(event.Locations, facility.ID)
+	}
+	return nil
+}
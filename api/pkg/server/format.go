@@ -0,0 +1,106 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+// applyEventFormat defaults the format of a new event from the number of players, the same way existing events
+// were migrated, and checks the number of players fits it
+func applyEventFormat(event *api.EventData) error {
+	if event.Format == "" {
+		switch event.ExpectedPlayers {
+		case 2:
+			event.Format = api.SingleDoubleTypeSingle
+		case 4:
+			event.Format = api.SingleDoubleTypeDoubles
+		default:
+			event.Format = api.SingleDoubleTypeCustom
+		}
+	}
+	return validatePlayersForFormat(event.Format, event.ExpectedPlayers)
+}
+
+func validatePlayersForFormat(format api.SingleDoubleType, expectedPlayers int) error {
+	required := 0
+	switch format {
+	case api.SingleDoubleTypeSingle:
+		required = 2
+	case api.SingleDoubleTypeDoubles:
+		required = 4
+	case api.SingleDoubleTypeCustom:
+	default:
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid format",
+		}
+	}
+
+	if required != 0 && expectedPlayers != required {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  fmt.Sprintf("%s events need %d players", format, required),
+		}
+	}
+	return nil
+}
+
+// validateTeams checks that every player of the confirmed event is in exactly one team.
+// Doubles are played by two teams of two, custom events take any split into at least two teams.
+func validateTeams(format api.SingleDoubleType, teams [][]string, players []string) error {
+	if format == api.SingleDoubleTypeSingle {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Teams can only be set for DOUBLES and CUSTOM events",
+		}
+	}
+
+	if len(teams) < 2 {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "At least two teams are required",
+		}
+	}
+
+	assigned := []string{}
+	for _, team := range teams {
+		if len(team) == 0 {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Teams cannot be empty",
+			}
+		}
+		if format == api.SingleDoubleTypeDoubles && (len(teams) != 2 || len(team) != 2) {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Doubles are played by two teams of two players",
+			}
+		}
+		for _, userId := range team {
+			if !slices.Contains(players, userId) {
+				return HttpError{
+					HttpCode: http.StatusBadRequest,
+					Message:  fmt.Sprintf("User %s is not a player of the event", userId),
+				}
+			}
+			if slices.Contains(assigned, userId) {
+				return HttpError{
+					HttpCode: http.StatusBadRequest,
+					Message:  fmt.Sprintf("User %s is in more than one team", userId),
+				}
+			}
+			assigned = append(assigned, userId)
+		}
+	}
+
+	if len(assigned) != len(players) {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Every player must be in a team",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func Test_applyEventFormat(t *testing.T) {
+	singles := &api.EventData{ExpectedPlayers: 2}
+	assert.NoError(t, applyEventFormat(singles))
+	assert.Equal(t, api.SingleDoubleTypeSingle, singles.Format)
+
+	doubles := &api.EventData{ExpectedPlayers: 4}
+	assert.NoError(t, applyEventFormat(doubles))
+	assert.Equal(t, api.SingleDoubleTypeDoubles, doubles.Format)
+
+	group := &api.EventData{ExpectedPlayers: 6}
+	assert.NoError(t, applyEventFormat(group))
+	assert.Equal(t, api.SingleDoubleTypeCustom, group.Format)
+
+	assert.NoError(t, applyEventFormat(&api.EventData{ExpectedPlayers: 4, Format: api.SingleDoubleTypeDoubles}))
+	assert.Error(t, applyEventFormat(&api.EventData{ExpectedPlayers: 3, Format: api.SingleDoubleTypeDoubles}))
+	assert.Error(t, applyEventFormat(&api.EventData{ExpectedPlayers: 4, Format: api.SingleDoubleTypeSingle}))
+	assert.Error(t, applyEventFormat(&api.EventData{ExpectedPlayers: 2, Format: "TRIPLES"}))
+}
+
+func Test_validateTeams(t *testing.T) {
+	players := []string{"host", "p1", "p2", "p3"}
+
+	tests := []struct {
+		name    string
+		format  api.SingleDoubleType
+		teams   [][]string
+		wantErr bool
+	}{
+		{"doubles", api.SingleDoubleTypeDoubles, [][]string{{"host", "p2"}, {"p1", "p3"}}, false},
+		{"custom split", api.SingleDoubleTypeCustom, [][]string{{"host"}, {"p1", "p2", "p3"}}, false},
+		{"singles take no teams", api.SingleDoubleTypeSingle, [][]string{{"host"}, {"p1"}}, true},
+		{"one team", api.SingleDoubleTypeCustom, [][]string{players}, true},
+		{"uneven doubles", api.SingleDoubleTypeDoubles, [][]string{{"host"}, {"p1", "p2", "p3"}}, true},
+		{"missing player", api.SingleDoubleTypeDoubles, [][]string{{"host", "p2"}, {"p1"}}, true},
+		{"player twice", api.SingleDoubleTypeDoubles, [][]string{{"host", "p2"}, {"p2", "p3"}}, true},
+		{"not a player", api.SingleDoubleTypeDoubles, [][]string{{"host", "p2"}, {"p1", "stranger"}}, true},
+		{"empty team", api.SingleDoubleTypeCustom, [][]string{{"host", "p1", "p2", "p3"}, {}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTeams(tt.format, tt.teams, players)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/holidays"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const maxHolidaysRangeDays = 2 * 366
+
+// countryHolidays returns the holidays of the country with the admin overrides applied. When the overrides cannot be
+// loaded only the embedded holidays are used.
+func (r *Router) countryHolidays(ctx context.Context, logCtx *slog.Logger, country string) *holidays.CountryCalendar {
+	overrides, err := r.db.GetHolidayOverrides(ctx, country)
+	if err != nil {
+		logCtx.Warn("Failed to get holiday overrides, using embedded holidays only", "error", err, "country", country)
+	}
+	return r.holidays.Country(country, overrides)
+}
+
+// getLocationHolidaysHandler lists the public holidays in the country of the facility
+func (r *Router) getLocationHolidaysHandler(c *gin.Context, req *api.LocationHolidaysRequest) (*api.HolidaysResponse, error) {
+	logCtx := slog.With("locationId", req.LocationId)
+	ctx := context.Background()
+
+	country, err := r.db.GetFacilityCountry(ctx, req.LocationId)
+	if err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Location not found",
+			}
+		}
+		logCtx.Error("Failed to get facility country", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get holidays",
+		}
+	}
+
+	now := time.Now().In(pricing.TimeZone(country))
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.From != "" {
+		if from, err = time.Parse(time.DateOnly, req.From); err != nil {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid from date format, expected YYYY-MM-DD",
+			}
+		}
+	}
+	to := from.AddDate(1, 0, 0)
+	if req.To != "" {
+		if to, err = time.Parse(time.DateOnly, req.To); err != nil {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid to date format, expected YYYY-MM-DD",
+			}
+		}
+	}
+	if to.Before(from) || to.Sub(from) > maxHolidaysRangeDays*24*time.Hour {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "The to date must be after the from date and at most two years later",
+		}
+	}
+
+	countryHolidays := r.countryHolidays(ctx, logCtx, country)
+	return &api.HolidaysResponse{
+		Country:  countryHolidays.Country,
+		Holidays: toApiHolidays(countryHolidays.Between(from, to)),
+	}, nil
+}
+
+// adminListHolidaysHandler lists the holidays of the year in the country together with all its overrides
+func (r *Router) adminListHolidaysHandler(c *gin.Context, req *api.AdminListHolidaysRequest) (*api.AdminListHolidaysResponse, error) {
+	ctx := context.Background()
+	country, err := adminHolidayCountry(req.Country)
+	if err != nil {
+		return nil, err
+	}
+	logCtx := slog.With("country", country)
+
+	overrides, err := r.db.GetHolidayOverrides(ctx, country)
+	if err != nil {
+		logCtx.Error("Failed to get holiday overrides", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get holidays",
+		}
+	}
+
+	year := req.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	countryHolidays := r.holidays.Country(country, overrides)
+	resp := &api.AdminListHolidaysResponse{
+		Country:   countryHolidays.Country,
+		Holidays:  toApiHolidays(countryHolidays.Between(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC))),
+		Overrides: make([]api.HolidayOverride, len(overrides)),
+	}
+	for i, o := range overrides {
+		resp.Overrides[i] = api.HolidayOverride{Date: o.Date, Name: o.Name, IsHoliday: o.IsHoliday}
+	}
+	return resp, nil
+}
+
+// adminSetHolidayOverrideHandler adds a holiday missing from the embedded data or removes one from it
+func (r *Router) adminSetHolidayOverrideHandler(c *gin.Context, req *api.AdminSetHolidayOverrideRequest) error {
+	ctx := context.Background()
+	country, err := adminHolidayCountry(req.Country)
+	if err != nil {
+		return err
+	}
+	userId := c.GetString(auth.USER_ID_CONTEXT_KEY)
+	logCtx := slog.With("userId", userId, "country", country, "date", req.Date)
+
+	if _, err := time.Parse(time.DateOnly, req.Date); err != nil {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid date format, expected YYYY-MM-DD",
+		}
+	}
+	name := strings.TrimSpace(req.Name)
+	if req.IsHoliday && name == "" {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Holiday name is required",
+		}
+	}
+
+	err = r.db.SetHolidayOverride(ctx, userId, holidays.Override{
+		Country:   country,
+		Date:      req.Date,
+		Name:      name,
+		IsHoliday: req.IsHoliday,
+	})
+	if err != nil {
+		logCtx.Error("Failed to set holiday override", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to set holiday override",
+		}
+	}
+
+	logCtx.Info("Holiday override set", "isHoliday", req.IsHoliday)
+	return nil
+}
+
+// adminDeleteHolidayOverrideHandler restores the embedded holiday data for the date
+func (r *Router) adminDeleteHolidayOverrideHandler(c *gin.Context, req *api.AdminDeleteHolidayOverrideRequest) error {
+	ctx := context.Background()
+	country, err := adminHolidayCountry(req.Country)
+	if err != nil {
+		return err
+	}
+	logCtx := slog.With("country", country, "date", req.Date)
+
+	if _, err := time.Parse(time.DateOnly, req.Date); err != nil {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid date format, expected YYYY-MM-DD",
+		}
+	}
+
+	if err := r.db.DeleteHolidayOverride(ctx, country, req.Date); err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Holiday override not found",
+			}
+		}
+		logCtx.Error("Failed to delete holiday override", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to delete holiday override",
+		}
+	}
+
+	logCtx.Info("Holiday override deleted")
+	return nil
+}
+
+// adminHolidayCountry returns the upper case ISO 3166-1 alpha-2 code overrides of the country are stored under
+func adminHolidayCountry(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if !holidays.IsCountryCode(country) {
+		return "", HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid country code, expected ISO 3166-1 alpha-2, e.g. PL",
+		}
+	}
+	return country, nil
+}
+
+func toApiHolidays(list []holidays.Holiday) []api.Holiday {
+	result := make([]api.Holiday, len(list))
+	for i, h := range list {
+		result[i] = api.Holiday{Date: h.Date, Name: h.Name}
+	}
+	return result
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -33,7 +35,9 @@ func (r *Router) getEventCalendarHandler(c *gin.Context) {
 		return
 	}
 
-	if event.Status != api.EventStatusConfirmed {
+	// A cancelled event that had been confirmed is served as a cancellation so calendars drop it
+	cancelled := event.Status == api.EventStatusCancelled && event.Confirmation != nil
+	if event.Status != api.EventStatusConfirmed && !cancelled {
 		c.JSON(http.StatusBadRequest, api.ErrorMessage{Message: "Event is not confirmed"})
 		return
 	}
@@ -49,7 +53,24 @@ func (r *Router) getEventCalendarHandler(c *gin.Context) {
 		}
 	}
 
-	ics, err := generateICS(event, facilityName)
+	teamNames := make([]string, len(event.Teams))
+	if len(event.Teams) > 0 {
+		userNames, err := r.db.GetUserNames(context.Background(), slices.Concat(event.Teams...))
+		if err != nil {
+			logCtx.Error("Failed to get user names", "error", err)
+			c.JSON(http.StatusInternalServerError, api.ErrorMessage{Message: "Failed to get players"})
+			return
+		}
+		for i, team := range event.Teams {
+			names := make([]string, len(team))
+			for j, userId := range team {
+				names[j] = userNames[userId]
+			}
+			teamNames[i] = strings.Join(names, " & ")
+		}
+	}
+
+	ics, err := generateICS(event, facilityName, teamNames)
 	if err != nil {
 		logCtx.Error("Failed to generate ICS", "error", err)
 		c.JSON(http.StatusInternalServerError, api.ErrorMessage{Message: "Failed to generate calendar file"})
@@ -60,7 +81,8 @@ func (r *Router) getEventCalendarHandler(c *gin.Context) {
 	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
 }
 
-func generateICS(event *api.Event, facilityName string) (string, error) {
+// generateICS renders the confirmed event, teamNames are the player names of each team joined with " & "
+func generateICS(event *api.Event, facilityName string, teamNames []string) (string, error) {
 	dtStart, err := time.Parse(time.RFC3339, event.Confirmation.Datetime)
 	if err != nil {
 		return "", fmt.Errorf("invalid event datetime: %w", err)
@@ -78,26 +100,57 @@ func generateICS(event *api.Event, facilityName string) (string, error) {
 		eventTypeLabel = "Training"
 	}
 
-	summary := fmt.Sprintf("Tennis %s at %s", eventTypeLabel, facilityName)
+	location := escapeICSText(facilityName)
+	summary := fmt.Sprintf("Tennis %s at %s", eventTypeLabel, location)
 
-	description := event.Description
+	description := escapeICSText(event.Description)
 	if description == "" {
 		description = summary
 	}
+	if len(teamNames) > 0 {
+		// new lines are escaped in iCalendar text values
+		description += "\\nTeams: " + escapeICSText(strings.Join(teamNames, " vs "))
+	}
+	if cost := event.Confirmation.CourtCost; cost != nil {
+		description += fmt.Sprintf("\\nCourt cost: %.2f (%.2f per player)", cost.Total, cost.PerPlayer)
+	}
+
+	// Calendars match updates to the original event by UID and require a higher SEQUENCE: every reschedule
+	// bumps the confirmation version and the cancellation comes after the latest version
+	method := "PUBLISH"
+	status := "CONFIRMED"
+	sequence := max(event.Confirmation.Version-1, 0)
+	if event.Status == api.EventStatusCancelled {
+		method = "CANCEL"
+		status = "CANCELLED"
+		sequence++
+		if event.CancelReason != "" {
+			description = escapeICSText(event.CancelReason)
+		}
+	}
 
 	ics := "BEGIN:VCALENDAR\r\n" +
 		"VERSION:2.0\r\n" +
 		"PRODID:-//XTP Tour//XTP Tour//EN\r\n" +
+		fmt.Sprintf("METHOD:%s\r\n", method) +
 		"BEGIN:VEVENT\r\n" +
 		fmt.Sprintf("UID:%s@xtptour.com\r\n", event.Id) +
 		fmt.Sprintf("DTSTAMP:%s\r\n", dtstamp) +
 		fmt.Sprintf("DTSTART:%s\r\n", dtStartStr) +
 		fmt.Sprintf("DTEND:%s\r\n", dtEndStr) +
 		fmt.Sprintf("SUMMARY:%s\r\n", summary) +
-		fmt.Sprintf("LOCATION:%s\r\n", facilityName) +
+		fmt.Sprintf("LOCATION:%s\r\n", location) +
 		fmt.Sprintf("DESCRIPTION:%s\r\n", description) +
+		fmt.Sprintf("STATUS:%s\r\n", status) +
+		fmt.Sprintf("SEQUENCE:%d\r\n", sequence) +
 		"END:VEVENT\r\n" +
 		"END:VCALENDAR\r\n"
 
 	return ics, nil
 }
+
+// escapeICSText escapes a TEXT value as required by RFC 5545, so that user input cannot end the line and add
+// properties of its own
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func Test_generateICS(t *testing.T) {
+	event := &api.Event{
+		EventData: api.EventData{
+			Id:              "event-1",
+			EventType:       api.ActivityTypeMatch,
+			SessionDuration: 90,
+			Description:     "Friendly match",
+		},
+		Status: api.EventStatusConfirmed,
+		Confirmation: &api.Confirmation{
+			LocationId: "matchpoint",
+			Datetime:   "2025-06-03T18:00:00Z",
+		},
+	}
+
+	t.Run("Confirmed", func(tt *testing.T) {
+		ics, err := generateICS(event, "Matchpoint", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "METHOD:PUBLISH\r\n")
+		assert.Contains(tt, ics, "UID:event-1@xtptour.com\r\n")
+		assert.Contains(tt, ics, "DTSTART:20250603T180000Z\r\n")
+		assert.Contains(tt, ics, "DTEND:20250603T193000Z\r\n")
+		assert.Contains(tt, ics, "STATUS:CONFIRMED\r\n")
+		assert.Contains(tt, ics, "SEQUENCE:0\r\n")
+		assert.Contains(tt, ics, "DESCRIPTION:Friendly match\r\n")
+	})
+
+	t.Run("ConfirmedWithTeams", func(tt *testing.T) {
+		ics, err := generateICS(event, "Matchpoint", []string{"Alice & Bob", "Carol & Dave"})
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "DESCRIPTION:Friendly match\\nTeams: Alice & Bob vs Carol & Dave\r\n")
+	})
+
+	t.Run("ConfirmedWithCourtCost", func(tt *testing.T) {
+		priced := *event
+		confirmation := *event.Confirmation
+		confirmation.CourtCost = &api.CourtCost{Total: 100, Players: 3, PerPlayer: 33.34}
+		priced.Confirmation = &confirmation
+
+		ics, err := generateICS(&priced, "Matchpoint", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "DESCRIPTION:Friendly match\\nCourt cost: 100.00 (33.34 per player)\r\n")
+	})
+
+	t.Run("Cancelled", func(tt *testing.T) {
+		cancelled := *event
+		cancelled.Status = api.EventStatusCancelled
+		cancelled.CancelReason = "Court is flooded"
+
+		ics, err := generateICS(&cancelled, "Matchpoint", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "METHOD:CANCEL\r\n")
+		assert.Contains(tt, ics, "UID:event-1@xtptour.com\r\n")
+		assert.Contains(tt, ics, "STATUS:CANCELLED\r\n")
+		assert.Contains(tt, ics, "SEQUENCE:1\r\n")
+		assert.Contains(tt, ics, "DESCRIPTION:Court is flooded\r\n")
+	})
+
+	t.Run("CancelReasonEscaped", func(tt *testing.T) {
+		cancelled := *event
+		cancelled.Status = api.EventStatusCancelled
+		cancelled.CancelReason = "Rain; courts, closed\\\nBEGIN:VEVENT"
+
+		ics, err := generateICS(&cancelled, "Club, Court; 1", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "DESCRIPTION:Rain\\; courts\\, closed\\\\\\nBEGIN:VEVENT\r\n")
+		assert.Contains(tt, ics, "LOCATION:Club\\, Court\\; 1\r\n")
+	})
+
+	t.Run("Rescheduled", func(tt *testing.T) {
+		rescheduled := *event
+		confirmation := *event.Confirmation
+		confirmation.Datetime = "2025-06-04T18:00:00Z"
+		confirmation.Version = 3
+		rescheduled.Confirmation = &confirmation
+
+		ics, err := generateICS(&rescheduled, "Matchpoint", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "UID:event-1@xtptour.com\r\n")
+		assert.Contains(tt, ics, "DTSTART:20250604T180000Z\r\n")
+		assert.Contains(tt, ics, "SEQUENCE:2\r\n")
+
+		rescheduled.Status = api.EventStatusCancelled
+		ics, err = generateICS(&rescheduled, "Matchpoint", nil)
+		require.NoError(tt, err)
+		assert.Contains(tt, ics, "SEQUENCE:3\r\n")
+	})
+}
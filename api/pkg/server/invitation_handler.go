@@ -0,0 +1,265 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/crypto"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+func (r *Router) createInviteLinkHandler(c *gin.Context, req *api.CreateInviteLinkRequest) (*api.InviteLinkResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	event, err := r.getHostedPrivateEvent(logCtx, userId.(string), req.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().UTC().Add(r.inviteTtl)
+	token := r.inviteSigner.Sign(event.Id, expiresAt)
+
+	return &api.InviteLinkResponse{
+		Token:     token,
+		Path:      "/events/" + event.Id + "?invite=" + url.QueryEscape(token),
+		ExpiresAt: api.DtToIso(expiresAt),
+	}, nil
+}
+
+func (r *Router) resolveInviteHandler(c *gin.Context, req *api.ResolveInviteRequest) (*api.GetEventResponse, error) {
+	eventId, err := r.inviteSigner.Verify(req.Token, time.Now())
+	if err != nil {
+		if errors.Is(err, crypto.ErrExpiredInviteToken) {
+			return nil, HttpError{
+				HttpCode: http.StatusGone,
+				Message:  "Invite link has expired",
+			}
+		}
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid invite link",
+		}
+	}
+
+	logCtx := slog.With("eventId", eventId)
+
+	event, err := r.db.GetEventById(context.Background(), eventId)
+	if err != nil {
+		logCtx.Error("Failed to get event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	return &api.GetEventResponse{
+		Event: event,
+	}, nil
+}
+
+func (r *Router) inviteUsersHandler(c *gin.Context, req *api.InviteUsersRequest) (*api.ListInvitationsResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	event, err := r.getHostedPrivateEvent(logCtx, userId.(string), req.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	invited, err := r.db.InviteUsers(context.Background(), userId.(string), event.Id, req.UserIds)
+	if err != nil {
+		if e, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  e.Message,
+			}
+		}
+		logCtx.Error("Failed to invite users", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to invite users",
+		}
+	}
+
+	if len(invited) > 0 {
+		go r.notifier.UsersInvited(userId.(string), event.Id, invited)
+	}
+
+	return r.listInvitations(logCtx, event.Id)
+}
+
+func (r *Router) listInvitationsHandler(c *gin.Context, req *api.ListInvitationsRequest) (*api.ListInvitationsResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	event, err := r.getHostedPrivateEvent(logCtx, userId.(string), req.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.listInvitations(logCtx, event.Id)
+}
+
+func (r *Router) deleteInvitationHandler(c *gin.Context, req *api.DeleteInvitationRequest) error {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "invitedUserId", req.UserId)
+
+	event, err := r.getHostedPrivateEvent(logCtx, userId.(string), req.EventId)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.DeleteInvitation(context.Background(), event.Id, req.UserId)
+	if err != nil {
+		if e, ok := err.(db.DbObjectNotFoundError); ok {
+			return HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  e.Message,
+			}
+		}
+		logCtx.Error("Failed to delete invitation", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to delete invitation",
+		}
+	}
+
+	return nil
+}
+
+func (r *Router) listInvitations(logCtx *slog.Logger, eventId string) (*api.ListInvitationsResponse, error) {
+	invitations, err := r.db.GetInvitations(context.Background(), eventId)
+	if err != nil {
+		logCtx.Error("Failed to get invitations", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get invitations",
+		}
+	}
+
+	return &api.ListInvitationsResponse{
+		Invitations: invitations,
+	}, nil
+}
+
+// getHostedPrivateEvent returns the event if it belongs to the user, only private events take invitations
+func (r *Router) getHostedPrivateEvent(logCtx *slog.Logger, userId string, eventId string) (*api.Event, error) {
+	event, err := r.db.GetMyEvent(context.Background(), userId, eventId)
+	if err != nil {
+		logCtx.Error("Failed to get my event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if event.Visibility != api.EventVisibilityPrivate {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Only private events take invitations",
+		}
+	}
+
+	return event, nil
+}
+
+// checkJoinPermission lets anyone join public events, private events require an invite token or an invitation from the host
+func (r *Router) checkJoinPermission(logCtx *slog.Logger, eventId string, userId string, inviteToken string) error {
+	event, err := r.db.GetEventById(context.Background(), eventId)
+	if err != nil {
+		logCtx.Error("Failed to get event", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if event.Visibility != api.EventVisibilityPrivate || event.UserId == userId {
+		return nil
+	}
+
+	if inviteToken != "" {
+		tokenEventId, err := r.inviteSigner.Verify(inviteToken, time.Now())
+		if err == nil && tokenEventId == event.Id {
+			return nil
+		}
+		logCtx.Info("Rejected invite token", "error", err, "tokenEventId", tokenEventId)
+	}
+
+	invited, err := r.db.IsInvited(context.Background(), event.Id, userId)
+	if err != nil {
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to check invitation",
+		}
+	}
+	if !invited {
+		return HttpError{
+			HttpCode: http.StatusForbidden,
+			Message:  "Invitation required to join this private event",
+		}
+	}
+
+	return nil
+}
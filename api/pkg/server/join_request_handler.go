@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const maxHostMessageLength = 500
+
+func (r *Router) acceptJoinRequestHandler(c *gin.Context, req *api.AnswerJoinRequestRequest) (*api.JoinRequestResponse, error) {
+	return r.answerJoinRequest(c, req, true)
+}
+
+func (r *Router) rejectJoinRequestHandler(c *gin.Context, req *api.AnswerJoinRequestRequest) (*api.JoinRequestResponse, error) {
+	return r.answerJoinRequest(c, req, false)
+}
+
+func (r *Router) answerJoinRequest(c *gin.Context, req *api.AnswerJoinRequestRequest, accept bool) (*api.JoinRequestResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "joinRequestId", req.JoinRequestId, "accept", accept)
+
+	if utf8.RuneCountInString(req.Message) > maxHostMessageLength {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Message is too long",
+		}
+	}
+
+	answer, err := r.db.RespondJoinRequest(context.Background(), userId.(string), req.EventId, req.JoinRequestId, accept, req.Message)
+	if err != nil {
+		if e, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  e.Message,
+			}
+		}
+		if e, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  e.Message,
+			}
+		}
+		logCtx.Error("Failed to answer join request", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to answer join request",
+		}
+	}
+
+	go r.notifier.JoinRequestAnswered(userId.(string), *answer.JoinRequest, accept)
+	if answer.Promotion != nil {
+		go r.notifier.WaitlistPromoted(*answer.Promotion)
+	}
+
+	return &api.JoinRequestResponse{
+		JoinRequest: *answer.JoinRequest,
+	}, nil
+}
+
+// setJoinRequestPaymentHandler lets the host record which accepted players paid their share of the court
+func (r *Router) setJoinRequestPaymentHandler(c *gin.Context, req *api.JoinRequestPaymentRequest) (*api.JoinRequestResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "joinRequestId", req.JoinRequestId, "paid", req.Paid)
+	ctx := context.Background()
+
+	err := r.db.SetJoinRequestPaid(ctx, userId.(string), req.EventId, req.JoinRequestId, req.Paid)
+	if err != nil {
+		if e, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  e.Message,
+			}
+		}
+		if e, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  e.Message,
+			}
+		}
+		logCtx.Error("Failed to set join request payment", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to set payment",
+		}
+	}
+
+	joinRequest, err := r.db.GetJoinRequest(ctx, req.JoinRequestId)
+	if err != nil {
+		logCtx.Error("Failed to get join request", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get join request",
+		}
+	}
+
+	return &api.JoinRequestResponse{
+		JoinRequest: *joinRequest,
+	}, nil
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const maxDisputeReasonLength = 500
+
+func (r *Router) submitMatchResultHandler(c *gin.Context, req *api.SubmitMatchResultRequest) (*api.MatchResultResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	if err := api.ValidateSets(req.Sets); err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  err.Error(),
+		}
+	}
+
+	event, err := r.getPlayedMatch(logCtx, userId.(string), req.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	team, opponents, err := matchSides(event, userId.(string), req.OpponentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.SubmitMatchResult(context.Background(), event.Id, userId.(string), team, opponents, req.Sets)
+	if err != nil {
+		return nil, matchResultError(logCtx, err, "Failed to submit match result")
+	}
+
+	return &api.MatchResultResponse{
+		Result: result,
+	}, nil
+}
+
+func (r *Router) confirmMatchResultHandler(c *gin.Context, req *api.ConfirmMatchResultRequest) (*api.MatchResultResponse, error) {
+	return r.respondMatchResult(c, req.EventId, api.MatchResultStatusConfirmed, "")
+}
+
+func (r *Router) disputeMatchResultHandler(c *gin.Context, req *api.DisputeMatchResultRequest) (*api.MatchResultResponse, error) {
+	if utf8.RuneCountInString(req.Reason) > maxDisputeReasonLength {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Dispute reason is too long",
+		}
+	}
+	return r.respondMatchResult(c, req.EventId, api.MatchResultStatusDisputed, req.Reason)
+}
+
+func (r *Router) respondMatchResult(c *gin.Context, eventId string, status api.MatchResultStatus, reason string) (*api.MatchResultResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", eventId, "status", status)
+
+	event, err := r.getPlayedMatch(logCtx, userId.(string), eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.RespondMatchResult(context.Background(), event.Id, userId.(string), status, reason)
+	if err != nil {
+		return nil, matchResultError(logCtx, err, "Failed to respond to match result")
+	}
+
+	return &api.MatchResultResponse{
+		Result: result,
+	}, nil
+}
+
+// getPlayedMatch returns the match event the user played in, if its post-match window is still open
+func (r *Router) getPlayedMatch(logCtx *slog.Logger, userId string, eventId string) (*api.Event, error) {
+	event, err := r.db.GetEventById(context.Background(), eventId)
+	if err != nil {
+		logCtx.Error("Failed to get event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil || !slices.Contains(matchPlayers(event), userId) {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if event.EventType != api.ActivityTypeMatch {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Results can be recorded for matches only",
+		}
+	}
+
+	if !event.IsPostMatchOpen(time.Now().UTC()) {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Results can be recorded for completed events within the post-match window only",
+		}
+	}
+
+	return event, nil
+}
+
+// matchPlayers returns the host and the accepted joiners of the event
+func matchPlayers(event *api.Event) []string {
+	players := []string{event.UserId}
+	for _, jr := range event.JoinRequests {
+		if jr.Status == api.JoinRequestStatusAccepted {
+			players = append(players, jr.UserId)
+		}
+	}
+	return players
+}
+
+// matchSides splits the players of the match into the side of the submitting user and the opposing side.
+// Teams recorded at confirmation decide the sides, opponentIds pick the opposing team when there are more than two.
+// Without teams the opponents are the given players, or the other player of a singles match, and the
+// remaining players are partners of the user.
+func matchSides(event *api.Event, userId string, opponentIds []string) ([]string, []string, error) {
+	if len(event.Teams) > 0 {
+		var team []string
+		var others [][]string
+		for _, t := range event.Teams {
+			if slices.Contains(t, userId) {
+				team = t
+			} else {
+				others = append(others, t)
+			}
+		}
+		if team == nil {
+			return nil, nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "You are not in any team of the match",
+			}
+		}
+		if len(opponentIds) == 0 && len(others) == 1 {
+			return team, others[0], nil
+		}
+		for _, t := range others {
+			if sameUsers(t, opponentIds) {
+				return team, t, nil
+			}
+		}
+		return nil, nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Opponents must be one of the other teams of the match",
+		}
+	}
+
+	players := matchPlayers(event)
+	if len(opponentIds) == 0 && len(players) == 2 {
+		opponentIds = slices.DeleteFunc(slices.Clone(players), func(p string) bool { return p == userId })
+	}
+
+	var team []string
+	for _, p := range players {
+		if !slices.Contains(opponentIds, p) {
+			team = append(team, p)
+		}
+	}
+	if slices.Contains(opponentIds, userId) || len(team)+len(opponentIds) != len(players) || len(team) != len(opponentIds) {
+		return nil, nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Opponents must be the other half of the players of the match",
+		}
+	}
+	return team, opponentIds, nil
+}
+
+// sameUsers tells whether both lists hold the same users in any order
+func sameUsers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, userId := range a {
+		if !slices.Contains(b, userId) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchResultError(logCtx *slog.Logger, err error, message string) error {
+	if e, ok := err.(db.DbObjectNotFoundError); ok {
+		return HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  e.Message,
+		}
+	}
+	if e, ok := err.(*db.ValidationError); ok {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  e.Message,
+		}
+	}
+	logCtx.Error(message, "error", err)
+	return HttpError{
+		HttpCode: http.StatusInternalServerError,
+		Message:  message,
+	}
+}
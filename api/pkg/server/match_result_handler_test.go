@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func Test_matchSides(t *testing.T) {
+	accepted := func(userIds ...string) []*api.JoinRequest {
+		var jrs []*api.JoinRequest
+		for _, userId := range userIds {
+			jrs = append(jrs, &api.JoinRequest{UserId: userId, Status: api.JoinRequestStatusAccepted})
+		}
+		return jrs
+	}
+	singles := &api.Event{EventData: api.EventData{UserId: "host"}, JoinRequests: accepted("p1")}
+	doubles := &api.Event{EventData: api.EventData{UserId: "host"}, JoinRequests: accepted("p1", "p2", "p3"),
+		Teams: [][]string{{"host", "p2"}, {"p1", "p3"}}}
+	noTeams := &api.Event{EventData: api.EventData{UserId: "host"}, JoinRequests: accepted("p1", "p2", "p3")}
+	threeTeams := &api.Event{EventData: api.EventData{UserId: "host"}, JoinRequests: accepted("p1", "p2"),
+		Teams: [][]string{{"host"}, {"p1"}, {"p2"}}}
+
+	tests := []struct {
+		name          string
+		event         *api.Event
+		userId        string
+		opponentIds   []string
+		wantTeam      []string
+		wantOpponents []string
+		wantErr       bool
+	}{
+		{"singles opponent implied", singles, "p1", nil, []string{"p1"}, []string{"host"}, false},
+		{"singles opponent given", singles, "host", []string{"p1"}, []string{"host"}, []string{"p1"}, false},
+		{"singles self as opponent", singles, "host", []string{"host"}, nil, nil, true},
+		{"doubles teams implied", doubles, "p3", nil, []string{"p1", "p3"}, []string{"host", "p2"}, false},
+		{"doubles own team as opponents", doubles, "host", []string{"p2", "host"}, nil, nil, true},
+		{"no teams split by opponents", noTeams, "p2", []string{"p1", "p3"}, []string{"host", "p2"}, []string{"p1", "p3"}, false},
+		{"no teams uneven split", noTeams, "host", []string{"p1"}, nil, nil, true},
+		{"no teams unknown opponent", noTeams, "host", []string{"p1", "stranger"}, nil, nil, true},
+		{"three teams need opponents", threeTeams, "host", nil, nil, nil, true},
+		{"three teams picked", threeTeams, "host", []string{"p2"}, []string{"host"}, []string{"p2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, opponents, err := matchSides(tt.event, tt.userId, tt.opponentIds)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.ElementsMatch(t, tt.wantTeam, team)
+				assert.ElementsMatch(t, tt.wantOpponents, opponents)
+			}
+		})
+	}
+}
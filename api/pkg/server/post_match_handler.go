@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+const maxReportCommentLength = 500
+
+func (r *Router) submitFeedbackHandler(c *gin.Context, req *api.SubmitFeedbackRequest) (*api.FeedbackResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "toUserId", req.UserId)
+
+	if req.Rating < api.MinFeedbackRating || req.Rating > api.MaxFeedbackRating {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  fmt.Sprintf("Rating must be between %d and %d", api.MinFeedbackRating, api.MaxFeedbackRating),
+		}
+	}
+	if utf8.RuneCountInString(req.Comment) > maxReportCommentLength {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Comment is too long",
+		}
+	}
+
+	event, err := r.getPostMatchEvent(logCtx, userId.(string), req.EventId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := r.db.SubmitFeedback(context.Background(), event.Id, userId.(string), req.UserId, req.Rating, req.Comment)
+	if err != nil {
+		logCtx.Error("Failed to submit feedback", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to submit feedback",
+		}
+	}
+
+	return &api.FeedbackResponse{
+		Feedback: feedback,
+	}, nil
+}
+
+func (r *Router) reportNoShowHandler(c *gin.Context, req *api.ReportNoShowRequest) (*api.NoShowReportResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "noShowUserId", req.UserId)
+
+	if utf8.RuneCountInString(req.Comment) > maxReportCommentLength {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Comment is too long",
+		}
+	}
+
+	event, err := r.getPostMatchEvent(logCtx, userId.(string), req.EventId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.db.ReportNoShow(context.Background(), event.Id, userId.(string), req.UserId, req.Comment)
+	if err != nil {
+		logCtx.Error("Failed to report no-show", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to report no-show",
+		}
+	}
+
+	return &api.NoShowReportResponse{
+		Report: report,
+	}, nil
+}
+
+// getPostMatchEvent returns the event the user played in with the other player, if its post-match window is still open
+func (r *Router) getPostMatchEvent(logCtx *slog.Logger, userId string, eventId string, otherUserId string) (*api.Event, error) {
+	event, err := r.db.GetEventById(context.Background(), eventId)
+	if err != nil {
+		logCtx.Error("Failed to get event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if err := checkPostMatchReport(event, userId, otherUserId, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// checkPostMatchReport tells whether the user can report about the other player of the event: both must have played
+// in it and its post-match window must still be open
+func checkPostMatchReport(event *api.Event, userId string, otherUserId string, now time.Time) error {
+	players := matchPlayers(event)
+	if !slices.Contains(players, userId) {
+		return HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	if otherUserId == userId || !slices.Contains(players, otherUserId) {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Reports can be made about the other players of the event only",
+		}
+	}
+
+	if !event.IsPostMatchOpen(now) {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Reports can be made for completed events within the post-match window only",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func Test_checkPostMatchReport(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	completed := func(postMatchUntil time.Time) *api.Event {
+		return &api.Event{
+			EventData: api.EventData{UserId: "host"},
+			JoinRequests: []*api.JoinRequest{
+				{UserId: "p1", Status: api.JoinRequestStatusAccepted},
+				{UserId: "rejected", Status: api.JoinRequestStatusRejected},
+			},
+			Status:         api.EventStatusCompleted,
+			PostMatchUntil: api.DtToIso(postMatchUntil),
+		}
+	}
+	open := completed(now.Add(time.Hour))
+	closed := completed(now.Add(-time.Hour))
+	confirmed := completed(now.Add(time.Hour))
+	confirmed.Status = api.EventStatusConfirmed
+
+	tests := []struct {
+		name        string
+		event       *api.Event
+		userId      string
+		otherUserId string
+		wantCode    int
+	}{
+		{"player about host", open, "p1", "host", 0},
+		{"host about player", open, "host", "p1", 0},
+		{"stranger", open, "stranger", "host", http.StatusNotFound},
+		{"rejected joiner", open, "rejected", "host", http.StatusNotFound},
+		{"about self", open, "host", "host", http.StatusBadRequest},
+		{"about rejected joiner", open, "host", "rejected", http.StatusBadRequest},
+		{"window closed", closed, "p1", "host", http.StatusBadRequest},
+		{"not completed", confirmed, "p1", "host", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPostMatchReport(tt.event, tt.userId, tt.otherUserId, now)
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if httpErr, ok := err.(HttpError); assert.True(t, ok, "expected HttpError, got %v", err) {
+				assert.Equal(t, tt.wantCode, httpErr.HttpCode)
+			}
+		})
+	}
+}
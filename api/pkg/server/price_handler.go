@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/pricing"
+)
+
+const maxPricedSessionMinutes = 24 * 60
+
+// getCourtPriceHandler resolves the court price of a session at the facility from its published price list
+func (r *Router) getCourtPriceHandler(c *gin.Context, req *api.CourtPriceRequest) (*api.CourtPriceResponse, error) {
+	logCtx := slog.With("locationId", req.LocationId)
+
+	start, err := time.Parse(time.RFC3339, req.DateTime)
+	if err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid datetime format, expected RFC3339",
+		}
+	}
+	if req.Duration <= 0 || req.Duration > maxPricedSessionMinutes {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Duration must be between 1 and 1440 minutes",
+		}
+	}
+
+	priceList, err := r.getPriceList(context.Background(), logCtx, req.LocationId)
+	if err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Location not found",
+			}
+		}
+		logCtx.Error("Failed to get price list", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get price",
+		}
+	}
+
+	resp := &api.CourtPriceResponse{
+		LocationId:  req.LocationId,
+		DateTime:    api.DtToIso(start),
+		Duration:    req.Duration,
+		CourtGroups: []api.CourtGroupPrice{},
+	}
+	for _, quote := range priceList.Quote(start, time.Duration(req.Duration)*time.Minute) {
+		groupPrice := api.CourtGroupPrice{
+			CourtGroupId: quote.CourtGroup.Id,
+			Surface:      quote.CourtGroup.Surface,
+			Type:         quote.CourtGroup.Type,
+			Price:        quote.Price,
+		}
+		for _, segment := range quote.Segments {
+			groupPrice.Segments = append(groupPrice.Segments, api.PriceSegment{
+				Start:       api.DtToIso(segment.Start),
+				End:         api.DtToIso(segment.End),
+				HourlyPrice: segment.HourlyPrice,
+				Price:       segment.Price,
+			})
+		}
+		resp.CourtGroups = append(resp.CourtGroups, groupPrice)
+	}
+	if len(resp.CourtGroups) > 0 {
+		price := resp.CourtGroups[0].Price
+		resp.Price = &price
+	}
+
+	return resp, nil
+}
+
+// sessionCourtPrice returns the lowest court price of the session at the location, nil when it is not known
+func (r *Router) sessionCourtPrice(ctx context.Context, logCtx *slog.Logger, locationId string, start time.Time, sessionDuration int) *float64 {
+	priceList, err := r.getPriceList(ctx, logCtx, locationId)
+	if err != nil {
+		logCtx.Warn("Failed to get price list", "error", err, "locationId", locationId)
+		return nil
+	}
+	price, ok := priceList.Cheapest(start, time.Duration(sessionDuration)*time.Minute)
+	if !ok {
+		return nil
+	}
+	return &price
+}
+
+// getPriceList returns the price list of the facility with holiday rules applying on public holidays of its country
+func (r *Router) getPriceList(ctx context.Context, logCtx *slog.Logger, locationId string) (*pricing.PriceList, error) {
+	priceList, err := r.db.GetPriceList(ctx, locationId)
+	if err != nil {
+		return nil, err
+	}
+	priceList.IsHoliday = r.countryHolidays(ctx, logCtx, priceList.Country).IsHoliday
+	return priceList, nil
+}
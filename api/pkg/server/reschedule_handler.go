@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+// rescheduleEventHandler moves a confirmed event to another time or court, e.g. when the court booking fell through.
+// The court of the new version is booked again and accepted players are asked to acknowledge it.
+func (r *Router) rescheduleEventHandler(c *gin.Context, req *api.RescheduleEventRequest) (*api.EventConfirmationResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+	ctx := context.Background()
+
+	if utf8.RuneCountInString(req.Reason) > maxCancelReasonLength {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Reschedule reason is too long",
+		}
+	}
+
+	dt, err := time.Parse(time.RFC3339, req.DateTime)
+	if err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid datetime format, expected RFC3339",
+		}
+	}
+	if !dt.After(time.Now()) {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Event cannot be rescheduled to the past",
+		}
+	}
+
+	// the new court does not have to be one of the locations offered when the event was created
+	if _, err := r.db.GetFacilityName(ctx, req.LocationId); err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Location not found",
+			}
+		}
+		logCtx.Error("Failed to get facility", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get location",
+		}
+	}
+
+	event, err := r.db.GetMyEvent(ctx, userId.(string), req.EventId)
+	if err != nil {
+		logCtx.Error("Failed to get my event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+	if event == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	courtPrice := r.sessionCourtPrice(ctx, logCtx, req.LocationId, dt, event.SessionDuration)
+	confirmation, previousBooking, err := r.db.RescheduleEvent(ctx, userId.(string), req.EventId, req.LocationId, dt.UTC(), req.Reason, courtPrice)
+	if err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Event not found",
+			}
+		}
+		if validationErr, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  validationErr.Message,
+			}
+		}
+		logCtx.Error("Failed to reschedule event", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to reschedule event",
+		}
+	}
+
+	logCtx.Info("Event rescheduled", "version", confirmation.Version)
+	r.releaseCourt(previousBooking)
+	r.reserveCourt(logCtx, event, confirmation)
+	if confirmation.ReservationStatus != api.ReservationStatusFailed {
+		go r.notifier.EventRescheduled(userId.(string), req.EventId, *confirmation, req.Reason)
+	}
+
+	return &api.EventConfirmationResponse{
+		Confirmation: *confirmation,
+	}, nil
+}
+
+// acknowledgeRescheduleHandler lets an accepted player agree to the latest time and court of a rescheduled event,
+// players who cannot make it cancel their join request instead
+func (r *Router) acknowledgeRescheduleHandler(c *gin.Context, req *api.AcknowledgeRescheduleRequest) error {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId)
+
+	err := r.db.AcknowledgeReschedule(context.Background(), userId.(string), req.EventId)
+	if err != nil {
+		if notFoundErr, ok := err.(db.DbObjectNotFoundError); ok {
+			return HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  notFoundErr.Message,
+			}
+		}
+		if validationErr, ok := err.(*db.ValidationError); ok {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  validationErr.Message,
+			}
+		}
+		logCtx.Error("Failed to acknowledge rescheduled event", "error", err)
+		return HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to acknowledge rescheduled event",
+		}
+	}
+
+	return nil
+}
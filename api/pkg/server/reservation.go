@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/reservation"
+)
+
+// reserveCourt books the court of the confirmation version through the reservation provider of its facility
+// and records the outcome on the confirmation returned to the host
+func (r *Router) reserveCourt(logCtx *slog.Logger, event *api.Event, confirmation *api.Confirmation) {
+	status, booking := r.reserver.Reserve(context.Background(), event.UserId, confirmation.Version, reservation.Request{
+		EventId:    event.Id,
+		FacilityId: confirmation.LocationId,
+		Start:      api.ParseDt(confirmation.Datetime),
+		Duration:   time.Duration(event.SessionDuration) * time.Minute,
+		Players:    event.ExpectedPlayers,
+	})
+
+	confirmation.ReservationStatus = status
+	if booking != nil {
+		confirmation.CourtNumber = booking.CourtNumber
+	}
+	logCtx.Debug("Court reservation finished", "reservationStatus", status)
+}
+
+// releaseCourt cancels the court booked for a confirmation that was cancelled or replaced, nil bookings are ignored
+func (r *Router) releaseCourt(booking *db.CourtBooking) {
+	if booking == nil {
+		return
+	}
+	r.reserver.Release(context.Background(), booking.FacilityId, reservation.Booking{
+		CourtNumber: booking.CourtNumber,
+		Reference:   booking.Reference,
+	})
+}
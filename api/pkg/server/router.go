@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -19,8 +20,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/xtp-tour/xtp-tour/api/pkg/api"
 	"github.com/xtp-tour/xtp-tour/api/pkg/calendar"
+	"github.com/xtp-tour/xtp-tour/api/pkg/crypto"
 	"github.com/xtp-tour/xtp-tour/api/pkg/db"
+	"github.com/xtp-tour/xtp-tour/api/pkg/holidays"
 	"github.com/xtp-tour/xtp-tour/api/pkg/places"
+	"github.com/xtp-tour/xtp-tour/api/pkg/reservation"
 	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
 
 	"github.com/wI2L/fizz"
@@ -28,10 +32,17 @@ import (
 )
 
 type Notifier interface {
-	EventConfirmed(logCtx *slog.Logger, eventId string, confirmedJoinReqIds []string, dateTime string, locationId string, hostUserId string)
+	EventConfirmed(logCtx *slog.Logger, eventId string, confirmedJoinReqIds []string, dateTime string, locationId string, hostUserId string, teams [][]string, courtCost *api.CourtCost)
 	UserJoined(logCtx slog.Logger, userId string, joinRequest api.JoinRequestData)
 	EventExpired(userId string, eventId string)
 	ChatMessagePosted(senderUserId string, eventId string)
+	EventsCancelled(hostUserId string, seriesId string, events []db.CancelledEvent, reason string, wasConfirmed bool)
+	EventUpdated(hostUserId string, eventId string, updates []db.JoinRequestUpdate)
+	WaitlistPromoted(promotion db.WaitlistPromotion)
+	JoinRequestAnswered(hostUserId string, joinRequest api.JoinRequest, accepted bool)
+	UsersInvited(hostUserId string, eventId string, userIds []string)
+	EventRescheduled(hostUserId string, eventId string, confirmation api.Confirmation, reason string)
+	ReservationFailed(hostUserId string, eventId string, reason string)
 }
 
 type Router struct {
@@ -40,8 +51,12 @@ type Router struct {
 	db              *db.Db
 	notifier        Notifier
 	calendarService *calendar.Service
-	placesService   *places.Service
+	placesProvider  places.Provider
 	features        pkg.FeatureToggles
+	inviteSigner    *crypto.InviteSigner
+	inviteTtl       time.Duration
+	reserver        *reservation.Reserver
+	holidays        *holidays.Calendar
 }
 
 func (r *Router) Run() {
@@ -67,7 +82,28 @@ func NewRouter(config *pkg.HttpConfig, dbConn *db.Db, debugMode bool, notifier N
 	}
 	calendarService := calendar.NewService(calendarConfig, dbConn)
 
-	placesService := places.NewService(config.GooglePlaces.APIKey)
+	placesProvider, err := newPlacesProvider(&config.GooglePlaces)
+	if err != nil {
+		panic(err)
+	}
+
+	inviteSigner, err := newInviteSigner(&config.Invites, debugMode || config.AuthConfig.Type == "debug")
+	if err != nil {
+		panic(err)
+	}
+
+	reservations := reservation.NewRegistry()
+	for _, facilityId := range strings.Split(config.Reservations.FakeFacilities, ",") {
+		if facilityId = strings.TrimSpace(facilityId); facilityId != "" {
+			slog.Warn("Courts of the facility are booked by the fake reservation provider", "facilityId", facilityId, "courts", config.Reservations.FakeCourts)
+			reservations.Register(facilityId, reservation.NewFakeProvider(config.Reservations.FakeCourts))
+		}
+	}
+
+	holidayCalendar, err := holidays.NewCalendar()
+	if err != nil {
+		panic(err)
+	}
 
 	r := &Router{
 		fizz:            f,
@@ -75,8 +111,12 @@ func NewRouter(config *pkg.HttpConfig, dbConn *db.Db, debugMode bool, notifier N
 		db:              dbConn,
 		notifier:        notifier,
 		calendarService: calendarService,
-		placesService:   placesService,
+		placesProvider:  placesProvider,
 		features:        features,
+		inviteSigner:    inviteSigner,
+		inviteTtl:       config.Invites.TokenTtl,
+		reserver:        reservation.NewReserver(reservations, dbConn, notifier),
+		holidays:        holidayCalendar,
 	}
 	r.init(config.AuthConfig)
 
@@ -115,6 +155,7 @@ func (r *Router) init(authConf pkg.AuthConfig) {
 	)
 
 	authMiddleware := auth.CreateAuthMiddleware(authConf)
+	optionalAuthMiddleware := auth.CreateOptionalAuthMiddleware(authConf)
 
 	profiles := api.Group("/profiles", "Profiles", "Profiles operations", authMiddleware)
 	profiles.GET("/me", []fizz.OperationOption{fizz.Summary("Get user profile")}, tonic.Handler(r.getMyProfileHandler, http.StatusOK))
@@ -122,18 +163,42 @@ func (r *Router) init(authConf pkg.AuthConfig) {
 	profiles.POST("/", []fizz.OperationOption{fizz.Summary("Create user profile")}, tonic.Handler(r.createUserProfileHandler, http.StatusOK))
 	profiles.PUT("/me", []fizz.OperationOption{fizz.Summary("Update user profile")}, tonic.Handler(r.updateUserProfileHandler, http.StatusOK))
 	profiles.DELETE("/me", []fizz.OperationOption{fizz.Summary("Delete user profile")}, tonic.Handler(r.deleteUserProfileHandler, http.StatusOK))
+	profiles.GET("/me/favourites", []fizz.OperationOption{fizz.Summary("Get favourite facilities")}, tonic.Handler(r.listFavouriteFacilitiesHandler, http.StatusOK))
+	profiles.PUT("/me/favourites/:facilityId", []fizz.OperationOption{fizz.Summary("Add a facility to favourites")}, tonic.Handler(r.addFavouriteFacilityHandler, http.StatusOK))
+	profiles.DELETE("/me/favourites/:facilityId", []fizz.OperationOption{fizz.Summary("Remove a facility from favourites")}, tonic.Handler(r.removeFavouriteFacilityHandler, http.StatusOK))
+	profiles.PUT("/me/home-facility", []fizz.OperationOption{fizz.Summary("Set home facility"), fizz.Description("Makes the facility the home facility and adds it to favourites, an empty facility id clears it")}, tonic.Handler(r.setHomeFacilityHandler, http.StatusOK))
 
 	events := api.Group("/events", "Events", "Events operations", authMiddleware)
 	events.POST("/", []fizz.OperationOption{fizz.Summary("Create an event")}, tonic.Handler(r.createEventHandler, http.StatusOK))
 	events.GET("/", []fizz.OperationOption{fizz.Summary("Get list of events that belong to the user")}, tonic.Handler(r.listEventsHandler, http.StatusOK))
 	events.GET("/joined", []fizz.OperationOption{fizz.Summary("Get list of events that the user joined")}, tonic.Handler(r.listJoinedEventsHandler, http.StatusOK))
 	events.GET("/:eventId", []fizz.OperationOption{fizz.Summary("Get event by id")}, tonic.Handler(r.getMyEventHandler, http.StatusOK))
+	events.PUT("/:eventId", []fizz.OperationOption{fizz.Summary("Update event by id")}, tonic.Handler(r.updateEventHandler, http.StatusOK))
 	events.DELETE("/:eventId", []fizz.OperationOption{fizz.Summary("Delete event by id")}, tonic.Handler(r.deleteEventHandler, http.StatusOK))
+	events.POST("/:eventId/cancellation", []fizz.OperationOption{fizz.Summary("Cancel event keeping its history")}, tonic.Handler(r.cancelEventHandler, http.StatusOK))
+	events.POST("/:eventId/joins/:joinRequestId/acceptance", []fizz.OperationOption{fizz.Summary("Accept a join request before confirming the event")}, tonic.Handler(r.acceptJoinRequestHandler, http.StatusOK))
+	events.POST("/:eventId/joins/:joinRequestId/rejection", []fizz.OperationOption{fizz.Summary("Reject a join request")}, tonic.Handler(r.rejectJoinRequestHandler, http.StatusOK))
+	events.PUT("/:eventId/joins/:joinRequestId/payment", []fizz.OperationOption{fizz.Summary("Mark whether an accepted player paid their share of the court")}, tonic.Handler(r.setJoinRequestPaymentHandler, http.StatusOK))
+	events.GET("/:eventId/confirmation/candidates", []fizz.OperationOption{fizz.Summary("Get ranked location and time slot combinations to confirm the event with")}, tonic.Handler(r.getConfirmationCandidatesHandler, http.StatusOK))
 	events.POST("/:eventId/confirmation", []fizz.OperationOption{fizz.Summary("Confirm event")}, tonic.Handler(r.confirmEvent, http.StatusOK))
+	events.POST("/:eventId/reschedule", []fizz.OperationOption{fizz.Summary("Move a confirmed event to another time or court")}, tonic.Handler(r.rescheduleEventHandler, http.StatusOK))
+	events.POST("/:eventId/reschedule/acknowledgement", []fizz.OperationOption{fizz.Summary("Acknowledge the new time and court of a rescheduled event")}, tonic.Handler(r.acknowledgeRescheduleHandler, http.StatusOK))
+	events.POST("/:eventId/result", []fizz.OperationOption{fizz.Summary("Submit the match result")}, tonic.Handler(r.submitMatchResultHandler, http.StatusOK))
+	events.POST("/:eventId/result/confirmation", []fizz.OperationOption{fizz.Summary("Confirm the match result submitted by the opposing side")}, tonic.Handler(r.confirmMatchResultHandler, http.StatusOK))
+	events.POST("/:eventId/result/dispute", []fizz.OperationOption{fizz.Summary("Dispute the match result submitted by the opposing side")}, tonic.Handler(r.disputeMatchResultHandler, http.StatusOK))
+	events.POST("/:eventId/feedback", []fizz.OperationOption{fizz.Summary("Leave feedback about another player of the completed event")}, tonic.Handler(r.submitFeedbackHandler, http.StatusOK))
+	events.POST("/:eventId/no-shows", []fizz.OperationOption{fizz.Summary("Report a player of the completed event who did not show up")}, tonic.Handler(r.reportNoShowHandler, http.StatusOK))
+	events.POST("/:eventId/invite-link", []fizz.OperationOption{fizz.Summary("Create an invite link to a private event")}, tonic.Handler(r.createInviteLinkHandler, http.StatusOK))
+	events.GET("/:eventId/invitations", []fizz.OperationOption{fizz.Summary("Get invitations to a private event")}, tonic.Handler(r.listInvitationsHandler, http.StatusOK))
+	events.POST("/:eventId/invitations", []fizz.OperationOption{fizz.Summary("Invite users to a private event")}, tonic.Handler(r.inviteUsersHandler, http.StatusOK))
+	events.DELETE("/:eventId/invitations/:userId", []fizz.OperationOption{fizz.Summary("Withdraw an invitation to a private event")}, tonic.Handler(r.deleteInvitationHandler, http.StatusOK))
+	events.PUT("/:eventId/series", []fizz.OperationOption{fizz.Summary("Update this occurrence or the whole series")}, tonic.Handler(r.updateSeriesEventHandler, http.StatusOK))
+	events.POST("/:eventId/series/cancel", []fizz.OperationOption{fizz.Summary("Cancel this occurrence or the whole series")}, tonic.Handler(r.cancelSeriesEventHandler, http.StatusOK))
 
 	// those does not require auth
-	api.GET("/events/public", []fizz.OperationOption{fizz.Summary("Get list of public events")}, tonic.Handler(r.listPublicEventsHandler, http.StatusOK))
+	api.GET("/events/public", []fizz.OperationOption{fizz.Summary("Search public events"), fizz.Description("Filters open public events and returns them page by page, events at favourite facilities of the user first, then newest first")}, optionalAuthMiddleware, tonic.Handler(r.listPublicEventsHandler, http.StatusOK))
 	api.GET("/events/public/:eventId", []fizz.OperationOption{fizz.Summary("Get public event by id")}, tonic.Handler(r.getPublicEventHandler, http.StatusOK))
+	api.GET("/events/invites/:token", []fizz.OperationOption{fizz.Summary("Resolve an invite link to its event")}, tonic.Handler(r.resolveInviteHandler, http.StatusOK))
 
 	public := events.Group("/public", "Public events", "Public events and their operations")
 	public.POST("/:eventId/joins", []fizz.OperationOption{fizz.Summary("Join an event")}, tonic.Handler(r.joinEventHandler, http.StatusOK))
@@ -150,6 +215,18 @@ func (r *Router) init(authConf pkg.AuthConfig) {
 	locations.GET("/", []fizz.OperationOption{fizz.Summary("Get list of locations"), fizz.Security(&openapi.SecurityRequirement{
 		"Bearer": []string{},
 	})}, tonic.Handler(r.listLocationsHandler, http.StatusOK))
+	locations.GET("/:id", []fizz.OperationOption{fizz.Summary("Get facility details with courts and partner cards"), fizz.Security(&openapi.SecurityRequirement{
+		"Bearer": []string{},
+	})}, tonic.Handler(r.getLocationHandler, http.StatusOK))
+	locations.GET("/:id/price", []fizz.OperationOption{fizz.Summary("Get court price of a session at the location"), fizz.Security(&openapi.SecurityRequirement{
+		"Bearer": []string{},
+	})}, tonic.Handler(r.getCourtPriceHandler, http.StatusOK))
+	locations.GET("/:id/holidays", []fizz.OperationOption{fizz.Summary("Get public holidays in the country of the location"), fizz.Security(&openapi.SecurityRequirement{
+		"Bearer": []string{},
+	})}, tonic.Handler(r.getLocationHolidaysHandler, http.StatusOK))
+
+	partnerCards := api.Group("/partner-cards", "Partner cards", "Sport cards accepted by facilities", authMiddleware)
+	partnerCards.GET("/", []fizz.OperationOption{fizz.Summary("Get list of partner cards")}, tonic.Handler(r.listPartnerCardsHandler, http.StatusOK))
 
 	// Places endpoints
 	placesGroup := api.Group("/places", "Places", "Place search and management", authMiddleware)
@@ -157,9 +234,23 @@ func (r *Router) init(authConf pkg.AuthConfig) {
 	placesGroup.POST("/", []fizz.OperationOption{fizz.Summary("Add a place from Google Places")}, tonic.Handler(r.addPlaceHandler, http.StatusOK))
 
 	// Admin endpoints
-	admin := api.Group("/admin", "Admin", "Admin operations", authMiddleware)
+	admin := api.Group("/admin", "Admin", "Admin operations", authMiddleware, auth.CreateAdminMiddleware(r.db))
 	admin.GET("/facilities", []fizz.OperationOption{fizz.Summary("List all facilities for admin")}, tonic.Handler(r.adminListFacilitiesHandler, http.StatusOK))
 	admin.PUT("/facilities/:facilityId", []fizz.OperationOption{fizz.Summary("Update facility status")}, tonic.Handler(r.adminUpdateFacilityHandler, http.StatusOK))
+	admin.POST("/facilities", []fizz.OperationOption{fizz.Summary("Create a facility")}, tonic.Handler(r.adminCreateFacilityHandler, http.StatusOK))
+	admin.GET("/facilities/duplicates", []fizz.OperationOption{fizz.Summary("List probable duplicate facilities")}, tonic.Handler(r.adminListFacilityDuplicatesHandler, http.StatusOK))
+	admin.POST("/facilities/:facilityId/merge", []fizz.OperationOption{fizz.Summary("Merge a duplicate into the facility")}, tonic.Handler(r.adminMergeFacilityHandler, http.StatusOK))
+	admin.GET("/facilities/:facilityId", []fizz.OperationOption{fizz.Summary("Get facility with court groups and price periods")}, tonic.Handler(r.adminGetFacilityHandler, http.StatusOK))
+	admin.PUT("/facilities/:facilityId/details", []fizz.OperationOption{fizz.Summary("Update facility name, address and links")}, tonic.Handler(r.adminUpdateFacilityDetailsHandler, http.StatusOK))
+	admin.POST("/facilities/:facilityId/court-groups", []fizz.OperationOption{fizz.Summary("Add a court group to a facility")}, tonic.Handler(r.adminCreateCourtGroupHandler, http.StatusOK))
+	admin.PUT("/court-groups/:courtGroupId", []fizz.OperationOption{fizz.Summary("Update a court group")}, tonic.Handler(r.adminUpdateCourtGroupHandler, http.StatusOK))
+	admin.DELETE("/court-groups/:courtGroupId", []fizz.OperationOption{fizz.Summary("Delete a court group with its prices")}, tonic.Handler(r.adminDeleteCourtGroupHandler, http.StatusOK))
+	admin.POST("/court-groups/:courtGroupId/price-periods", []fizz.OperationOption{fizz.Summary("Add a price period with its rules to a court group")}, tonic.Handler(r.adminCreatePricePeriodHandler, http.StatusOK))
+	admin.PUT("/price-periods/:pricePeriodId", []fizz.OperationOption{fizz.Summary("Replace dates and rules of a price period")}, tonic.Handler(r.adminUpdatePricePeriodHandler, http.StatusOK))
+	admin.DELETE("/price-periods/:pricePeriodId", []fizz.OperationOption{fizz.Summary("Delete a price period")}, tonic.Handler(r.adminDeletePricePeriodHandler, http.StatusOK))
+	admin.GET("/holidays/:country", []fizz.OperationOption{fizz.Summary("List holidays and overrides of a country")}, tonic.Handler(r.adminListHolidaysHandler, http.StatusOK))
+	admin.PUT("/holidays/:country/:date", []fizz.OperationOption{fizz.Summary("Add or remove a holiday of a country")}, tonic.Handler(r.adminSetHolidayOverrideHandler, http.StatusOK))
+	admin.DELETE("/holidays/:country/:date", []fizz.OperationOption{fizz.Summary("Delete a holiday override")}, tonic.Handler(r.adminDeleteHolidayOverrideHandler, http.StatusOK))
 
 	// Calendar integration endpoints
 	calendar := api.Group("/calendar", "Calendar", "Google Calendar integration operations", authMiddleware)
@@ -226,8 +317,31 @@ func (r *Router) errorHandler(c *gin.Context) error {
 func (r *Router) listLocationsHandler(c *gin.Context, req *api.ListLocationsRequest) (*api.ListLocationsResponse, error) {
 	ctx := context.Background()
 
+	filter, err := locationsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Near == nil && req.NearProfile {
+		userId := c.GetString(auth.USER_ID_CONTEXT_KEY)
+		profile, err := r.db.GetUserProfile(ctx, userId)
+		if err != nil {
+			slog.Warn("Failed to get user profile, locations are not sorted by distance", "error", err, "userId", userId)
+		} else if center, ok, err := places.GeocodeCity(ctx, r.placesProvider, profile.City); err != nil {
+			slog.Warn("Failed to geocode the city of the profile, locations are not sorted by distance", "error", err, "userId", userId, "city", profile.City)
+		} else if !ok {
+			slog.Info("City of the profile not found, locations are not sorted by distance", "userId", userId, "city", profile.City)
+		} else {
+			filter.Near = &db.GeoRadius{
+				Latitude:  center.Latitude,
+				Longitude: center.Longitude,
+				RadiusKm:  req.RadiusKm,
+			}
+		}
+	}
+
 	// Get facilities from database
-	facilities, err := r.db.GetAllFacilities(ctx)
+	facilities, err := r.db.GetAllFacilities(ctx, filter)
 	if err != nil {
 		slog.Error("Failed to get facilities", "error", err)
 		return nil, HttpError{
@@ -241,6 +355,98 @@ func (r *Router) listLocationsHandler(c *gin.Context, req *api.ListLocationsRequ
 	}, nil
 }
 
+// locationsFilter validates query parameters of the locations list and converts them to a db filter
+func locationsFilter(req *api.ListLocationsRequest) (*db.FacilitiesFilter, error) {
+	filter := &db.FacilitiesFilter{
+		Covered:  req.Covered,
+		Lighting: req.Lighting,
+	}
+
+	for _, card := range req.PartnerCard {
+		if card = strings.TrimSpace(card); card != "" {
+			filter.PartnerCards = append(filter.PartnerCards, card)
+		}
+	}
+
+	for _, surface := range req.Surfaces {
+		switch surface {
+		case api.CourtSurfaceHard, api.CourtSurfaceClay, api.CourtSurfaceArtificialGrass, api.CourtSurfaceCarpet, api.CourtSurfaceGrass:
+			filter.Surfaces = append(filter.Surfaces, surface)
+		default:
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  fmt.Sprintf("Invalid surface: %s", surface),
+			}
+		}
+	}
+
+	if req.RadiusKm < 0 || req.RadiusKm > 500 {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "radiusKm must be between 0 and 500",
+		}
+	}
+
+	if req.Latitude != 0 || req.Longitude != 0 {
+		if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid coordinates",
+			}
+		}
+		filter.Near = &db.GeoRadius{
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			RadiusKm:  req.RadiusKm,
+		}
+	} else if req.RadiusKm > 0 && !req.NearProfile {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "lat and lng are required with radiusKm",
+		}
+	}
+
+	return filter, nil
+}
+
+func (r *Router) listPartnerCardsHandler(c *gin.Context, req *api.ListPartnerCardsRequest) (*api.ListPartnerCardsResponse, error) {
+	cards, err := r.db.GetPartnerCards(context.Background())
+	if err != nil {
+		slog.Error("Failed to get partner cards", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to retrieve partner cards",
+		}
+	}
+
+	return &api.ListPartnerCardsResponse{
+		PartnerCards: cards,
+	}, nil
+}
+
+func (r *Router) getLocationHandler(c *gin.Context, req *api.GetLocationRequest) (*api.GetLocationResponse, error) {
+	logCtx := slog.With("locationId", req.ID)
+
+	details, err := r.db.GetFacilityDetails(context.Background(), req.ID)
+	if err != nil {
+		if _, ok := err.(db.DbObjectNotFoundError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusNotFound,
+				Message:  "Location not found",
+			}
+		}
+		logCtx.Error("Failed to get facility details", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to retrieve location",
+		}
+	}
+
+	return &api.GetLocationResponse{
+		Location: *details,
+	}, nil
+}
+
 // Events
 func (r *Router) createEventHandler(c *gin.Context, req *api.CreateEventRequest) (*api.CreateEventResponse, error) {
 
@@ -262,7 +468,20 @@ func (r *Router) createEventHandler(c *gin.Context, req *api.CreateEventRequest)
 		}
 	}
 
+	if err := applyEventFormat(&req.Event); err != nil {
+		return nil, err
+	}
+
 	req.Event.UserId = userId.(string)
+
+	if err := r.defaultEventLocations(logCtx, &req.Event); err != nil {
+		return nil, err
+	}
+
+	if req.Event.Recurrence != nil {
+		return r.createEventSeries(logCtx, &req.Event)
+	}
+
 	err := r.db.CreateEvent(context.Background(), &req.Event)
 	if err != nil {
 		logCtx.Error("Failed to create event", "error", err, "event", req.Event)
@@ -340,7 +559,13 @@ func (r *Router) listPublicEventsHandler(c *gin.Context, req *api.ListPublicEven
 		userId = userIdVal.(string)
 	}
 
-	events, err := r.db.GetPublicEvents(context.Background(), userId)
+	filter, err := publicEventsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.FavouritesFirst = userId != ""
+
+	events, total, next, err := r.db.GetPublicEvents(context.Background(), userId, filter)
 	if err != nil {
 		slog.Error("Failed to get public events", "error", err, "userId", userId)
 		return nil, HttpError{
@@ -349,10 +574,110 @@ func (r *Router) listPublicEventsHandler(c *gin.Context, req *api.ListPublicEven
 		}
 	}
 
-	return &api.ListEventsResponse{
+	resp := &api.ListEventsResponse{
 		Events: events,
-		Total:  len(events),
-	}, nil
+		Total:  total,
+	}
+	if next != nil {
+		resp.NextCursor = next.Encode()
+	}
+
+	return resp, nil
+}
+
+const (
+	defaultPublicEventsLimit = 50
+	maxPublicEventsLimit     = 100
+)
+
+// publicEventsFilter validates query parameters of the public events search and converts them to a db filter
+func publicEventsFilter(req *api.ListPublicEventsRequest) (*db.PublicEventsFilter, error) {
+	filter := &db.PublicEventsFilter{
+		EventType:   req.EventType,
+		FacilityIds: req.FacilityIds,
+		Text:        strings.TrimSpace(req.Query),
+		Limit:       req.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPublicEventsLimit
+	}
+	filter.Limit = min(filter.Limit, maxPublicEventsLimit)
+
+	for _, level := range req.SkillLevels {
+		switch level {
+		case api.SkillLevelAny, api.SkillLevelBeginner, api.SkillLevelIntermediate, api.SkillLevelAdvanced:
+			filter.SkillLevels = append(filter.SkillLevels, level)
+		default:
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  fmt.Sprintf("Invalid skill level: %s", level),
+			}
+		}
+	}
+
+	if req.DateFrom != "" {
+		dt, err := time.Parse(time.RFC3339, req.DateFrom)
+		if err != nil {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid dateFrom, expected ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)",
+			}
+		}
+		filter.DateFrom = &dt
+	}
+
+	if req.DateTo != "" {
+		dt, err := time.Parse(time.RFC3339, req.DateTo)
+		if err != nil {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid dateTo, expected ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ)",
+			}
+		}
+		filter.DateTo = &dt
+	}
+
+	if filter.DateFrom != nil && filter.DateTo != nil && !filter.DateFrom.Before(*filter.DateTo) {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "dateFrom must be before dateTo",
+		}
+	}
+
+	if req.RadiusKm < 0 || req.RadiusKm > 500 {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "radiusKm must be between 0 and 500",
+		}
+	}
+
+	if req.RadiusKm > 0 {
+		if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid coordinates",
+			}
+		}
+		filter.Near = &db.GeoRadius{
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			RadiusKm:  req.RadiusKm,
+		}
+	}
+
+	if req.Cursor != "" {
+		cursor, err := db.DecodeEventsCursor(req.Cursor)
+		if err != nil {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Invalid cursor",
+			}
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
 }
 
 func (r *Router) getJoinedEvents(userId string) (*api.ListEventsResponse, error) {
@@ -510,6 +835,14 @@ func (r *Router) deleteEventHandler(c *gin.Context, req *api.DeleteEventRequest)
 		}
 	}
 
+	// Joiners and the chat history are kept by cancelling the event instead
+	if len(event.JoinRequests) > 0 {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Cannot delete event with join requests, cancel it instead",
+		}
+	}
+
 	err = r.db.DeleteEvent(context.Background(), userId.(string), req.EventId)
 	if err != nil {
 		if _, ok := err.(db.DbObjectNotFoundError); ok {
@@ -541,7 +874,11 @@ func (r *Router) joinEventHandler(c *gin.Context, req *api.JoinRequestRequest) (
 
 	logCtx := slog.With("userId", userId, "eventId", req.EventId)
 
-	joinRequestId, err := r.db.CreateJoinRequest(context.Background(), req.EventId, userId.(string), &req.JoinRequest)
+	if err := r.checkJoinPermission(logCtx, req.EventId, userId.(string), req.InviteToken); err != nil {
+		return nil, err
+	}
+
+	joinRequestId, status, err := r.db.CreateJoinRequest(context.Background(), req.EventId, userId.(string), &req.JoinRequest)
 	if err != nil {
 		if _, ok := err.(db.DbObjectNotFoundError); ok {
 			return nil, HttpError{
@@ -549,6 +886,12 @@ func (r *Router) joinEventHandler(c *gin.Context, req *api.JoinRequestRequest) (
 				Message:  "Event not found",
 			}
 		}
+		if validationErr, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  validationErr.Message,
+			}
+		}
 		logCtx.Error("Failed to create join request", "error", err)
 		return nil, HttpError{
 			HttpCode: http.StatusInternalServerError,
@@ -567,6 +910,7 @@ func (r *Router) joinEventHandler(c *gin.Context, req *api.JoinRequestRequest) (
 		JoinRequest: api.JoinRequest{
 			JoinRequestData: req.JoinRequest,
 			UserId:          userId.(string),
+			Status:          status,
 			CreatedAt:       api.DtToIso(time.Now()),
 		},
 	}, nil
@@ -608,14 +952,14 @@ func (r *Router) cancelJoinRequest(c *gin.Context, req *api.CancelJoinRequestReq
 		}
 	}
 
-	if event.Status != api.EventStatusOpen {
+	if event.Status != api.EventStatusOpen && event.Status != api.EventStatusConfirmed {
 		return HttpError{
 			HttpCode: http.StatusBadRequest,
 			Message:  "Cannot cancel join request for non-open event",
 		}
 	}
 
-	err = r.db.DeleteJoinRequest(context.Background(), userId.(string), req.JoinRequestId)
+	promotion, err := r.db.CancelJoinRequest(context.Background(), userId.(string), req.JoinRequestId)
 	if err != nil {
 		if _, ok := err.(db.DbObjectNotFoundError); ok {
 			return HttpError{
@@ -623,6 +967,12 @@ func (r *Router) cancelJoinRequest(c *gin.Context, req *api.CancelJoinRequestReq
 				Message:  "Join request not found",
 			}
 		}
+		if validationErr, ok := err.(*db.ValidationError); ok {
+			return HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  validationErr.Message,
+			}
+		}
 		logCtx.Error("Failed to delete join request", "error", err)
 		return HttpError{
 			HttpCode: http.StatusInternalServerError,
@@ -630,6 +980,10 @@ func (r *Router) cancelJoinRequest(c *gin.Context, req *api.CancelJoinRequestReq
 		}
 	}
 
+	if promotion != nil {
+		go r.notifier.WaitlistPromoted(*promotion)
+	}
+
 	return nil
 }
 
@@ -683,7 +1037,25 @@ func (r *Router) confirmEvent(c *gin.Context, req *api.EventConfirmationRequest)
 		}
 	}
 
-	confirmation, err := r.db.ConfirmEvent(context.Background(), userId.(string), req.EventId, req)
+	if len(req.Teams) > 0 {
+		players := []string{event.UserId}
+		for _, joinRequestId := range req.JoinRequestsIds {
+			i := slices.IndexFunc(event.JoinRequests, func(jr *api.JoinRequest) bool { return jr.Id == joinRequestId })
+			if i < 0 {
+				return nil, HttpError{
+					HttpCode: http.StatusBadRequest,
+					Message:  "Some join requests were not found for this event",
+				}
+			}
+			players = append(players, event.JoinRequests[i].UserId)
+		}
+		if err := validateTeams(event.Format, req.Teams, players); err != nil {
+			return nil, err
+		}
+	}
+
+	courtPrice := r.sessionCourtPrice(context.Background(), logCtx, req.LocationId, api.ParseDt(req.DateTime), event.SessionDuration)
+	confirmation, err := r.db.ConfirmEvent(context.Background(), userId.(string), req.EventId, req, courtPrice)
 	if err != nil {
 		if validationErr, ok := err.(*db.ValidationError); ok {
 			return nil, HttpError{
@@ -698,7 +1070,11 @@ func (r *Router) confirmEvent(c *gin.Context, req *api.EventConfirmationRequest)
 		}
 	}
 
-	go r.notifier.EventConfirmed(logCtx, req.EventId, req.JoinRequestsIds, req.DateTime, req.LocationId, userId.(string))
+	// players hear about the confirmation only once the court is booked, a failed reservation is notified instead
+	r.reserveCourt(logCtx, event, confirmation)
+	if confirmation.ReservationStatus != api.ReservationStatusFailed {
+		go r.notifier.EventConfirmed(logCtx, req.EventId, req.JoinRequestsIds, req.DateTime, req.LocationId, userId.(string), req.Teams, confirmation.CourtCost)
+	}
 
 	return &api.EventConfirmationResponse{
 		Confirmation: *confirmation,
@@ -743,9 +1119,30 @@ func (r *Router) getUserProfile(c *gin.Context, userId string) (*api.GetUserProf
 		}
 	}
 
+	playerRating, err := r.db.GetPlayerRating(c, userId)
+	if err != nil {
+		logCtx.Error("Failed to get player rating", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get profile",
+		}
+	}
+
+	homeFacilityId, favourites, err := r.db.GetFavouriteFacilities(c, userId)
+	if err != nil {
+		logCtx.Error("Failed to get favourite facilities", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get profile",
+		}
+	}
+
 	return &api.GetUserProfileResponse{
-		UserId:  userId,
-		Profile: profile,
+		UserId:              userId,
+		Profile:             profile,
+		Rating:              playerRating,
+		HomeFacilityId:      homeFacilityId,
+		FavouriteFacilities: favourites,
 	}, nil
 }
 
@@ -796,6 +1193,12 @@ func (r *Router) createUserProfileHandler(c *gin.Context, req *api.CreateUserPro
 
 	_, profile, err := r.db.CreateUserProfile(c, userId.(string), req)
 	if err != nil {
+		if e, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  e.Message,
+			}
+		}
 		logCtx.Error("Failed to create profile", "error", err)
 		return nil, HttpError{
 			HttpCode: http.StatusInternalServerError,
@@ -829,6 +1232,12 @@ func (r *Router) updateUserProfileHandler(c *gin.Context, req *api.UpdateUserPro
 				Message:  "Profile not found",
 			}
 		}
+		if e, ok := err.(*db.ValidationError); ok {
+			return nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  e.Message,
+			}
+		}
 		logCtx.Error("Failed to update user profile", "error", err)
 		return nil, HttpError{
 			HttpCode: http.StatusInternalServerError,
@@ -965,14 +1374,14 @@ func (r *Router) getMessagesHandler(c *gin.Context, req *api.GetMessagesRequest)
 func (r *Router) searchPlacesHandler(c *gin.Context, req *api.SearchPlacesRequest) (*api.SearchPlacesResponse, error) {
 	logCtx := slog.With("query", req.Query)
 
-	if !r.placesService.IsConfigured() {
+	if r.placesProvider == nil {
 		return nil, HttpError{
 			HttpCode: http.StatusServiceUnavailable,
 			Message:  "Place search is not available",
 		}
 	}
 
-	results, err := r.placesService.SearchPlaces(context.Background(), req.Query, req.Latitude, req.Longitude)
+	results, err := r.placesProvider.SearchPlaces(context.Background(), req.Query, req.Latitude, req.Longitude)
 	if err != nil {
 		logCtx.Error("Failed to search places", "error", err)
 		return nil, HttpError{
@@ -998,6 +1407,47 @@ func (r *Router) searchPlacesHandler(c *gin.Context, req *api.SearchPlacesReques
 	return &api.SearchPlacesResponse{Places: places}, nil
 }
 
+// newPlacesProvider creates the configured places provider, it is nil when place search is not available. Google
+// results are not cached as the Google Maps Platform terms only allow caching place ids.
+// newInviteSigner creates the signer of invite links. Only local development may run without a secret, links then
+// stop working after a restart.
+func newInviteSigner(config *pkg.InviteConfig, local bool) (*crypto.InviteSigner, error) {
+	if config.Secret == "" {
+		if !local {
+			return nil, errors.New("invite secret is not set, set INVITE_SECRET")
+		}
+		slog.Warn("Invite secret is not set, invite links will stop working after a restart")
+	}
+	return crypto.NewInviteSigner(config.Secret)
+}
+
+func newPlacesProvider(config *pkg.PlacesConfig) (places.Provider, error) {
+	var provider places.Provider
+	switch config.Provider {
+	case "", places.ProviderGoogle:
+		google := places.NewGoogleProvider(config.APIKey)
+		if !google.IsConfigured() {
+			slog.Warn("Google Places API key is not set, place search is not available")
+			return nil, nil
+		}
+		return google, nil
+	case places.ProviderOsm:
+		osm, err := places.NewOsmProviderFromFile(config.OsmFile, config.OsmCountry)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("Places are searched in the OpenStreetMap extract", "file", config.OsmFile, "places", osm.Count())
+		provider = osm
+	default:
+		return nil, fmt.Errorf("unknown places provider %q, expected google or osm", config.Provider)
+	}
+
+	if config.CacheSize > 0 {
+		provider = places.NewCachedProvider(provider, config.CacheTtl, config.CacheSize)
+	}
+	return provider, nil
+}
+
 func (r *Router) addPlaceHandler(c *gin.Context, req *api.AddPlaceRequest) (*api.AddPlaceResponse, error) {
 	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
 	if !ok {
@@ -1016,8 +1466,15 @@ func (r *Router) addPlaceHandler(c *gin.Context, req *api.AddPlaceRequest) (*api
 		}
 	}
 
+	if r.placesProvider == nil {
+		return nil, HttpError{
+			HttpCode: http.StatusServiceUnavailable,
+			Message:  "Place search is not available",
+		}
+	}
+
 	// Check if place already exists
-	existing, err := r.db.GetFacilityByGooglePlaceID(context.Background(), req.PlaceID)
+	existing, err := r.db.GetFacilityByPlaceID(context.Background(), r.placesProvider.Name(), req.PlaceID)
 	if err != nil {
 		logCtx.Error("Failed to check existing facility", "error", err)
 		return nil, HttpError{
@@ -1039,15 +1496,8 @@ func (r *Router) addPlaceHandler(c *gin.Context, req *api.AddPlaceRequest) (*api
 		return &api.AddPlaceResponse{Location: existing.Location}, nil
 	}
 
-	// Get details from Google
-	if !r.placesService.IsConfigured() {
-		return nil, HttpError{
-			HttpCode: http.StatusServiceUnavailable,
-			Message:  "Place search is not available",
-		}
-	}
-
-	details, err := r.placesService.GetPlaceDetails(context.Background(), req.PlaceID)
+	// Get details from the places provider
+	details, err := r.placesProvider.GetPlaceDetails(context.Background(), req.PlaceID)
 	if err != nil {
 		logCtx.Error("Failed to get place details", "error", err)
 		return nil, HttpError{
@@ -1061,7 +1511,7 @@ func (r *Router) addPlaceHandler(c *gin.Context, req *api.AddPlaceRequest) (*api
 		context.Background(),
 		facilityID, details.Name, details.Address, details.Country,
 		details.Latitude, details.Longitude,
-		req.PlaceID, userId.(string),
+		r.placesProvider.Name(), req.PlaceID, userId.(string),
 	)
 	if err != nil {
 		logCtx.Error("Failed to create facility", "error", err)
@@ -1087,22 +1537,6 @@ func (r *Router) addPlaceHandler(c *gin.Context, req *api.AddPlaceRequest) (*api
 // Admin handlers
 
 func (r *Router) adminListFacilitiesHandler(c *gin.Context) (*api.AdminListFacilitiesResponse, error) {
-	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
-	if !ok {
-		return nil, HttpError{
-			HttpCode: http.StatusNotFound,
-			Message:  "Not found",
-		}
-	}
-
-	role, err := r.db.GetUserRole(context.Background(), userId.(string))
-	if err != nil || role != "admin" {
-		return nil, HttpError{
-			HttpCode: http.StatusNotFound,
-			Message:  "Not found",
-		}
-	}
-
 	facilities, err := r.db.GetAllFacilitiesAdmin(context.Background())
 	if err != nil {
 		slog.Error("Failed to get facilities for admin", "error", err)
@@ -1116,22 +1550,6 @@ func (r *Router) adminListFacilitiesHandler(c *gin.Context) (*api.AdminListFacil
 }
 
 func (r *Router) adminUpdateFacilityHandler(c *gin.Context, req *api.AdminUpdateFacilityRequest) error {
-	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
-	if !ok {
-		return HttpError{
-			HttpCode: http.StatusNotFound,
-			Message:  "Not found",
-		}
-	}
-
-	role, err := r.db.GetUserRole(context.Background(), userId.(string))
-	if err != nil || role != "admin" {
-		return HttpError{
-			HttpCode: http.StatusNotFound,
-			Message:  "Not found",
-		}
-	}
-
 	if req.Status != "active" && req.Status != "hidden" {
 		return HttpError{
 			HttpCode: http.StatusBadRequest,
@@ -1139,7 +1557,7 @@ func (r *Router) adminUpdateFacilityHandler(c *gin.Context, req *api.AdminUpdate
 		}
 	}
 
-	err = r.db.UpdateFacilityStatus(context.Background(), req.FacilityID, req.Status)
+	err := r.db.UpdateFacilityStatus(context.Background(), req.FacilityID, req.Status)
 	if err != nil {
 		if _, ok := err.(db.DbObjectNotFoundError); ok {
 			return HttpError{
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+)
+
+func Test_publicEventsFilter_Limit(t *testing.T) {
+	for limit, expected := range map[int]int{
+		0:    defaultPublicEventsLimit,
+		-1:   defaultPublicEventsLimit,
+		20:   20,
+		100:  maxPublicEventsLimit,
+		101:  maxPublicEventsLimit,
+		1000: maxPublicEventsLimit,
+	} {
+		filter, err := publicEventsFilter(&api.ListPublicEventsRequest{Limit: limit})
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, filter.Limit, "limit %d", limit)
+		}
+	}
+}
+
+func Test_newInviteSigner(t *testing.T) {
+	_, err := newInviteSigner(&pkg.InviteConfig{}, false)
+	assert.Error(t, err, "secret must be required outside of local development")
+
+	signer, err := newInviteSigner(&pkg.InviteConfig{}, true)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, signer)
+	}
+
+	signer, err = newInviteSigner(&pkg.InviteConfig{Secret: "secret"}, false)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, signer)
+	}
+}
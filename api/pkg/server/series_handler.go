@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xtp-tour/xtp-tour/api/pkg/api"
+	"github.com/xtp-tour/xtp-tour/api/pkg/server/auth"
+)
+
+// createEventSeries materialises every occurrence of a recurring event as a separate event of one series
+func (r *Router) createEventSeries(logCtx *slog.Logger, event *api.EventData) (*api.CreateEventResponse, error) {
+	occurrences, err := event.Recurrence.Occurrences(api.DtFromIsoArray(event.TimeSlots))
+	if err != nil {
+		return nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid recurrence: " + err.Error(),
+		}
+	}
+
+	seriesId, created, err := r.db.CreateEventSeries(context.Background(), event, occurrences)
+	if err != nil {
+		logCtx.Error("Failed to create event series", "error", err, "event", event)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to create event",
+		}
+	}
+
+	resp := &api.CreateEventResponse{
+		Occurrences: make([]*api.Event, len(created)),
+	}
+	for i, data := range created {
+		resp.Occurrences[i] = &api.Event{
+			EventData:   data,
+			Status:      api.EventStatusOpen,
+			SeriesId:    seriesId,
+			SeriesIndex: i + 1,
+		}
+	}
+	resp.Event = resp.Occurrences[0]
+
+	return resp, nil
+}
+
+func (r *Router) updateSeriesEventHandler(c *gin.Context, req *api.UpdateSeriesEventRequest) (*api.SeriesEventsResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "scope", req.Scope)
+
+	if err := validateSeriesEventData(&req.Event); err != nil {
+		return nil, err
+	}
+
+	event, eventIds, err := r.resolveSeriesScope(logCtx, userId.(string), req.EventId, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// occurrences of a series share the format
+	if err := validatePlayersForFormat(event.Format, req.Event.ExpectedPlayers); err != nil {
+		return nil, err
+	}
+
+	err = r.db.UpdateEventsDetails(context.Background(), userId.(string), eventIds, &req.Event)
+	if err != nil {
+		logCtx.Error("Failed to update series events", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to update events",
+		}
+	}
+
+	return r.seriesEventsResponse(logCtx, eventIds)
+}
+
+func (r *Router) cancelSeriesEventHandler(c *gin.Context, req *api.CancelSeriesEventRequest) (*api.SeriesEventsResponse, error) {
+	userId, ok := c.Get(auth.USER_ID_CONTEXT_KEY)
+	if !ok {
+		slog.Info("User ID not found in context")
+		return nil, HttpError{
+			HttpCode: http.StatusUnauthorized,
+			Message:  "User ID not found",
+		}
+	}
+
+	logCtx := slog.With("userId", userId, "eventId", req.EventId, "scope", req.Scope)
+
+	event, eventIds, err := r.resolveSeriesScope(logCtx, userId.(string), req.EventId, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCancelReason(req.Reason); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := r.db.CancelEvents(context.Background(), userId.(string), eventIds, req.Reason)
+	if err != nil {
+		logCtx.Error("Failed to cancel series events", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to cancel events",
+		}
+	}
+
+	for _, cancelledEvent := range cancelled {
+		r.releaseCourt(cancelledEvent.Booking)
+	}
+	go r.notifier.EventsCancelled(userId.(string), event.SeriesId, cancelled, req.Reason, false)
+
+	return r.seriesEventsResponse(logCtx, eventIds)
+}
+
+// resolveSeriesScope returns the user's event and ids of the events affected by the scope:
+// the event itself for THIS, or every open occurrence of its series for SERIES
+func (r *Router) resolveSeriesScope(logCtx *slog.Logger, userId string, eventId string, scope api.SeriesScope) (*api.Event, []string, error) {
+	event, err := r.db.GetMyEvent(context.Background(), userId, eventId)
+	if err != nil {
+		logCtx.Error("Failed to get my event", "error", err)
+		return nil, nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get event",
+		}
+	}
+
+	if event == nil {
+		return nil, nil, HttpError{
+			HttpCode: http.StatusNotFound,
+			Message:  "Event not found",
+		}
+	}
+
+	switch scope {
+	case api.SeriesScopeThis:
+		if event.Status != api.EventStatusOpen {
+			return nil, nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Only open events can be changed",
+			}
+		}
+		return event, []string{event.Id}, nil
+
+	case api.SeriesScopeSeries:
+		if event.SeriesId == "" {
+			return nil, nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Event is not part of a series",
+			}
+		}
+
+		eventIds, err := r.db.GetOpenSeriesEventIds(context.Background(), userId, event.SeriesId)
+		if err != nil {
+			logCtx.Error("Failed to get open series events", "error", err, "seriesId", event.SeriesId)
+			return nil, nil, HttpError{
+				HttpCode: http.StatusInternalServerError,
+				Message:  "Failed to get series events",
+			}
+		}
+
+		if len(eventIds) == 0 {
+			return nil, nil, HttpError{
+				HttpCode: http.StatusBadRequest,
+				Message:  "Series has no open occurrences",
+			}
+		}
+		return event, eventIds, nil
+
+	default:
+		return nil, nil, HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Scope must be THIS or SERIES",
+		}
+	}
+}
+
+// seriesEventsResponse loads the affected events ordered by their position in the series
+func (r *Router) seriesEventsResponse(logCtx *slog.Logger, eventIds []string) (*api.SeriesEventsResponse, error) {
+	events, err := r.db.GetEventsByIds(context.Background(), eventIds)
+	if err != nil {
+		logCtx.Error("Failed to get series events", "error", err)
+		return nil, HttpError{
+			HttpCode: http.StatusInternalServerError,
+			Message:  "Failed to get events",
+		}
+	}
+
+	slices.SortFunc(events, func(a, b *api.Event) int {
+		return a.SeriesIndex - b.SeriesIndex
+	})
+
+	return &api.SeriesEventsResponse{
+		Events: events,
+	}, nil
+}
+
+func validateSeriesEventData(data *api.SeriesEventData) error {
+	switch data.SkillLevel {
+	case api.SkillLevelAny, api.SkillLevelBeginner, api.SkillLevelIntermediate, api.SkillLevelAdvanced:
+	default:
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Invalid skill level",
+		}
+	}
+
+	if data.ExpectedPlayers < 2 || data.ExpectedPlayers > 1000 {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Expected players must be between 2 and 1000",
+		}
+	}
+
+	if data.SessionDuration <= 0 {
+		return HttpError{
+			HttpCode: http.StatusBadRequest,
+			Message:  "Session duration must be positive",
+		}
+	}
+
+	return nil
+}
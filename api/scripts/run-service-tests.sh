@@ -64,6 +64,8 @@ echo "Starting server in background..."
 AUTH_TYPE=debug LOG_LEVEL=debug DB_HOST=127.0.0.1 DB_PORT="${DB_PORT}" \
 	SERVICE_PORT="${SERVICE_PORT}" METRICS_PORT="${METRICS_PORT}" \
 	TOKEN_ENCRYPTION_KEY="${TOKEN_ENCRYPTION_KEY}" \
+	RESERVATION_FAKE_FACILITIES=winners RESERVATION_FAKE_COURTS=1 \
+	COMPLETION_JOBS_INTERVAL=1s \
 	go run cmd/server/main.go &
 SERVER_PID=$!
 
@@ -73,6 +75,7 @@ sleep 2
 echo "Running service tests..."
 set +e
 SERVICE_HOST="http://localhost:${SERVICE_PORT}" METRICS_HOST="http://localhost:${METRICS_PORT}" \
+	DB_HOST=127.0.0.1 DB_PORT="${DB_PORT}" \
 	go test ./test/stest -tags servicetest -v -count=1
 TEST_EXIT=$?
 set -e
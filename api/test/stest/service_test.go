@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
 	"github.com/num30/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/xtp-tour/xtp-tour/api/pkg"
 	"github.com/xtp-tour/xtp-tour/api/pkg/api"
 	"github.com/xtp-tour/xtp-tour/api/pkg/db"
 )
@@ -22,6 +25,8 @@ type TestConfig struct {
 	LogLevel    string `default:"info" envvar:"LOG_LEVEL"`
 	ServiceHost string `default:"http://localhost:8080" envvar:"SERVICE_HOST"`
 	MetricsHost string `default:"http://localhost:10250" envvar:"METRICS_HOST"`
+	// Db is the database of the service, used to arrange states the API cannot reach such as games played in the past
+	Db pkg.DbConfig
 }
 
 var tConfig = &TestConfig{}
@@ -50,6 +55,141 @@ func getRelativeTimeSlots() []string {
 	}
 }
 
+// openTestDb connects to the database of the service
+func openTestDb(t *testing.T) *sqlx.DB {
+	conn, err := sqlx.Open("mysql", tConfig.Db.GetConnectionString())
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// createProfile creates a profile of the user, needed for anything stored per user such as ratings
+func createProfile(t *testing.T, userId string) {
+	r, err := restClient.R().
+		SetHeader("Authentication", userId).
+		SetBody(api.CreateUserProfileRequest{
+			UserProfileData: api.UserProfileData{
+				FirstName: userId,
+				LastName:  "Doe",
+				NTRPLevel: 3.5,
+				City:      "Iktslan",
+				Notifications: api.NotificationSettings{
+					DebugAddress: userId + "@xtp-tour-debug.com",
+					Channels:     db.NotificationChannelDebug,
+				},
+			},
+		}).
+		Post(tConfig.ServiceHost + "/api/profiles/")
+	if err != nil || r.StatusCode() != http.StatusOK {
+		t.Fatalf("Failed to create profile: %v %s", err, string(r.Body()))
+	}
+}
+
+// createAdmin creates a profile of the user with the admin role, admins are only made in the database
+func createAdmin(t *testing.T, userId string) {
+	createProfile(t, userId)
+	if _, err := openTestDb(t).Exec(`UPDATE users SET role = 'admin' WHERE uid = ?`, userId); err != nil {
+		t.Fatalf("Failed to make user admin: %v", err)
+	}
+}
+
+// createConfirmedMatch creates profiles of the host and the players, a public match of the host for all of them,
+// lets the players join it and confirms it with all of them. Teams make it a doubles match. Returns id of the event.
+func createConfirmedMatch(t *testing.T, host string, players []string, teams [][]string) string {
+	for _, userId := range append([]string{host}, players...) {
+		createProfile(t, userId)
+	}
+
+	slot := getRelativeDate(5, 12)
+	format := api.SingleDoubleType("")
+	if teams != nil {
+		format = api.SingleDoubleTypeDoubles
+	}
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{Event: api.EventData{
+			Locations:       []string{"matchpoint"},
+			SkillLevel:      api.SkillLevelAny,
+			EventType:       api.ActivityTypeMatch,
+			ExpectedPlayers: len(players) + 1,
+			Format:          format,
+			SessionDuration: 60,
+			TimeSlots:       []string{slot},
+			Visibility:      api.EventVisibilityPublic,
+		}}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if err != nil || r.StatusCode() != http.StatusOK {
+		t.Fatalf("Failed to create event: %v %s", err, string(r.Body()))
+	}
+
+	var joinRequestIds []string
+	for _, player := range players {
+		var joinResp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: []string{slot},
+				},
+			}).
+			SetResult(&joinResp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + created.Event.Id + "/joins")
+		if err != nil || r.StatusCode() != http.StatusOK {
+			t.Fatalf("Failed to join event: %v %s", err, string(r.Body()))
+		}
+		joinRequestIds = append(joinRequestIds, joinResp.JoinRequest.Id)
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.EventConfirmationRequest{
+			LocationId:      "matchpoint",
+			DateTime:        slot,
+			JoinRequestsIds: joinRequestIds,
+			Teams:           teams,
+		}).
+		Post(tConfig.ServiceHost + "/api/events/" + created.Event.Id + "/confirmation")
+	if err != nil || r.StatusCode() != http.StatusOK {
+		t.Fatalf("Failed to confirm event: %v %s", err, string(r.Body()))
+	}
+
+	return created.Event.Id
+}
+
+// completeEvent moves the confirmed session of the event into the past and waits until the completion worker
+// of the service completes the event
+func completeEvent(t *testing.T, conn *sqlx.DB, host string, eventId string) *api.Event {
+	_, err := conn.Exec(`UPDATE confirmations SET dt = ? WHERE event_id = ?`, time.Now().UTC().Add(-3*time.Hour), eventId)
+	if err != nil {
+		t.Fatalf("Failed to move the session into the past: %v", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		var resp api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if err != nil || r.StatusCode() != http.StatusOK {
+			t.Fatalf("Failed to get event: %v %s", err, string(r.Body()))
+		}
+		if resp.Event.Status == api.EventStatusCompleted {
+			return resp.Event
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Event was not completed, status %s", resp.Event.Status)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
 func Test_Ping(t *testing.T) {
 	t.Run("Ping", func(t *testing.T) {
 		r, err := restClient.R().Get(tConfig.ServiceHost + "/api/ping")
@@ -511,978 +651,3353 @@ func Test_DeleteEvent(t *testing.T) {
 	})
 }
 
-// Test Locations API
-func Test_LocationsAPI(t *testing.T) {
-	testUserId := "test-user-123"
-
-	t.Run("ListLocations", func(tt *testing.T) {
-		r, err := restClient.R().
-			SetHeader("Authentication", testUserId).
-			Get(tConfig.ServiceHost + "/api/locations/")
+func Test_RecurringEventSeries(t *testing.T) {
+	user := "test-user-series"
+
+	eventData := api.CreateEventRequest{
+		Event: api.EventData{
+			Locations:       []string{"matchpoint"},
+			SkillLevel:      api.SkillLevelIntermediate,
+			EventType:       api.ActivityTypeMatch,
+			ExpectedPlayers: 2,
+			SessionDuration: 90,
+			TimeSlots:       []string{getRelativeDate(3, 18)},
+			Visibility:      api.EventVisibilityPublic,
+			Recurrence: &api.Recurrence{
+				Frequency: api.RecurrenceFrequencyWeekly,
+				Count:     3,
+			},
+		},
+	}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			assert.Contains(tt, string(r.Body()), `"locations":`)
-		}
-	})
-}
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", user).
+		SetBody(eventData).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
 
-// Test Profile API endpoints
-func Test_ProfileAPI(t *testing.T) {
-	timestamp := time.Now().UnixNano()
-	testUser := fmt.Sprintf("test-%d", timestamp%1000000) // Keep it short for VARCHAR(36)
-	anotherUser := fmt.Sprintf("user-%d", timestamp%1000000)
+	if !assert.Len(t, created.Occurrences, 3) {
+		return
+	}
+	assert.NotEmpty(t, created.Event.SeriesId)
+	assert.Equal(t, created.Event.Id, created.Occurrences[0].Id)
+	assert.Equal(t, getRelativeDate(10, 18), created.Occurrences[1].TimeSlots[0])
 
-	// Test getting profile when it doesn't exist
-	t.Run("GetNonExistentProfile", func(tt *testing.T) {
+	t.Run("UpdateThisOccurrence", func(tt *testing.T) {
+		var resp api.SeriesEventsResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			Get(tConfig.ServiceHost + "/api/profiles/me")
-
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			SetHeader("Authentication", user).
+			SetBody(api.UpdateSeriesEventRequest{
+				Scope: api.SeriesScopeThis,
+				Event: api.SeriesEventData{
+					SkillLevel:      api.SkillLevelAdvanced,
+					Description:     "Only this week",
+					ExpectedPlayers: 2,
+					SessionDuration: 90,
+				},
+			}).
+			SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/events/" + created.Occurrences[1].Id + "/series")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Len(tt, resp.Events, 1)
+			assert.Equal(tt, "Only this week", resp.Events[0].Description)
 		}
 	})
 
-	// Create a profile
-	t.Run("CreateProfile", func(tt *testing.T) {
-		profileData := api.CreateUserProfileRequest{
-			UserProfileData: api.UserProfileData{
-				FirstName: "John",
-				LastName:  "Doe",
-				NTRPLevel: 4.5,
-				Language:  "en",
-				Country:   "PL",
-				City:      "Warsaw",
-				Notifications: api.NotificationSettings{
-					DebugAddress: "test-user-123@example.com",
-					Channels:     db.NotificationChannelDebug,
+	t.Run("UpdateWholeSeries", func(tt *testing.T) {
+		var resp api.SeriesEventsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user).
+			SetBody(api.UpdateSeriesEventRequest{
+				Scope: api.SeriesScopeSeries,
+				Event: api.SeriesEventData{
+					SkillLevel:      api.SkillLevelBeginner,
+					Description:     "Weekly match",
+					ExpectedPlayers: 2,
+					SessionDuration: 60,
 				},
-			},
+			}).
+			SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/events/" + created.Event.Id + "/series")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Len(tt, resp.Events, 3)
+			for i, e := range resp.Events {
+				assert.Equal(tt, i+1, e.SeriesIndex)
+				assert.Equal(tt, "Weekly match", e.Description)
+			}
 		}
+	})
 
-		var response api.CreateUserProfileResponse
+	t.Run("CancelThisOccurrence", func(tt *testing.T) {
+		var resp api.SeriesEventsResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetBody(profileData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/profiles/")
-
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, testUser, response.UserId)
-				assert.Equal(tt, "John", response.Profile.FirstName)
-				assert.Equal(tt, "Doe", response.Profile.LastName)
-				assert.Equal(tt, 4.5, response.Profile.NTRPLevel)
-				assert.Equal(tt, "Warsaw", response.Profile.City)
-			}
+			SetHeader("Authentication", user).
+			SetBody(api.CancelSeriesEventRequest{Scope: api.SeriesScopeThis}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + created.Occurrences[2].Id + "/series/cancel")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Len(tt, resp.Events, 1)
+			assert.Equal(tt, api.EventStatusCancelled, resp.Events[0].Status)
 		}
 	})
 
-	// Get own profile
-	t.Run("GetOwnProfile", func(tt *testing.T) {
-		var response api.GetUserProfileResponse
+	t.Run("CancelWholeSeries", func(tt *testing.T) {
+		var resp api.SeriesEventsResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/profiles/me")
-
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, testUser, response.UserId)
-				assert.Equal(tt, "John", response.Profile.FirstName)
-				assert.Equal(tt, "Doe", response.Profile.LastName)
-				assert.Equal(tt, 4.5, response.Profile.NTRPLevel)
-				assert.Equal(tt, "Warsaw", response.Profile.City)
+			SetHeader("Authentication", user).
+			SetBody(api.CancelSeriesEventRequest{Scope: api.SeriesScopeSeries}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + created.Event.Id + "/series/cancel")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			// the third occurrence is already cancelled
+			assert.Len(tt, resp.Events, 2)
+			for _, e := range resp.Events {
+				assert.Equal(tt, api.EventStatusCancelled, e.Status)
 			}
 		}
 	})
+}
 
-	// Update profile
-	t.Run("UpdateProfile", func(tt *testing.T) {
-		profileData := api.UpdateUserProfileRequest{
-			UserProfileData: api.UserProfileData{
-				FirstName: "Jane",
-				LastName:  "Smith",
-				NTRPLevel: 3.5,
-				City:      "Krakow",
-				Notifications: api.NotificationSettings{
-					DebugAddress: "test-user-123@example.com",
-					Channels:     db.NotificationChannelDebug,
-				},
+func Test_UpdateEvent(t *testing.T) {
+	host := "test-user-edit-host"
+	joiner := "test-user-edit-joiner"
+	keptSlot := getRelativeDate(4, 18)
+	droppedSlot := getRelativeDate(5, 18)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 90,
+				TimeSlots:       []string{keptSlot, droppedSlot},
+				Visibility:      api.EventVisibilityPublic,
 			},
-		}
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
 
-		var response api.UpdateUserProfileResponse
-		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetBody(profileData).
-			SetResult(&response).
-			Put(tConfig.ServiceHost + "/api/profiles/me")
+	r, err = restClient.R().
+		SetHeader("Authentication", joiner).
+		SetBody(api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{keptSlot, droppedSlot},
+			},
+		}).
+		Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
 
+	t.Run("NotOwner", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", joiner).
+			SetBody(api.UpdateEventRequest{
+				Event: api.UpdateEventData{
+					Locations:  []string{"matchpoint"},
+					SkillLevel: api.SkillLevelAdvanced,
+					TimeSlots:  []string{keptSlot},
+				},
+			}).
+			Put(tConfig.ServiceHost + "/api/events/" + eventId)
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, testUser, response.UserId)
-				assert.Equal(tt, "Jane", response.Profile.FirstName)
-				assert.Equal(tt, "Smith", response.Profile.LastName)
-				assert.Equal(tt, 3.5, response.Profile.NTRPLevel)
-				assert.Equal(tt, "Krakow", response.Profile.City)
-			}
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Verify profile was updated
-	t.Run("GetUpdatedProfile", func(tt *testing.T) {
-		var response api.GetUserProfileResponse
+	t.Run("DropTimeSlot", func(tt *testing.T) {
+		var resp api.UpdateEventResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/profiles/me")
+			SetHeader("Authentication", host).
+			SetBody(api.UpdateEventRequest{
+				Event: api.UpdateEventData{
+					Locations:   []string{"matchpoint"},
+					SkillLevel:  api.SkillLevelAdvanced,
+					Description: "Moved to one evening",
+					TimeSlots:   []string{keptSlot},
+				},
+			}).
+			SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/events/" + eventId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, testUser, response.UserId)
-				assert.Equal(tt, "Jane", response.Profile.FirstName)
-				assert.Equal(tt, "Smith", response.Profile.LastName)
-				assert.Equal(tt, 3.5, response.Profile.NTRPLevel)
-				assert.Equal(tt, "Krakow", response.Profile.City)
-			}
+		assert.Equal(tt, api.SkillLevelAdvanced, resp.Event.SkillLevel)
+		assert.Equal(tt, []string{keptSlot}, resp.Event.TimeSlots)
+		if assert.Len(tt, resp.Event.JoinRequests, 1) {
+			assert.Equal(tt, []string{keptSlot}, resp.Event.JoinRequests[0].TimeSlots)
 		}
 	})
 
-	// Create another user's profile for testing profile lookup
-	t.Run("CreateAnotherUserProfile", func(tt *testing.T) {
-		profileData := api.CreateUserProfileRequest{
-
-			UserProfileData: api.UserProfileData{
-				FirstName: "Bob",
-				LastName:  "Johnson",
-				NTRPLevel: 5.0,
-				City:      "Gdansk",
-				Notifications: api.NotificationSettings{
-					DebugAddress: "test-user-123@example.com",
-					Channels:     db.NotificationChannelDebug,
+	update := func(tt *testing.T, locations []string) *api.Event {
+		var resp api.UpdateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.UpdateEventRequest{
+				Event: api.UpdateEventData{
+					Locations:  locations,
+					SkillLevel: api.SkillLevelAdvanced,
+					TimeSlots:  []string{keptSlot},
 				},
-			},
+			}).
+			SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/events/" + eventId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
 		}
+		return resp.Event
+	}
 
-		var response api.CreateUserProfileResponse
-		r, err := restClient.R().
-			SetHeader("Authentication", anotherUser).
-			SetBody(profileData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/profiles/")
+	t.Run("DropAllLocations", func(tt *testing.T) {
+		// the join request is kept as cancelled together with its choices
+		event := update(tt, []string{"spartan-pultuska"})
+		if event == nil || !assert.Len(tt, event.JoinRequests, 1) {
+			return
+		}
+		assert.Equal(tt, api.JoinRequestStatusCancelled, event.JoinRequests[0].Status)
+		assert.Equal(tt, []string{"matchpoint"}, event.JoinRequests[0].Locations)
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, anotherUser, response.UserId)
-				assert.Equal(tt, "Bob", response.Profile.FirstName)
-			}
+		// cancelled requests are not reconciled anymore
+		event = update(tt, []string{"krzycka-park"})
+		if event != nil && assert.Len(tt, event.JoinRequests, 1) {
+			assert.Equal(tt, api.JoinRequestStatusCancelled, event.JoinRequests[0].Status)
+			assert.Equal(tt, []string{"matchpoint"}, event.JoinRequests[0].Locations)
 		}
 	})
+}
 
-	// Get another user's profile by user ID
-	t.Run("GetOtherUserProfile", func(tt *testing.T) {
-		var response api.GetUserProfileResponse
-		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/profiles/" + anotherUser)
+func Test_UpdateEventPromotesWaitlisted(t *testing.T) {
+	host := "test-user-edit-waitlist-host"
+	first := "test-user-edit-waitlist-first"
+	second := "test-user-edit-waitlist-second"
+	droppedSlot := getRelativeDate(8, 20)
+	keptSlot := getRelativeDate(10, 20)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       []string{droppedSlot, keptSlot},
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, anotherUser, response.UserId)
-				assert.Equal(tt, "Bob", response.Profile.FirstName)
-				assert.Equal(tt, "Johnson", response.Profile.LastName)
-				assert.Equal(tt, 5.0, response.Profile.NTRPLevel)
-				assert.Equal(tt, "Gdansk", response.Profile.City)
-			}
+	join := func(userId string, timeSlots []string) *api.JoinRequest {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: timeSlots,
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
 		}
-	})
+		return &resp.JoinRequest
+	}
 
-	// Test getting profile of non-existent user
-	t.Run("GetNonExistentUserProfile", func(tt *testing.T) {
-		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			Get(tConfig.ServiceHost + "/api/profiles/non-existent-user")
+	firstRequest := join(first, []string{droppedSlot})
+	secondRequest := join(second, []string{droppedSlot, keptSlot})
+	if firstRequest == nil || secondRequest == nil {
+		return
+	}
+	assert.Equal(t, api.JoinRequestStatusWaiting, firstRequest.Status)
+	assert.Equal(t, api.JoinRequestStatusWaitlisted, secondRequest.Status)
+
+	var resp api.UpdateEventResponse
+	r, err = restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.UpdateEventRequest{
+			Event: api.UpdateEventData{
+				Locations:  []string{"matchpoint"},
+				SkillLevel: api.SkillLevelAny,
+				TimeSlots:  []string{keptSlot},
+			},
+		}).
+		SetResult(&resp).
+		Put(tConfig.ServiceHost + "/api/events/" + eventId)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-		}
-	})
+	statuses := map[string]api.JoinRequestStatus{}
+	for _, jr := range resp.Event.JoinRequests {
+		statuses[jr.Id] = jr.Status
+	}
+	assert.Equal(t, api.JoinRequestStatusCancelled, statuses[firstRequest.Id])
+	assert.Equal(t, api.JoinRequestStatusWaiting, statuses[secondRequest.Id], "Waitlisted player should take the freed place")
+}
 
-	// Test updating profile with invalid NTRP level
-	t.Run("UpdateProfileInvalidNTRP", func(tt *testing.T) {
-		profileData := api.UpdateUserProfileRequest{
-			UserProfileData: api.UserProfileData{
-				FirstName: "Invalid",
-				LastName:  "NTRP",
-				NTRPLevel: 10.0, // Invalid - NTRP should be 1.0-7.0
-				City:      "Test City",
+func Test_CancelEvent(t *testing.T) {
+	host := "test-user-cancel-host"
+	joiner := "test-user-cancel-joiner"
+	slot := getRelativeDate(6, 18)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       []string{slot},
+				Visibility:      api.EventVisibilityPublic,
 			},
-		}
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
 
-		r, err := restClient.R().
-			SetHeader("Authentication", testUser).
-			SetBody(profileData).
-			Put(tConfig.ServiceHost + "/api/profiles/me")
+	r, err = restClient.R().
+		SetHeader("Authentication", joiner).
+		SetBody(api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{slot},
+			},
+		}).
+		Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", joiner).
+		SetBody(api.CreateMessageRequest{MessageText: "See you there"}).
+		Post(tConfig.ServiceHost + "/api/events/" + eventId + "/chat/messages")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
 
+	t.Run("DeleteWithJoinRequestsFails", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			Delete(tConfig.ServiceHost + "/api/events/" + eventId)
 		if assert.NoError(tt, err) {
-			// Database constraint should reject invalid NTRP level (10.0 is outside 1.0-7.0 range)
-			assert.Equal(tt, http.StatusInternalServerError, r.StatusCode(),
-				"Invalid NTRP level should result in database constraint error. Response body: %s", string(r.Body()))
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Test updating profile of non-existent user
-	t.Run("UpdateNonExistentUserProfile", func(tt *testing.T) {
-		profileData := api.UpdateUserProfileRequest{
-			UserProfileData: api.UserProfileData{
-				FirstName: "Non",
-				LastName:  "Existent",
-				NTRPLevel: 3.0,
-				City:      "No City",
-			},
-		}
-
+	t.Run("Cancel", func(tt *testing.T) {
+		var resp api.CancelEventResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", "non-existent-user-123").
-			SetBody(profileData).
-			Put(tConfig.ServiceHost + "/api/profiles/me")
+			SetHeader("Authentication", host).
+			SetBody(api.CancelEventRequest{Reason: "Court is flooded"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/cancellation")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		assert.Equal(tt, api.EventStatusCancelled, resp.Event.Status)
+		assert.Equal(tt, "Court is flooded", resp.Event.CancelReason)
+		assert.NotEmpty(tt, resp.Event.CancelledAt)
+		if assert.Len(tt, resp.Event.JoinRequests, 1) {
+			assert.Equal(tt, api.JoinRequestStatusCancelled, resp.Event.JoinRequests[0].Status)
 		}
 	})
 
-	// Test creating profile with minimal data (within valid constraints)
-	t.Run("CreateProfileMinimalData", func(tt *testing.T) {
-		testUserMinimal := fmt.Sprintf("min-%d", timestamp%1000000)
-		profileData := api.CreateUserProfileRequest{
-
-			UserProfileData: api.UserProfileData{
-				FirstName: "",
-				LastName:  "",
-				NTRPLevel: 1.0, // Minimum valid NTRP level
-				City:      "",
-			},
+	t.Run("CancelTwiceFails", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CancelEventRequest{}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/cancellation")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
+	})
 
-		var response api.CreateUserProfileResponse
+	t.Run("ChatHistoryKept", func(tt *testing.T) {
+		var resp api.GetMessagesResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", testUserMinimal).
-			SetBody(profileData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/profiles/")
-
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Profile) {
-				assert.Equal(tt, testUserMinimal, response.UserId)
-			}
+			SetHeader("Authentication", joiner).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/public/" + eventId + "/chat/messages")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Len(tt, resp.Messages, 1)
 		}
 	})
 }
 
-// Test private events functionality
-func Test_PrivateEventAPI(t *testing.T) {
-	// Use simple string user IDs (similar to Test_CancelJoinRequest)
-	user := "private-event-owner"
-	user2 := "private-event-joiner"
-
-	timeSlots := getRelativeTimeSlots()
-	var privateEventId string
+func Test_Waitlist(t *testing.T) {
+	host := "test-user-waitlist-host"
+	first := "test-user-waitlist-first"
+	second := "test-user-waitlist-second"
+	slot := getRelativeDate(7, 18)
 
-	// Create a private event
-	t.Run("CreatePrivateEvent", func(tt *testing.T) {
-		eventData := api.CreateEventRequest{
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
 			Event: api.EventData{
-				Locations:       []string{"matchpoint", "spartan-pultuska"},
-				SkillLevel:      api.SkillLevelIntermediate,
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelAny,
 				EventType:       api.ActivityTypeMatch,
 				ExpectedPlayers: 2,
 				SessionDuration: 60,
-				TimeSlots:       timeSlots,
-				Description:     "Private event - invite only",
-				Visibility:      api.EventVisibilityPrivate, // PRIVATE event
+				TimeSlots:       []string{slot},
+				Visibility:      api.EventVisibilityPublic,
 			},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+
+	join := func(userId string) *api.JoinRequest {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: []string{slot},
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
 		}
+		return &resp.JoinRequest
+	}
 
-		var response api.CreateEventResponse
+	firstRequest := join(first)
+	secondRequest := join(second)
+	if firstRequest == nil || secondRequest == nil {
+		return
+	}
+	assert.Equal(t, api.JoinRequestStatusWaiting, firstRequest.Status)
+	assert.Equal(t, api.JoinRequestStatusWaitlisted, secondRequest.Status, "Requests beyond capacity should be waitlisted")
 
+	r, err = restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.EventConfirmationRequest{
+			LocationId:      "matchpoint",
+			DateTime:        slot,
+			JoinRequestsIds: []string{firstRequest.Id},
+		}).
+		Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	t.Run("AcceptedPlayerCancels", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", user).
-			SetBody(eventData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/")
+			SetHeader("Authentication", first).
+			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/" + firstRequest.Id)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Event) {
-				privateEventId = response.Event.Id
-				assert.Equal(tt, api.EventVisibilityPrivate, response.Event.Visibility, "Event should be private")
-			}
+		var resp api.GetEventResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		assert.Equal(tt, api.EventStatusConfirmed, resp.Event.Status)
+		statuses := map[string]api.JoinRequestStatus{}
+		for _, jr := range resp.Event.JoinRequests {
+			statuses[jr.Id] = jr.Status
 		}
+		assert.Equal(tt, api.JoinRequestStatusCancelled, statuses[firstRequest.Id])
+		assert.Equal(tt, api.JoinRequestStatusAccepted, statuses[secondRequest.Id], "Waitlisted player should be promoted")
 	})
+}
 
-	t.Logf("Private Event ID: %s", privateEventId)
+func Test_AnswerJoinRequest(t *testing.T) {
+	host := "test-user-answer-host"
+	first := "test-user-answer-first"
+	second := "test-user-answer-second"
+	slot := getRelativeDate(7, 10)
 
-	// Verify private event does NOT appear in public events list
-	t.Run("PrivateEventNotInPublicList", func(tt *testing.T) {
-		var response api.ListEventsResponse
-		r, err := restClient.R().
-			SetHeader("Authentication", user2).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/public")
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       []string{slot},
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			found := slices.ContainsFunc(response.Events, func(e *api.Event) bool {
-				return e.Id == privateEventId
-			})
-			assert.False(tt, found, "Private event should NOT appear in public events list")
+	joinRequestIds := map[string]string{}
+	for _, userId := range []string{first, second} {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: []string{slot},
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
 		}
-	})
+		joinRequestIds[userId] = resp.JoinRequest.Id
+	}
 
-	// Verify private event CAN be accessed via direct link
-	t.Run("PrivateEventAccessibleViaDirectLink", func(tt *testing.T) {
-		var response api.GetEventResponse
+	answer := func(userId string, joinRequestId string, action string, message string) (*resty.Response, *api.JoinRequestResponse, error) {
+		var resp api.JoinRequestResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", user2).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/public/" + privateEventId)
+			SetHeader("Authentication", userId).
+			SetBody(api.AnswerJoinRequestRequest{Message: message}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/joins/" + joinRequestId + "/" + action)
+		return r, &resp, err
+	}
 
+	t.Run("OnlyHostCanAnswer", func(tt *testing.T) {
+		r, _, err := answer(second, joinRequestIds[first], "rejection", "")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Private event should be accessible via direct link. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Event) {
-				assert.Equal(tt, privateEventId, response.Event.Id)
-				assert.Equal(tt, api.EventVisibilityPrivate, response.Event.Visibility)
-			}
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Verify user can join private event via direct link
-	t.Run("JoinPrivateEvent", func(tt *testing.T) {
-		if privateEventId == "" {
-			assert.Fail(tt, "Event ID is not available")
+	t.Run("RejectPromotesWaitlisted", func(tt *testing.T) {
+		r, resp, err := answer(host, joinRequestIds[first], "rejection", "Looking for a stronger partner")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
 			return
 		}
+		assert.Equal(tt, api.JoinRequestStatusRejected, resp.JoinRequest.Status)
+		assert.Equal(tt, "Looking for a stronger partner", resp.JoinRequest.HostMessage)
 
-		joinRequestData := api.JoinRequestRequest{
-			JoinRequest: api.JoinRequestData{
-				Locations: []string{"matchpoint"},
-				TimeSlots: []string{timeSlots[0]},
-				Comment:   "Joining private event via link",
-			},
+		var event api.GetEventResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&event).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			for _, jr := range event.Event.JoinRequests {
+				if jr.Id == joinRequestIds[second] {
+					assert.Equal(tt, api.JoinRequestStatusWaiting, jr.Status, "Waitlisted request should take the freed place")
+				}
+			}
+		}
+	})
+
+	t.Run("Accept", func(tt *testing.T) {
+		r, resp, err := answer(host, joinRequestIds[second], "acceptance", "")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, api.JoinRequestStatusAccepted, resp.JoinRequest.Status)
 		}
+	})
 
+	t.Run("ConfirmRejectedFails", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", user2).
-			SetBody(joinRequestData).
-			Post(tConfig.ServiceHost + "/api/events/public/" + privateEventId + "/joins")
-
+			SetHeader("Authentication", host).
+			SetBody(api.EventConfirmationRequest{
+				LocationId:      "matchpoint",
+				DateTime:        slot,
+				JoinRequestsIds: []string{joinRequestIds[first]},
+			}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Should be able to join private event. Response body: %s", string(r.Body()))
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Verify host can see private event in their events list
-	t.Run("HostCanSeePrivateEventInMyEvents", func(tt *testing.T) {
-		var response api.ListEventsResponse
+	t.Run("ConfirmAccepted", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", user).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/")
-
+			SetHeader("Authentication", host).
+			SetBody(api.EventConfirmationRequest{
+				LocationId:      "matchpoint",
+				DateTime:        slot,
+				JoinRequestsIds: []string{joinRequestIds[second]},
+			}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			found := slices.ContainsFunc(response.Events, func(e *api.Event) bool {
-				return e.Id == privateEventId
-			})
-			assert.True(tt, found, "Host should see private event in their events list")
 		}
 	})
 
-	// Verify private event has join request from user2
-	t.Run("PrivateEventHasJoinRequest", func(tt *testing.T) {
-		var response api.GetEventResponse
+	t.Run("AnswerAfterConfirmationFails", func(tt *testing.T) {
+		r, _, err := answer(host, joinRequestIds[first], "acceptance", "")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+// Test Locations API
+func Test_LocationsAPI(t *testing.T) {
+	testUserId := "test-user-123"
+
+	t.Run("ListLocations", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", user).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/" + privateEventId)
+			SetHeader("Authentication", testUserId).
+			Get(tConfig.ServiceHost + "/api/locations/")
 
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			assert.Len(tt, response.Event.JoinRequests, 1, "Private event should have one join request")
+			assert.Contains(tt, string(r.Body()), `"locations":`)
 		}
 	})
-}
-
-func Test_CancelJoinRequest(t *testing.T) {
-	usrOwner := "join-event-owner"
-	usrJoiner := "join-event-joiner"
-	timeSlots := getRelativeTimeSlots()
-	var eventId string
-	var joinRequestId string
 
-	// Create an event
-	t.Run("CreateEvent", func(tt *testing.T) {
-		eventData := api.CreateEventRequest{
-			Event: api.EventData{
-				Locations:       []string{"matchpoint"},
-				SkillLevel:      api.SkillLevelIntermediate,
-				EventType:       api.ActivityTypeMatch,
-				ExpectedPlayers: 2,
-				SessionDuration: 60,
-				TimeSlots:       timeSlots,
-				Description:     "Test event for join request cancellation",
-				Visibility:      api.EventVisibilityPublic,
-			},
+	listIds := func(tt *testing.T, query string) []string {
+		var resp api.ListLocationsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", testUserId).
+			SetQueryString(query).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
 		}
+		ids := []string{}
+		for _, location := range resp.Locations {
+			ids = append(ids, location.ID)
+		}
+		return ids
+	}
 
-		var response api.CreateEventResponse
+	t.Run("FilterBySurface", func(tt *testing.T) {
+		ids := listIds(tt, "surface=clay")
+		assert.Contains(tt, ids, "krzycka-park")
+		assert.Contains(tt, ids, "spartan-pultuska")
+		assert.NotContains(tt, ids, "matchpoint")
+	})
 
-		r, err := restClient.R().
-			SetHeader("Authentication", usrOwner).
-			SetBody(eventData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/")
+	t.Run("FilterBySameCourtGroup", func(tt *testing.T) {
+		// spartan-pultuska has outdoor clay courts and a tent with hard courts only
+		ids := listIds(tt, "surface=clay&covered=true")
+		assert.Contains(tt, ids, "krzycka-park")
+		assert.NotContains(tt, ids, "spartan-pultuska")
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Event) {
-				eventId = response.Event.Id
-			}
-		}
+		ids = listIds(tt, "covered=true&lighting=true")
+		assert.NotContains(tt, ids, "krzycka-park")
 	})
 
-	// Join the event
-	t.Run("JoinEvent", func(tt *testing.T) {
-		if eventId == "" {
-			assert.Fail(tt, "Event ID is not available")
-			return
-		}
+	t.Run("FilterByPartnerCard", func(tt *testing.T) {
+		ids := listIds(tt, "partnerCard=medicover")
+		assert.Contains(tt, ids, "spartan-pultuska")
+		assert.NotContains(tt, ids, "krzycka-park")
+		assert.NotContains(tt, ids, "matchpoint")
 
-		joinRequestData := api.JoinRequestRequest{
-			JoinRequest: api.JoinRequestData{
-				Locations: []string{"matchpoint"},
-				TimeSlots: []string{timeSlots[0]},
-				Comment:   "I want to join",
-			},
-		}
+		ids = listIds(tt, "partnerCard=medicover&partnerCard=multisport-classic")
+		assert.Contains(tt, ids, "spartan-pultuska")
+		assert.Contains(tt, ids, "krzycka-park")
+	})
 
-		var response api.JoinRequestResponse
+	t.Run("InvalidSurface", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", usrJoiner).
-			SetBody(joinRequestData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
-
+			SetHeader("Authentication", testUserId).
+			SetQueryParam("surface", "ice").
+			Get(tConfig.ServiceHost + "/api/locations/")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			if assert.NotNil(tt, response.JoinRequest) {
-				joinRequestId = response.JoinRequest.Id
-				t.Logf("Created join request with ID: %s", joinRequestId)
-			}
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Try to cancel non-existent join request
-	t.Run("CancelNonExistentJoinRequest", func(tt *testing.T) {
+	t.Run("GetLocation", func(tt *testing.T) {
+		var resp api.GetLocationResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", usrJoiner).
-			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/non-existent-id")
+			SetHeader("Authentication", testUserId).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/krzycka-park")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		location := resp.Location
+		assert.Equal(tt, "Krzycka Park", location.Name)
+		assert.Equal(tt, "http://www.krzyckapark.pl/", location.Website)
+		assert.NotEmpty(tt, location.GoogleMapsLink)
+		if assert.Len(tt, location.CourtGroups, 3) {
+			assert.Equal(tt, api.CourtSurfaceClay, location.CourtGroups[0].Surface)
+			assert.Equal(tt, "outdoor", location.CourtGroups[0].Type)
+			assert.False(tt, location.CourtGroups[0].Covered)
+			assert.True(tt, location.CourtGroups[0].Light)
+			assert.Equal(tt, []string{"3", "4"}, location.CourtGroups[0].CourtNames)
+			assert.Equal(tt, "balloon", location.CourtGroups[2].Type)
+			assert.True(tt, location.CourtGroups[2].Covered)
+		}
+		assert.ElementsMatch(tt, []api.PartnerCard{
+			{Id: "multisport-classic", Name: "Multisport Classic"},
+			{Id: "multisport-plus", Name: "Multisport Plus"},
+		}, location.PartnerCards)
+	})
 
+	t.Run("GetUnknownLocation", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", testUserId).
+			Get(tConfig.ServiceHost + "/api/locations/no-such-location")
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
+}
 
-	// Try to cancel another user's join request
-	t.Run("CancelAnotherUsersJoinRequest", func(tt *testing.T) {
-		if joinRequestId == "" {
-			assert.Fail(tt, "Join request ID is not available")
-			return
-		}
+// Test Profile API endpoints
+func Test_ProfileAPI(t *testing.T) {
+	timestamp := time.Now().UnixNano()
+	testUser := fmt.Sprintf("test-%d", timestamp%1000000) // Keep it short for VARCHAR(36)
+	anotherUser := fmt.Sprintf("user-%d", timestamp%1000000)
 
+	// Test getting profile when it doesn't exist
+	t.Run("GetNonExistentProfile", func(tt *testing.T) {
 		r, err := restClient.R().
-			SetHeader("Authentication", usrOwner).
-			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/" + joinRequestId)
+			SetHeader("Authentication", testUser).
+			Get(tConfig.ServiceHost + "/api/profiles/me")
 
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	// Cancel own join request
-	t.Run("CancelOwnJoinRequest", func(tt *testing.T) {
-		if joinRequestId == "" {
-			assert.Fail(tt, "Join request ID is not available")
-			return
+	// Create a profile
+	t.Run("CreateProfile", func(tt *testing.T) {
+		profileData := api.CreateUserProfileRequest{
+			UserProfileData: api.UserProfileData{
+				FirstName: "John",
+				LastName:  "Doe",
+				NTRPLevel: 4.5,
+				Language:  "en",
+				Country:   "PL",
+				City:      "Warsaw",
+				Notifications: api.NotificationSettings{
+					DebugAddress: "test-user-123@example.com",
+					Channels:     db.NotificationChannelDebug,
+				},
+			},
 		}
 
+		var response api.CreateUserProfileResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", usrJoiner).
-			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/" + joinRequestId)
+			SetHeader("Authentication", testUser).
+			SetBody(profileData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/profiles/")
 
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, testUser, response.UserId)
+				assert.Equal(tt, "John", response.Profile.FirstName)
+				assert.Equal(tt, "Doe", response.Profile.LastName)
+				assert.Equal(tt, 4.5, response.Profile.NTRPLevel)
+				assert.Equal(tt, "Warsaw", response.Profile.City)
+			}
 		}
 	})
 
-	// Verify join request is deleted
-	t.Run("VerifyJoinRequestDeleted", func(tt *testing.T) {
-		if eventId == "" {
-			assert.Fail(tt, "Event ID is not available")
-			return
-		}
-
-		var response api.GetEventResponse
+	// Get own profile
+	t.Run("GetOwnProfile", func(tt *testing.T) {
+		var response api.GetUserProfileResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", usrOwner).
+			SetHeader("Authentication", testUser).
 			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+			Get(tConfig.ServiceHost + "/api/profiles/me")
 
 		if assert.NoError(tt, err) {
 			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
-			assert.Equal(tt, 0, len(response.Event.JoinRequests), "Join request should be deleted")
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, testUser, response.UserId)
+				assert.Equal(tt, "John", response.Profile.FirstName)
+				assert.Equal(tt, "Doe", response.Profile.LastName)
+				assert.Equal(tt, 4.5, response.Profile.NTRPLevel)
+				assert.Equal(tt, "Warsaw", response.Profile.City)
+			}
 		}
 	})
-}
 
-func createNProfiles(n int) ([]string, error) {
-	ts := time.Now().Format("150405.000")
-	users := make([]string, n)
-	for i := 0; i < n; i++ {
-		users[i] = fmt.Sprintf("mp-user-%d-%s", i, ts)
-		profileData := api.CreateUserProfileRequest{
+	// Update profile
+	t.Run("UpdateProfile", func(tt *testing.T) {
+		profileData := api.UpdateUserProfileRequest{
 			UserProfileData: api.UserProfileData{
-				FirstName: users[i],
-				LastName:  "Doe",
+				FirstName: "Jane",
+				LastName:  "Smith",
 				NTRPLevel: 3.5,
-				City:      "TestCity",
+				City:      "Krakow",
 				Notifications: api.NotificationSettings{
-					DebugAddress: users[i] + "@xtp-tour-debug.com",
+					DebugAddress: "test-user-123@example.com",
 					Channels:     db.NotificationChannelDebug,
 				},
 			},
 		}
 
-		var response api.CreateUserProfileResponse
+		var response api.UpdateUserProfileResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", users[i]).
+			SetHeader("Authentication", testUser).
 			SetBody(profileData).
 			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/profiles/")
-		if err != nil {
-			return nil, err
-		}
-		if r.StatusCode() != http.StatusOK {
-			return nil, fmt.Errorf("failed to create profile for %s: %s", users[i], string(r.Body()))
+			Put(tConfig.ServiceHost + "/api/profiles/me")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, testUser, response.UserId)
+				assert.Equal(tt, "Jane", response.Profile.FirstName)
+				assert.Equal(tt, "Smith", response.Profile.LastName)
+				assert.Equal(tt, 3.5, response.Profile.NTRPLevel)
+				assert.Equal(tt, "Krakow", response.Profile.City)
+			}
 		}
-	}
-	return users, nil
-}
+	})
 
-func Test_DoublesEventAPI(t *testing.T) {
-	// Host plus five joiners (only three join requests can be confirmed for a 4-player event).
-	users, err := createNProfiles(6)
-	if err != nil {
-		t.Fatalf("Failed to create profiles: %v", err)
-	}
-	defer deleteProfiles(users...)
+	// Verify profile was updated
+	t.Run("GetUpdatedProfile", func(tt *testing.T) {
+		var response api.GetUserProfileResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", testUser).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/profiles/me")
 
-	host := users[0]
-	joiners := users[1:6]
-	timeSlots := getRelativeTimeSlots()
-	confirmedDate := timeSlots[3]
-	confirmedLocation := "matchpoint"
-	var eventId string
-	var joinRequestIds []string
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, testUser, response.UserId)
+				assert.Equal(tt, "Jane", response.Profile.FirstName)
+				assert.Equal(tt, "Smith", response.Profile.LastName)
+				assert.Equal(tt, 3.5, response.Profile.NTRPLevel)
+				assert.Equal(tt, "Krakow", response.Profile.City)
+			}
+		}
+	})
 
-	t.Run("CreateDoublesEvent", func(tt *testing.T) {
-		eventData := api.CreateEventRequest{
-			Event: api.EventData{
-				Locations:       []string{confirmedLocation, "spartan-pultuska"},
-				SkillLevel:      api.SkillLevelIntermediate,
-				EventType:       api.ActivityTypeMatch,
-				ExpectedPlayers: 4,
-				SessionDuration: 60,
-				TimeSlots:       timeSlots,
-				Description:     "Doubles match test",
-				Visibility:      api.EventVisibilityPublic,
+	// Create another user's profile for testing profile lookup
+	t.Run("CreateAnotherUserProfile", func(tt *testing.T) {
+		profileData := api.CreateUserProfileRequest{
+
+			UserProfileData: api.UserProfileData{
+				FirstName: "Bob",
+				LastName:  "Johnson",
+				NTRPLevel: 5.0,
+				City:      "Gdansk",
+				Notifications: api.NotificationSettings{
+					DebugAddress: "test-user-123@example.com",
+					Channels:     db.NotificationChannelDebug,
+				},
 			},
 		}
 
-		var response api.CreateEventResponse
+		var response api.CreateUserProfileResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetBody(eventData).
+			SetHeader("Authentication", anotherUser).
+			SetBody(profileData).
 			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/")
+			Post(tConfig.ServiceHost + "/api/profiles/")
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
-			if assert.NotNil(tt, response.Event) {
-				eventId = response.Event.Id
-				assert.Equal(tt, 4, response.Event.ExpectedPlayers)
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, anotherUser, response.UserId)
+				assert.Equal(tt, "Bob", response.Profile.FirstName)
 			}
 		}
 	})
 
-	for i := range joiners {
-		joiner := joiners[i]
-		playerNum := i + 1
-		t.Run(fmt.Sprintf("JoinEvent_User%d", playerNum), func(tt *testing.T) {
-			joinRequestData := api.JoinRequestRequest{
-				JoinRequest: api.JoinRequestData{
-					Locations: []string{confirmedLocation},
-					TimeSlots: []string{confirmedDate},
-					Comment:   fmt.Sprintf("Player %d joining", playerNum),
-				},
+	// Get another user's profile by user ID
+	t.Run("GetOtherUserProfile", func(tt *testing.T) {
+		var response api.GetUserProfileResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", testUser).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/profiles/" + anotherUser)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, anotherUser, response.UserId)
+				assert.Equal(tt, "Bob", response.Profile.FirstName)
+				assert.Equal(tt, "Johnson", response.Profile.LastName)
+				assert.Equal(tt, 5.0, response.Profile.NTRPLevel)
+				assert.Equal(tt, "Gdansk", response.Profile.City)
 			}
+		}
+	})
 
-			r, err := restClient.R().
-				SetHeader("Authentication", joiner).
-				SetBody(joinRequestData).
-				Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	// Test getting profile of non-existent user
+	t.Run("GetNonExistentUserProfile", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", testUser).
+			Get(tConfig.ServiceHost + "/api/profiles/non-existent-user")
 
-			if assert.NoError(tt, err) {
-				assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
-			}
-		})
-	}
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	// Test updating profile with invalid NTRP level
+	t.Run("UpdateProfileInvalidNTRP", func(tt *testing.T) {
+		profileData := api.UpdateUserProfileRequest{
+			UserProfileData: api.UserProfileData{
+				FirstName: "Invalid",
+				LastName:  "NTRP",
+				NTRPLevel: 10.0, // Invalid - NTRP should be 1.0-7.0
+				City:      "Test City",
+			},
+		}
 
-	t.Run("GetEventAndCollectJoinRequests", func(tt *testing.T) {
-		var response api.GetEventResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+			SetHeader("Authentication", testUser).
+			SetBody(profileData).
+			Put(tConfig.ServiceHost + "/api/profiles/me")
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
-			assert.Len(tt, response.Event.JoinRequests, 5)
-			for _, jr := range response.Event.JoinRequests {
-				joinRequestIds = append(joinRequestIds, jr.Id)
-			}
+			// Database constraint should reject invalid NTRP level (10.0 is outside 1.0-7.0 range)
+			assert.Equal(tt, http.StatusInternalServerError, r.StatusCode(),
+				"Invalid NTRP level should result in database constraint error. Response body: %s", string(r.Body()))
 		}
 	})
 
-	t.Run("ConfirmWithTooFewJoinRequests", func(tt *testing.T) {
-		confirmation := api.EventConfirmationRequest{
-			EventId:         eventId,
-			LocationId:      confirmedLocation,
-			DateTime:        confirmedDate,
-			JoinRequestsIds: joinRequestIds[:2],
+	// Test updating profile of non-existent user
+	t.Run("UpdateNonExistentUserProfile", func(tt *testing.T) {
+		profileData := api.UpdateUserProfileRequest{
+			UserProfileData: api.UserProfileData{
+				FirstName: "Non",
+				LastName:  "Existent",
+				NTRPLevel: 3.0,
+				City:      "No City",
+			},
 		}
 
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetBody(confirmation).
-			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+			SetHeader("Authentication", "non-existent-user-123").
+			SetBody(profileData).
+			Put(tConfig.ServiceHost + "/api/profiles/me")
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	t.Run("ConfirmWithTooManyJoinRequests", func(tt *testing.T) {
-		confirmation := api.EventConfirmationRequest{
-			EventId:         eventId,
-			LocationId:      confirmedLocation,
-			DateTime:        confirmedDate,
-			JoinRequestsIds: joinRequestIds,
+	// Test creating profile with minimal data (within valid constraints)
+	t.Run("CreateProfileMinimalData", func(tt *testing.T) {
+		testUserMinimal := fmt.Sprintf("min-%d", timestamp%1000000)
+		profileData := api.CreateUserProfileRequest{
+
+			UserProfileData: api.UserProfileData{
+				FirstName: "",
+				LastName:  "",
+				NTRPLevel: 1.0, // Minimum valid NTRP level
+				City:      "",
+			},
 		}
 
+		var response api.CreateUserProfileResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetBody(confirmation).
-			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+			SetHeader("Authentication", testUserMinimal).
+			SetBody(profileData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/profiles/")
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Profile) {
+				assert.Equal(tt, testUserMinimal, response.UserId)
+			}
 		}
 	})
+}
 
-	t.Run("ConfirmDoublesEvent", func(tt *testing.T) {
-		confirmation := api.EventConfirmationRequest{
-			EventId:         eventId,
-			LocationId:      confirmedLocation,
-			DateTime:        confirmedDate,
-			JoinRequestsIds: joinRequestIds[:3],
+// Test private events functionality
+func Test_PrivateEventAPI(t *testing.T) {
+	// Use simple string user IDs (similar to Test_CancelJoinRequest)
+	user := "private-event-owner"
+	user2 := "private-event-joiner"
+
+	timeSlots := getRelativeTimeSlots()
+	var privateEventId string
+
+	// Create a private event
+	t.Run("CreatePrivateEvent", func(tt *testing.T) {
+		eventData := api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint", "spartan-pultuska"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       timeSlots,
+				Description:     "Private event - invite only",
+				Visibility:      api.EventVisibilityPrivate, // PRIVATE event
+			},
 		}
 
+		var response api.CreateEventResponse
+
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetBody(confirmation).
-			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+			SetHeader("Authentication", user).
+			SetBody(eventData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/")
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Event) {
+				privateEventId = response.Event.Id
+				assert.Equal(tt, api.EventVisibilityPrivate, response.Event.Visibility, "Event should be private")
+			}
 		}
 	})
 
-	t.Run("VerifyConfirmedDoublesEvent", func(tt *testing.T) {
+	t.Logf("Private Event ID: %s", privateEventId)
+
+	// Verify private event does NOT appear in public events list
+	t.Run("PrivateEventNotInPublicList", func(tt *testing.T) {
+		var response api.ListEventsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user2).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/public")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			found := slices.ContainsFunc(response.Events, func(e *api.Event) bool {
+				return e.Id == privateEventId
+			})
+			assert.False(tt, found, "Private event should NOT appear in public events list")
+		}
+	})
+
+	// Verify private event CAN be accessed via direct link
+	t.Run("PrivateEventAccessibleViaDirectLink", func(tt *testing.T) {
 		var response api.GetEventResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", host).
+			SetHeader("Authentication", user2).
 			SetResult(&response).
-			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+			Get(tConfig.ServiceHost + "/api/events/public/" + privateEventId)
 
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
-			assert.Equal(tt, api.EventStatusConfirmed, response.Event.Status)
-			if assert.NotNil(tt, response.Event.Confirmation) {
-				assert.Equal(tt, confirmedLocation, response.Event.Confirmation.LocationId)
-				assert.Equal(tt, confirmedDate, response.Event.Confirmation.Datetime)
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Private event should be accessible via direct link. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Event) {
+				assert.Equal(tt, privateEventId, response.Event.Id)
+				assert.Equal(tt, api.EventVisibilityPrivate, response.Event.Visibility)
 			}
+		}
+	})
 
-			acceptedCount := 0
-			notSelectedCount := 0
-			for _, jr := range response.Event.JoinRequests {
-				if jr.IsRejected != nil {
-					if *jr.IsRejected {
-						notSelectedCount++
-					} else {
-						acceptedCount++
-					}
-				} else {
-					// Host confirmed the event; remaining join requests stay pending in storage (is_accepted NULL).
-					notSelectedCount++
-				}
-			}
-			assert.Equal(tt, 3, acceptedCount, "Should have 3 accepted join requests")
-			assert.Equal(tt, 2, notSelectedCount, "Should have 2 join requests not selected for the roster")
+	// Verify user can join private event via direct link
+	t.Run("JoinPrivateEvent", func(tt *testing.T) {
+		if privateEventId == "" {
+			assert.Fail(tt, "Event ID is not available")
+			return
+		}
+
+		joinRequestData := api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{timeSlots[0]},
+				Comment:   "Joining private event via link",
+			},
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", user2).
+			SetBody(joinRequestData).
+			Post(tConfig.ServiceHost + "/api/events/public/" + privateEventId + "/joins")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusForbidden, r.StatusCode(), "Should not join private event without invitation. Response body: %s", string(r.Body()))
+		}
+
+		var link api.InviteLinkResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", user).
+			SetResult(&link).
+			Post(tConfig.ServiceHost + "/api/events/" + privateEventId + "/invite-link")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		joinRequestData.InviteToken = link.Token
+		r, err = restClient.R().
+			SetHeader("Authentication", user2).
+			SetBody(joinRequestData).
+			Post(tConfig.ServiceHost + "/api/events/public/" + privateEventId + "/joins")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Should be able to join private event via invite link. Response body: %s", string(r.Body()))
+		}
+	})
+
+	// Verify host can see private event in their events list
+	t.Run("HostCanSeePrivateEventInMyEvents", func(tt *testing.T) {
+		var response api.ListEventsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			found := slices.ContainsFunc(response.Events, func(e *api.Event) bool {
+				return e.Id == privateEventId
+			})
+			assert.True(tt, found, "Host should see private event in their events list")
+		}
+	})
+
+	// Verify private event has join request from user2
+	t.Run("PrivateEventHasJoinRequest", func(tt *testing.T) {
+		var response api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/" + privateEventId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			assert.Len(tt, response.Event.JoinRequests, 1, "Private event should have one join request")
+		}
+	})
+}
+
+func Test_CancelJoinRequest(t *testing.T) {
+	usrOwner := "join-event-owner"
+	usrJoiner := "join-event-joiner"
+	timeSlots := getRelativeTimeSlots()
+	var eventId string
+	var joinRequestId string
+
+	// Create an event
+	t.Run("CreateEvent", func(tt *testing.T) {
+		eventData := api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       timeSlots,
+				Description:     "Test event for join request cancellation",
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}
+
+		var response api.CreateEventResponse
+
+		r, err := restClient.R().
+			SetHeader("Authentication", usrOwner).
+			SetBody(eventData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Event) {
+				eventId = response.Event.Id
+			}
+		}
+	})
+
+	// Join the event
+	t.Run("JoinEvent", func(tt *testing.T) {
+		if eventId == "" {
+			assert.Fail(tt, "Event ID is not available")
+			return
+		}
+
+		joinRequestData := api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{timeSlots[0]},
+				Comment:   "I want to join",
+			},
+		}
+
+		var response api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", usrJoiner).
+			SetBody(joinRequestData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			if assert.NotNil(tt, response.JoinRequest) {
+				joinRequestId = response.JoinRequest.Id
+				t.Logf("Created join request with ID: %s", joinRequestId)
+			}
+		}
+	})
+
+	// Try to cancel non-existent join request
+	t.Run("CancelNonExistentJoinRequest", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", usrJoiner).
+			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/non-existent-id")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	// Try to cancel another user's join request
+	t.Run("CancelAnotherUsersJoinRequest", func(tt *testing.T) {
+		if joinRequestId == "" {
+			assert.Fail(tt, "Join request ID is not available")
+			return
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", usrOwner).
+			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/" + joinRequestId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	// Cancel own join request
+	t.Run("CancelOwnJoinRequest", func(tt *testing.T) {
+		if joinRequestId == "" {
+			assert.Fail(tt, "Join request ID is not available")
+			return
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", usrJoiner).
+			Delete(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins/" + joinRequestId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	// Verify join request is deleted
+	t.Run("VerifyJoinRequestDeleted", func(tt *testing.T) {
+		if eventId == "" {
+			assert.Fail(tt, "Event ID is not available")
+			return
+		}
+
+		var response api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", usrOwner).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+			assert.Equal(tt, 0, len(response.Event.JoinRequests), "Join request should be deleted")
+		}
+	})
+}
+
+func createNProfiles(n int) ([]string, error) {
+	ts := time.Now().Format("150405.000")
+	users := make([]string, n)
+	for i := 0; i < n; i++ {
+		users[i] = fmt.Sprintf("mp-user-%d-%s", i, ts)
+		profileData := api.CreateUserProfileRequest{
+			UserProfileData: api.UserProfileData{
+				FirstName: users[i],
+				LastName:  "Doe",
+				NTRPLevel: 3.5,
+				City:      "TestCity",
+				Notifications: api.NotificationSettings{
+					DebugAddress: users[i] + "@xtp-tour-debug.com",
+					Channels:     db.NotificationChannelDebug,
+				},
+			},
+		}
+
+		var response api.CreateUserProfileResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", users[i]).
+			SetBody(profileData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/profiles/")
+		if err != nil {
+			return nil, err
+		}
+		if r.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("failed to create profile for %s: %s", users[i], string(r.Body()))
+		}
+	}
+	return users, nil
+}
+
+func Test_DoublesEventAPI(t *testing.T) {
+	// Host plus five joiners (only three join requests can be confirmed for a 4-player event).
+	users, err := createNProfiles(6)
+	if err != nil {
+		t.Fatalf("Failed to create profiles: %v", err)
+	}
+	defer deleteProfiles(users...)
+
+	host := users[0]
+	joiners := users[1:6]
+	timeSlots := getRelativeTimeSlots()
+	confirmedDate := timeSlots[3]
+	confirmedLocation := "matchpoint"
+	var eventId string
+	var joinRequestIds []string
+
+	t.Run("CreateDoublesEvent", func(tt *testing.T) {
+		eventData := api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{confirmedLocation, "spartan-pultuska"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 4,
+				SessionDuration: 60,
+				TimeSlots:       timeSlots,
+				Description:     "Doubles match test",
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}
+
+		var response api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(eventData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+			if assert.NotNil(tt, response.Event) {
+				eventId = response.Event.Id
+				assert.Equal(tt, 4, response.Event.ExpectedPlayers)
+			}
+		}
+	})
+
+	for i := range joiners {
+		joiner := joiners[i]
+		playerNum := i + 1
+		t.Run(fmt.Sprintf("JoinEvent_User%d", playerNum), func(tt *testing.T) {
+			joinRequestData := api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{confirmedLocation},
+					TimeSlots: []string{confirmedDate},
+					Comment:   fmt.Sprintf("Player %d joining", playerNum),
+				},
+			}
+
+			r, err := restClient.R().
+				SetHeader("Authentication", joiner).
+				SetBody(joinRequestData).
+				Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+			}
+		})
+	}
+
+	t.Run("GetEventAndCollectJoinRequests", func(tt *testing.T) {
+		var response api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			assert.Len(tt, response.Event.JoinRequests, 5)
+			for _, jr := range response.Event.JoinRequests {
+				joinRequestIds = append(joinRequestIds, jr.Id)
+			}
+		}
+	})
+
+	t.Run("ConfirmWithTooFewJoinRequests", func(tt *testing.T) {
+		confirmation := api.EventConfirmationRequest{
+			EventId:         eventId,
+			LocationId:      confirmedLocation,
+			DateTime:        confirmedDate,
+			JoinRequestsIds: joinRequestIds[:2],
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(confirmation).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("ConfirmWithTooManyJoinRequests", func(tt *testing.T) {
+		confirmation := api.EventConfirmationRequest{
+			EventId:         eventId,
+			LocationId:      confirmedLocation,
+			DateTime:        confirmedDate,
+			JoinRequestsIds: joinRequestIds,
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(confirmation).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("ConfirmDoublesEvent", func(tt *testing.T) {
+		confirmation := api.EventConfirmationRequest{
+			EventId:         eventId,
+			LocationId:      confirmedLocation,
+			DateTime:        confirmedDate,
+			JoinRequestsIds: joinRequestIds[:3],
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(confirmation).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("VerifyConfirmedDoublesEvent", func(tt *testing.T) {
+		var response api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&response).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			assert.Equal(tt, api.EventStatusConfirmed, response.Event.Status)
+			if assert.NotNil(tt, response.Event.Confirmation) {
+				assert.Equal(tt, confirmedLocation, response.Event.Confirmation.LocationId)
+				assert.Equal(tt, confirmedDate, response.Event.Confirmation.Datetime)
+			}
+
+			acceptedCount := 0
+			notSelectedCount := 0
+			for _, jr := range response.Event.JoinRequests {
+				if jr.IsRejected != nil {
+					if *jr.IsRejected {
+						notSelectedCount++
+					} else {
+						acceptedCount++
+					}
+				} else {
+					// Host confirmed the event; remaining join requests stay pending in storage (is_accepted NULL).
+					notSelectedCount++
+				}
+			}
+			assert.Equal(tt, 3, acceptedCount, "Should have 3 accepted join requests")
+			assert.Equal(tt, 2, notSelectedCount, "Should have 2 join requests not selected for the roster")
+		}
+	})
+
+	// Players whose join requests were confirmed should see the event as CONFIRMED with their request accepted.
+	acceptedJoiners := joiners[:3]
+	for _, uid := range acceptedJoiners {
+		t.Run(fmt.Sprintf("AcceptedPlayerView_%s", uid), func(tt *testing.T) {
+			var listResp api.ListEventsResponse
+			r, err := restClient.R().
+				SetHeader("Authentication", uid).
+				SetResult(&listResp).
+				Get(tConfig.ServiceHost + "/api/events/joined")
+
+			if !assert.NoError(tt, err) {
+				return
+			}
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+
+			var joined *api.Event
+			for _, ev := range listResp.Events {
+				if ev.Id == eventId {
+					joined = ev
+					break
+				}
+			}
+			if !assert.NotNil(tt, joined, "event should appear in joined list for %s", uid) {
+				return
+			}
+			assert.Equal(tt, api.EventStatusConfirmed, joined.Status)
+			if assert.NotNil(tt, joined.Confirmation) {
+				assert.Equal(tt, confirmedLocation, joined.Confirmation.LocationId)
+				assert.Equal(tt, confirmedDate, joined.Confirmation.Datetime)
+			}
+
+			var self *api.JoinRequest
+			for _, jr := range joined.JoinRequests {
+				if jr.UserId == uid {
+					self = jr
+					break
+				}
+			}
+			if assert.NotNil(tt, self, "join request for this user should be present") {
+				// API uses omitempty on isRejected; false is omitted from JSON, so *IsRejected may be nil for accepted requests.
+				rejected := self.IsRejected != nil && *self.IsRejected
+				assert.False(tt, rejected, "accepted player should see their request as accepted")
+			}
+
+			var public api.GetEventResponse
+			r2, err2 := restClient.R().
+				SetHeader("Authentication", uid).
+				SetResult(&public).
+				Get(tConfig.ServiceHost + "/api/events/public/" + eventId)
+			if assert.NoError(tt, err2) {
+				assert.Equal(tt, http.StatusOK, r2.StatusCode(), "Response: %s", string(r2.Body()))
+				assert.Equal(tt, api.EventStatusConfirmed, public.Event.Status)
+				if assert.NotNil(tt, public.Event.Confirmation) {
+					assert.Equal(tt, confirmedLocation, public.Event.Confirmation.LocationId)
+					assert.Equal(tt, confirmedDate, public.Event.Confirmation.Datetime)
+				}
+			}
+		})
+	}
+}
+
+func Test_InvalidExpectedPlayers(t *testing.T) {
+	user := "invalid-players-user"
+	timeSlots := getRelativeTimeSlots()
+
+	invalidCounts := []int{0, 1, 1001}
+	for _, count := range invalidCounts {
+		t.Run(fmt.Sprintf("ExpectedPlayers_%d", count), func(tt *testing.T) {
+			eventData := api.CreateEventRequest{
+				Event: api.EventData{
+					Locations:       []string{"matchpoint"},
+					SkillLevel:      api.SkillLevelIntermediate,
+					EventType:       api.ActivityTypeMatch,
+					ExpectedPlayers: count,
+					SessionDuration: 60,
+					TimeSlots:       timeSlots,
+					Visibility:      api.EventVisibilityPublic,
+				},
+			}
+
+			r, err := restClient.R().
+				SetHeader("Authentication", user).
+				SetBody(eventData).
+				Post(tConfig.ServiceHost + "/api/events/")
+
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+			}
+		})
+	}
+}
+
+func Test_ConfirmWithWrongEventJoinRequests(t *testing.T) {
+	users, err := createNProfiles(3)
+	if err != nil {
+		t.Fatalf("Failed to create profiles: %v", err)
+	}
+	defer deleteProfiles(users...)
+
+	host := users[0]
+	joiner1 := users[1]
+	joiner2 := users[2]
+	timeSlots := getRelativeTimeSlots()
+	confirmedDate := timeSlots[0]
+
+	var event1Id, event2Id string
+	var event2JoinRequestId string
+
+	t.Run("CreateEvent1", func(tt *testing.T) {
+		eventData := api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       timeSlots,
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}
+
+		var response api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(eventData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			event1Id = response.Event.Id
+		}
+	})
+
+	t.Run("CreateEvent2", func(tt *testing.T) {
+		eventData := api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelIntermediate,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       timeSlots,
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}
+
+		var response api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(eventData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			event2Id = response.Event.Id
+		}
+	})
+
+	t.Run("JoinEvent1", func(tt *testing.T) {
+		joinRequestData := api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{confirmedDate},
+			},
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", joiner1).
+			SetBody(joinRequestData).
+			Post(tConfig.ServiceHost + "/api/events/public/" + event1Id + "/joins")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+		}
+	})
+
+	t.Run("JoinEvent2", func(tt *testing.T) {
+		joinRequestData := api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{confirmedDate},
+			},
+		}
+
+		var response api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", joiner2).
+			SetBody(joinRequestData).
+			SetResult(&response).
+			Post(tConfig.ServiceHost + "/api/events/public/" + event2Id + "/joins")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			event2JoinRequestId = response.JoinRequest.Id
+		}
+	})
+
+	t.Run("ConfirmEvent1WithEvent2JoinRequest", func(tt *testing.T) {
+		if event2JoinRequestId == "" {
+			assert.Fail(tt, "Event2 join request ID not available")
+			return
+		}
+
+		confirmation := api.EventConfirmationRequest{
+			EventId:         event1Id,
+			LocationId:      "matchpoint",
+			DateTime:        confirmedDate,
+			JoinRequestsIds: []string{event2JoinRequestId},
+		}
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(confirmation).
+			Post(tConfig.ServiceHost + "/api/events/" + event1Id + "/confirmation")
+
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(),
+				"Should reject confirmation with join requests from different event. Response: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_PrivateEventInvitations(t *testing.T) {
+	host, invited, stranger, err := createProfiles()
+	if !assert.NoError(t, err) {
+		return
+	}
+	slot := getRelativeDate(7, 10)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint"},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 60,
+				TimeSlots:       []string{slot},
+				Visibility:      api.EventVisibilityPrivate,
+			},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+
+	join := func(userId string, token string) (*resty.Response, error) {
+		return restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: []string{slot},
+				},
+				InviteToken: token,
+			}).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	}
+
+	t.Run("OnlyHostManagesInvitations", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", stranger).
+			SetBody(api.InviteUsersRequest{UserIds: []string{stranger}}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/invitations")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("UnknownUserIsRejected", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.InviteUsersRequest{UserIds: []string{"no-such-user"}}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/invitations")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("InvitedUserCanJoin", func(tt *testing.T) {
+		var resp api.ListInvitationsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.InviteUsersRequest{UserIds: []string{invited}}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/invitations")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		if assert.Len(tt, resp.Invitations, 1) {
+			assert.Equal(tt, invited, resp.Invitations[0].UserId)
+			assert.Equal(tt, host, resp.Invitations[0].InvitedBy)
+		}
+
+		r, err = join(invited, "")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("UninvitedUserNeedsToken", func(tt *testing.T) {
+		r, err := join(stranger, "not-a-token")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusForbidden, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("ResolveInviteLink", func(tt *testing.T) {
+		var link api.InviteLinkResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&link).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/invite-link")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Contains(tt, link.Path, "/events/"+eventId)
+
+		var resolved api.GetEventResponse
+		r, err = restClient.R().
+			SetResult(&resolved).
+			Get(tConfig.ServiceHost + "/api/events/invites/" + link.Token)
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, eventId, resolved.Event.Id)
+		}
+
+		r, err = restClient.R().Get(tConfig.ServiceHost + "/api/events/invites/" + link.Token + "x")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("WithdrawInvitation", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			Delete(tConfig.ServiceHost + "/api/events/" + eventId + "/invitations/" + invited)
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			Delete(tConfig.ServiceHost + "/api/events/" + eventId + "/invitations/" + invited)
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_ConfirmationCandidates(t *testing.T) {
+	host := "test-user-candidates-host"
+	first := "test-user-candidates-first"
+	second := "test-user-candidates-second"
+	morning := getRelativeDate(7, 10)
+	evening := getRelativeDate(7, 18)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{
+			Event: api.EventData{
+				Locations:       []string{"matchpoint", "spartan-pultuska"},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeTraining,
+				ExpectedPlayers: 3,
+				SessionDuration: 60,
+				TimeSlots:       []string{morning, evening},
+				Visibility:      api.EventVisibilityPublic,
+			},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+
+	joins := map[string]api.JoinRequestData{
+		first:  {Locations: []string{"matchpoint", "spartan-pultuska"}, TimeSlots: []string{morning, evening}},
+		second: {Locations: []string{"spartan-pultuska"}, TimeSlots: []string{morning}},
+	}
+	joinRequestIds := map[string]string{}
+	for userId, data := range joins {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{JoinRequest: data}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		joinRequestIds[userId] = resp.JoinRequest.Id
+	}
+
+	t.Run("OnlyHostGetsCandidates", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", first).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation/candidates")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("BestCandidateConfirmsEvent", func(tt *testing.T) {
+		var resp api.ConfirmationCandidatesResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation/candidates")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		if !assert.Len(tt, resp.Candidates, 3) {
+			return
+		}
+
+		best := resp.Candidates[0]
+		assert.Equal(tt, "spartan-pultuska", best.LocationId)
+		assert.Equal(tt, morning, best.DateTime)
+		assert.Equal(tt, 3, best.Players)
+		assert.True(tt, best.Complete)
+		assert.ElementsMatch(tt, []string{joinRequestIds[first], joinRequestIds[second]}, best.JoinRequestsIds)
+		for _, candidate := range resp.Candidates[1:] {
+			assert.False(tt, candidate.Complete)
+		}
+
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.EventConfirmationRequest{
+				LocationId:      best.LocationId,
+				DateTime:        best.DateTime,
+				JoinRequestsIds: best.JoinRequestsIds,
+			}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_DoublesTeams(t *testing.T) {
+	host := "test-user-doubles-host"
+	players := []string{"test-user-doubles-1", "test-user-doubles-2", "test-user-doubles-3"}
+	slot := getRelativeDate(7, 10)
+
+	eventData := api.EventData{
+		Locations:       []string{"matchpoint"},
+		SkillLevel:      api.SkillLevelAny,
+		EventType:       api.ActivityTypeMatch,
+		Format:          api.SingleDoubleTypeDoubles,
+		ExpectedPlayers: 3,
+		SessionDuration: 90,
+		TimeSlots:       []string{slot},
+		Visibility:      api.EventVisibilityPublic,
+	}
+
+	t.Run("DoublesNeedFourPlayers", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CreateEventRequest{Event: eventData}).
+			Post(tConfig.ServiceHost + "/api/events/")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	eventData.ExpectedPlayers = 4
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{Event: eventData}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+	assert.Equal(t, api.SingleDoubleTypeDoubles, created.Event.Format)
+
+	joinRequestIds := []string{}
+	for _, userId := range players {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{"matchpoint"},
+					TimeSlots: []string{slot},
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		joinRequestIds = append(joinRequestIds, resp.JoinRequest.Id)
+	}
+
+	confirm := func(teams [][]string) (*resty.Response, error) {
+		return restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.EventConfirmationRequest{
+				LocationId:      "matchpoint",
+				DateTime:        slot,
+				JoinRequestsIds: joinRequestIds,
+				Teams:           teams,
+			}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+	}
+
+	t.Run("UnevenTeamsAreRejected", func(tt *testing.T) {
+		r, err := confirm([][]string{{host}, players})
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("ConfirmWithTeams", func(tt *testing.T) {
+		teams := [][]string{{host, players[1]}, {players[0], players[2]}}
+		r, err := confirm(teams)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		var resp api.GetEventResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			if assert.Len(tt, resp.Event.Teams, 2) {
+				assert.ElementsMatch(tt, teams[0], resp.Event.Teams[0])
+				assert.ElementsMatch(tt, teams[1], resp.Event.Teams[1])
+			}
+		}
+
+		r, err = restClient.R().Get(tConfig.ServiceHost + "/api/events/public/" + eventId + "/calendar.ics")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Contains(tt, string(r.Body()), "Teams: ")
+		}
+	})
+}
+
+func Test_RescheduleEvent(t *testing.T) {
+	host := "test-user-reschedule-host"
+	player := "test-user-reschedule-player"
+	slot := getRelativeDate(7, 10)
+	newSlot := getRelativeDate(8, 18)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{Event: api.EventData{
+			Locations:       []string{"matchpoint"},
+			SkillLevel:      api.SkillLevelAny,
+			EventType:       api.ActivityTypeMatch,
+			ExpectedPlayers: 2,
+			SessionDuration: 60,
+			TimeSlots:       []string{slot},
+			Visibility:      api.EventVisibilityPublic,
+		}}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+
+	reschedule := func(userId string, req api.RescheduleEventRequest) (*resty.Response, error) {
+		return restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(req).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule")
+	}
+
+	t.Run("OpenEventCannotBeRescheduled", func(tt *testing.T) {
+		r, err := reschedule(host, api.RescheduleEventRequest{LocationId: "spartan-pultuska", DateTime: newSlot})
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	var joinResp api.JoinRequestResponse
+	r, err = restClient.R().
+		SetHeader("Authentication", player).
+		SetBody(api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{slot},
+			},
+		}).
+		SetResult(&joinResp).
+		Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.EventConfirmationRequest{
+			LocationId:      "matchpoint",
+			DateTime:        slot,
+			JoinRequestsIds: []string{joinResp.JoinRequest.Id},
+		}).
+		Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	getEvent := func(tt *testing.T) *api.Event {
+		var resp api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
+		}
+		return resp.Event
+	}
+
+	t.Run("OnlyHostCanReschedule", func(tt *testing.T) {
+		r, err := reschedule(player, api.RescheduleEventRequest{LocationId: "spartan-pultuska", DateTime: newSlot})
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("PastTimeIsRejected", func(tt *testing.T) {
+		r, err := reschedule(host, api.RescheduleEventRequest{LocationId: "spartan-pultuska", DateTime: getRelativeDate(-1, 10)})
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("Reschedule", func(tt *testing.T) {
+		var resp api.EventConfirmationResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.RescheduleEventRequest{LocationId: "spartan-pultuska", DateTime: newSlot, Reason: "Court is flooded"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, 2, resp.Confirmation.Version)
+		assert.Equal(tt, "spartan-pultuska", resp.Confirmation.LocationId)
+
+		event := getEvent(tt)
+		if assert.NotNil(tt, event) && assert.NotNil(tt, event.Confirmation) {
+			assert.Equal(tt, api.EventStatusConfirmed, event.Status)
+			assert.Equal(tt, newSlot, event.Confirmation.Datetime)
+			assert.Equal(tt, 2, event.Confirmation.Version)
+			assert.Equal(tt, []string{player}, event.Confirmation.AwaitingAcknowledgement)
+		}
+
+		r, err = restClient.R().Get(tConfig.ServiceHost + "/api/events/public/" + eventId + "/calendar.ics")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Contains(tt, string(r.Body()), "SEQUENCE:1\r\n")
+		}
+	})
+
+	t.Run("SameTimeAndCourtIsRejected", func(tt *testing.T) {
+		r, err := reschedule(host, api.RescheduleEventRequest{LocationId: "spartan-pultuska", DateTime: newSlot})
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("Acknowledge", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule/acknowledgement")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+
+		r, err = restClient.R().
+			SetHeader("Authentication", player).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule/acknowledgement")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		event := getEvent(tt)
+		if assert.NotNil(tt, event) && assert.NotNil(tt, event.Confirmation) {
+			assert.Empty(tt, event.Confirmation.AwaitingAcknowledgement)
+		}
+	})
+}
+
+func Test_CourtReservation(t *testing.T) {
+	// the service tests run with a fake reservation provider with a single court at this facility
+	const facility = "winners"
+	slot := getRelativeDate(9, 7)
+	newSlot := getRelativeDate(9, 12)
+
+	confirmEvent := func(tt *testing.T, host string, player string, slot string) (string, *api.Confirmation) {
+		var created api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CreateEventRequest{Event: api.EventData{
+				Locations:       []string{facility},
+				SkillLevel:      api.SkillLevelAny,
+				EventType:       api.ActivityTypeMatch,
+				ExpectedPlayers: 2,
+				SessionDuration: 90,
+				TimeSlots:       []string{slot},
+				Visibility:      api.EventVisibilityPublic,
+			}}).
+			SetResult(&created).
+			Post(tConfig.ServiceHost + "/api/events/")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return "", nil
+		}
+		eventId := created.Event.Id
+
+		var joinResp api.JoinRequestResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.JoinRequestRequest{
+				JoinRequest: api.JoinRequestData{
+					Locations: []string{facility},
+					TimeSlots: []string{slot},
+				},
+			}).
+			SetResult(&joinResp).
+			Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return "", nil
+		}
+
+		var resp api.EventConfirmationResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.EventConfirmationRequest{
+				LocationId:      facility,
+				DateTime:        slot,
+				JoinRequestsIds: []string{joinResp.JoinRequest.Id},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return "", nil
+		}
+		return eventId, &resp.Confirmation
+	}
+
+	getEvent := func(tt *testing.T, host string, eventId string) *api.Event {
+		var resp api.GetEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/" + eventId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
+		}
+		return resp.Event
+	}
+
+	t.Run("CourtIsReserved", func(tt *testing.T) {
+		host := "test-user-reservation-host-1"
+		eventId, confirmation := confirmEvent(tt, host, "test-user-reservation-player-1", slot)
+		if confirmation == nil {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusReserved, confirmation.ReservationStatus)
+		assert.Equal(tt, "1", confirmation.CourtNumber)
+
+		event := getEvent(tt, host, eventId)
+		if assert.NotNil(tt, event) && assert.NotNil(tt, event.Confirmation) {
+			assert.Equal(tt, api.EventStatusConfirmed, event.Status)
+			assert.Equal(tt, api.ReservationStatusReserved, event.Confirmation.ReservationStatus)
+			assert.Equal(tt, "1", event.Confirmation.CourtNumber)
+		}
+	})
+
+	t.Run("FailedReservationIsRescheduled", func(tt *testing.T) {
+		host := "test-user-reservation-host-2"
+		player := "test-user-reservation-player-2"
+		eventId, confirmation := confirmEvent(tt, host, player, slot)
+		if confirmation == nil {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusFailed, confirmation.ReservationStatus)
+		assert.Empty(tt, confirmation.CourtNumber)
+
+		event := getEvent(tt, host, eventId)
+		if !assert.NotNil(tt, event) {
+			return
+		}
+		assert.Equal(tt, api.EventStatusReservationFailed, event.Status)
+		for _, jr := range event.JoinRequests {
+			if jr.UserId == player {
+				assert.Equal(tt, api.JoinRequestStatusReservationFailed, jr.Status)
+			}
+		}
+
+		var resp api.EventConfirmationResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.RescheduleEventRequest{LocationId: facility, DateTime: newSlot, Reason: "No court at the first time"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusReserved, resp.Confirmation.ReservationStatus)
+		assert.Equal(tt, "1", resp.Confirmation.CourtNumber)
+
+		event = getEvent(tt, host, eventId)
+		if assert.NotNil(tt, event) {
+			assert.Equal(tt, api.EventStatusConfirmed, event.Status)
+			for _, jr := range event.JoinRequests {
+				if jr.UserId == player {
+					assert.Equal(tt, api.JoinRequestStatusAccepted, jr.Status)
+				}
+			}
+		}
+	})
+
+	t.Run("CancelReleasesCourt", func(tt *testing.T) {
+		cancelSlot := getRelativeDate(9, 15)
+		host := "test-user-reservation-host-3"
+		eventId, confirmation := confirmEvent(tt, host, "test-user-reservation-player-3", cancelSlot)
+		if confirmation == nil {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusReserved, confirmation.ReservationStatus)
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CancelEventRequest{Reason: "Not playing after all"}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/cancellation")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		// the only court of the facility is free again
+		_, confirmation = confirmEvent(tt, "test-user-reservation-host-4", "test-user-reservation-player-4", cancelSlot)
+		if confirmation != nil {
+			assert.Equal(tt, api.ReservationStatusReserved, confirmation.ReservationStatus)
+			assert.Equal(tt, "1", confirmation.CourtNumber)
+		}
+	})
+
+	t.Run("RescheduleReleasesCourt", func(tt *testing.T) {
+		firstSlot := getRelativeDate(9, 18)
+		movedSlot := getRelativeDate(9, 21)
+		host := "test-user-reservation-host-5"
+		eventId, confirmation := confirmEvent(tt, host, "test-user-reservation-player-5", firstSlot)
+		if confirmation == nil {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusReserved, confirmation.ReservationStatus)
+
+		var resp api.EventConfirmationResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.RescheduleEventRequest{LocationId: facility, DateTime: movedSlot, Reason: "Later suits everyone"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/reschedule")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, api.ReservationStatusReserved, resp.Confirmation.ReservationStatus)
+
+		// the court booked at the first time is free again
+		_, confirmation = confirmEvent(tt, "test-user-reservation-host-6", "test-user-reservation-player-6", firstSlot)
+		if confirmation != nil {
+			assert.Equal(tt, api.ReservationStatusReserved, confirmation.ReservationStatus)
+			assert.Equal(tt, "1", confirmation.CourtNumber)
+		}
+	})
+}
+
+func Test_CourtPrice(t *testing.T) {
+	userId := "test-user-court-price"
+	getPrice := func(locationId string, datetime string, duration string) (*resty.Response, *api.CourtPriceResponse, error) {
+		var resp api.CourtPriceResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetQueryParams(map[string]string{"datetime": datetime, "duration": duration}).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/" + locationId + "/price")
+		return r, &resp, err
+	}
+
+	t.Run("SpansRuleBoundary", func(tt *testing.T) {
+		// 14:30 in Warsaw, the seeded indoor price changes from 60 to 80 at 15:00
+		r, resp, err := getPrice("matchpoint", "2024-06-04T12:30:00Z", "60")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		if assert.NotNil(tt, resp.Price) {
+			assert.Equal(tt, 70.0, *resp.Price)
+		}
+		if assert.Len(tt, resp.CourtGroups, 1) {
+			assert.Len(tt, resp.CourtGroups[0].Segments, 2)
+		}
+	})
+
+	t.Run("NoPrice", func(tt *testing.T) {
+		r, resp, err := getPrice("matchpoint", "2020-06-04T12:30:00Z", "60")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Nil(tt, resp.Price)
+			assert.Empty(tt, resp.CourtGroups)
+		}
+	})
+
+	t.Run("InvalidDatetime", func(tt *testing.T) {
+		r, _, err := getPrice("matchpoint", "tomorrow", "60")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("UnknownLocation", func(tt *testing.T) {
+		r, _, err := getPrice("no-such-location", "2024-06-04T12:30:00Z", "60")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_HiddenFacilityNotFound(t *testing.T) {
+	admin := "test-user-hidden-facility-admin-" + time.Now().Format("150405.000")
+	createAdmin(t, admin)
+
+	var created api.AdminFacilityDetails
+	r, err := restClient.R().
+		SetHeader("Authentication", admin).
+		SetBody(api.AdminFacilityData{
+			Name:        "Hidden Club " + admin,
+			Address:     "Hidden Street 1",
+			Country:     "PL",
+			Coordinates: api.Coordinates{Latitude: 51.2, Longitude: 17.1},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/admin/facilities")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	facilityId := created.Location.ID
+
+	r, err = restClient.R().
+		SetHeader("Authentication", admin).
+		SetBody(map[string]string{"status": "hidden"}).
+		Put(tConfig.ServiceHost + "/api/admin/facilities/" + facilityId)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", admin).
+		Get(tConfig.ServiceHost + "/api/locations/" + facilityId)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	}
+
+	// admins still see it to show it again
+	var details api.AdminFacilityDetails
+	r, err = restClient.R().
+		SetHeader("Authentication", admin).
+		SetResult(&details).
+		Get(tConfig.ServiceHost + "/api/admin/facilities/" + facilityId)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		assert.Equal(t, "hidden", details.Status)
+	}
+}
+
+func Test_AdminFacilityEditing(t *testing.T) {
+	admin := "test-user-facility-admin-" + time.Now().Format("150405.000")
+	createAdmin(t, admin)
+
+	var created api.AdminFacilityDetails
+	r, err := restClient.R().
+		SetHeader("Authentication", admin).
+		SetBody(api.AdminFacilityData{
+			Name:        "Admin Club " + admin,
+			Address:     "Admin Street 1",
+			Country:     "PL",
+			Coordinates: api.Coordinates{Latitude: 51.11, Longitude: 17.04},
+		}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/admin/facilities")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	facilityId := created.Location.ID
+	assert.NotEmpty(t, facilityId)
+	assert.Equal(t, "active", created.Status)
+	assert.Equal(t, "Admin Street 1", created.Location.Address)
+
+	t.Run("UpdateDetails", func(tt *testing.T) {
+		var updated api.AdminFacilityDetails
+		r, err := restClient.R().
+			SetHeader("Authentication", admin).
+			SetBody(api.AdminFacilityData{
+				Name:        "Admin Club Renamed " + admin,
+				Address:     "Admin Street 2",
+				Country:     "PL",
+				Coordinates: api.Coordinates{Latitude: 51.12, Longitude: 17.05},
+				Website:     "https://admin-club.example.com",
+			}).
+			SetResult(&updated).
+			Put(tConfig.ServiceHost + "/api/admin/facilities/" + facilityId + "/details")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, "Admin Club Renamed "+admin, updated.Location.Name)
+		assert.Equal(tt, "Admin Street 2", updated.Location.Address)
+		assert.Equal(tt, "https://admin-club.example.com", updated.Location.Website)
+
+		var location api.GetLocationResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", admin).
+			SetResult(&location).
+			Get(tConfig.ServiceHost + "/api/locations/" + facilityId)
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, "Admin Street 2", location.Location.Address)
+		}
+	})
+
+	t.Run("Hide", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", admin).
+			SetBody(map[string]string{"status": "hidden"}).
+			Put(tConfig.ServiceHost + "/api/admin/facilities/" + facilityId)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		var list api.AdminListFacilitiesResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", admin).
+			SetResult(&list).
+			Get(tConfig.ServiceHost + "/api/admin/facilities")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		found := false
+		for _, facility := range list.Facilities {
+			if facility.ID == facilityId {
+				found = true
+				assert.Equal(tt, "hidden", facility.Status)
+			}
+		}
+		assert.True(tt, found, "hidden facilities are listed for admins")
+
+		var locations api.ListLocationsResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", admin).
+			SetResult(&locations).
+			Get(tConfig.ServiceHost + "/api/locations/")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			for _, location := range locations.Locations {
+				assert.NotEqual(tt, facilityId, location.ID)
+			}
+		}
+	})
+}
+
+func Test_AdminFacilityEditingHiddenFromUsers(t *testing.T) {
+	userId := "test-user-not-admin"
+
+	r, err := restClient.R().
+		SetHeader("Authentication", userId).
+		SetBody(api.AdminFacilityData{
+			Name:        "Not An Admin Club",
+			Address:     "Street 1",
+			Country:     "PL",
+			Coordinates: api.Coordinates{Latitude: 51.1, Longitude: 17.03},
+		}).
+		Post(tConfig.ServiceHost + "/api/admin/facilities")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", userId).
+		SetBody(api.AdminPricePeriodData{
+			ValidFrom: "2026-05-01",
+			ValidTo:   "2026-09-30",
+			Rules:     []api.AdminPriceRule{{DayPattern: "*", Start: "07:00", End: "23:00", Price: 1}},
+		}).
+		Post(tConfig.ServiceHost + "/api/admin/court-groups/1/price-periods")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", userId).
+		Get(tConfig.ServiceHost + "/api/admin/facilities/duplicates")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	}
+
+	r, err = restClient.R().
+		SetHeader("Authentication", userId).
+		SetBody(map[string]string{"duplicateId": "2"}).
+		Post(tConfig.ServiceHost + "/api/admin/facilities/1/merge")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	}
+}
+
+func Test_NearestLocations(t *testing.T) {
+	userId := "test-user-nearest"
+	listLocations := func(tt *testing.T, params map[string]string) []api.Location {
+		var resp api.ListLocationsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetQueryParams(params).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
+		}
+		return resp.Locations
+	}
+
+	t.Run("WithinRadius", func(tt *testing.T) {
+		// next to Matchpoint, Topacz is about 0.7 km away
+		locations := listLocations(tt, map[string]string{"lat": "51.0405", "lng": "16.9860", "radiusKm": "3"})
+		if !assert.NotEmpty(tt, locations) {
+			return
+		}
+		assert.Equal(tt, "matchpoint", locations[0].ID)
+		previous := 0.0
+		for _, location := range locations {
+			if assert.NotNil(tt, location.DistanceKm, location.ID) {
+				assert.LessOrEqual(tt, *location.DistanceKm, 3.0)
+				assert.GreaterOrEqual(tt, *location.DistanceKm, previous)
+				previous = *location.DistanceKm
+			}
+			assert.NotEqual(tt, "winners", location.ID)
+		}
+	})
+
+	t.Run("RadiusWithoutCenter", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetQueryParam("radiusKm", "3").
+			Get(tConfig.ServiceHost + "/api/locations/")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("NearProfileCity", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(api.CreateUserProfileRequest{UserProfileData: api.UserProfileData{
+				FirstName: "Near",
+				LastName:  "Est",
+				NTRPLevel: 3.5,
+				Country:   "PL",
+				City:      "Oleśnica",
+				Notifications: api.NotificationSettings{
+					DebugAddress: userId + "@xtp-tour-debug.com",
+					Channels:     db.NotificationChannelDebug,
+				},
+			}}).
+			Post(tConfig.ServiceHost + "/api/profiles/")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		locations := listLocations(tt, map[string]string{"nearProfile": "true"})
+		if assert.NotEmpty(tt, locations) {
+			assert.Equal(tt, "olesnicki-klub-tenisowy", locations[0].ID)
+			assert.NotNil(tt, locations[0].DistanceKm)
+		}
+	})
+
+	t.Run("NearUnknownProfileCity", func(tt *testing.T) {
+		// the profile city is not known and no place is found in it
+		unknownCityUser := "test-user-nearest-unknown-city-" + time.Now().Format("150405.000")
+		createProfile(tt, unknownCityUser)
+
+		var resp api.ListLocationsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", unknownCityUser).
+			SetQueryParam("nearProfile", "true").
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.NotEmpty(tt, resp.Locations)
+		for _, location := range resp.Locations {
+			assert.Nil(tt, location.DistanceKm, location.ID)
+		}
+	})
+}
+
+func Test_LocationHolidays(t *testing.T) {
+	userId := "test-user-holidays"
+	getHolidays := func(locationId string, from string, to string) (*resty.Response, *api.HolidaysResponse, error) {
+		var resp api.HolidaysResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetQueryParams(map[string]string{"from": from, "to": to}).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/locations/" + locationId + "/holidays")
+		return r, &resp, err
+	}
+
+	t.Run("Range", func(tt *testing.T) {
+		r, resp, err := getHolidays("matchpoint", "2026-11-01", "2026-11-30")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, "PL", resp.Country)
+		assert.Equal(tt, []api.Holiday{
+			{Date: "2026-11-01", Name: "All Saints' Day"},
+			{Date: "2026-11-11", Name: "Independence Day"},
+		}, resp.Holidays)
+	})
+
+	t.Run("InvalidRange", func(tt *testing.T) {
+		r, _, err := getHolidays("matchpoint", "2026-11-30", "2026-11-01")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("UnknownLocation", func(tt *testing.T) {
+		r, _, err := getHolidays("no-such-location", "2026-11-01", "2026-11-30")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_AdminHolidays(t *testing.T) {
+	admin := "test-user-holidays-admin-" + time.Now().Format("150405.000")
+	createAdmin(t, admin)
+
+	t.Run("ListWithOverrides", func(tt *testing.T) {
+		var resp api.AdminListHolidaysResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", admin).
+			SetQueryParam("year", "2030").
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/admin/holidays/pl")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, "PL", resp.Country)
+		// moveable feasts are computed for any year
+		assert.Contains(tt, resp.Holidays, api.Holiday{Date: "2030-04-21", Name: "Easter Sunday"})
+	})
+
+	t.Run("InvalidCountry", func(tt *testing.T) {
+		for _, country := range []string{"POL", "XX", "P1"} {
+			r, err := restClient.R().
+				SetHeader("Authentication", admin).
+				Get(tConfig.ServiceHost + "/api/admin/holidays/" + country)
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code for %s. Response body: %s", country, string(r.Body()))
+			}
+
+			r, err = restClient.R().
+				SetHeader("Authentication", admin).
+				SetBody(map[string]interface{}{"name": "Bridge day", "isHoliday": true}).
+				Put(tConfig.ServiceHost + "/api/admin/holidays/" + country + "/2030-05-02")
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code for %s. Response body: %s", country, string(r.Body()))
+			}
+		}
+	})
+
+	t.Run("HiddenFromUsers", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", "test-user-holidays-not-admin").
+			Get(tConfig.ServiceHost + "/api/admin/holidays/PL")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+}
+
+func Test_JoinRequestPayment(t *testing.T) {
+	host := "test-user-payment-host"
+	player := "test-user-payment-player"
+	slot := getRelativeDate(11, 10)
+
+	var created api.CreateEventResponse
+	r, err := restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.CreateEventRequest{Event: api.EventData{
+			Locations:       []string{"matchpoint"},
+			SkillLevel:      api.SkillLevelAny,
+			EventType:       api.ActivityTypeMatch,
+			ExpectedPlayers: 2,
+			SessionDuration: 60,
+			TimeSlots:       []string{slot},
+			Visibility:      api.EventVisibilityPublic,
+		}}).
+		SetResult(&created).
+		Post(tConfig.ServiceHost + "/api/events/")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	eventId := created.Event.Id
+
+	var joinResp api.JoinRequestResponse
+	r, err = restClient.R().
+		SetHeader("Authentication", player).
+		SetBody(api.JoinRequestRequest{
+			JoinRequest: api.JoinRequestData{
+				Locations: []string{"matchpoint"},
+				TimeSlots: []string{slot},
+			},
+		}).
+		SetResult(&joinResp).
+		Post(tConfig.ServiceHost + "/api/events/public/" + eventId + "/joins")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+	joinRequestId := joinResp.JoinRequest.Id
+
+	setPaid := func(userId string, paid bool) (*resty.Response, *api.JoinRequestResponse, error) {
+		var resp api.JoinRequestResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetBody(map[string]bool{"paid": paid}).
+			SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/events/" + eventId + "/joins/" + joinRequestId + "/payment")
+		return r, &resp, err
+	}
+
+	t.Run("NotConfirmedYet", func(tt *testing.T) {
+		r, _, err := setPaid(host, true)
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	r, err = restClient.R().
+		SetHeader("Authentication", host).
+		SetBody(api.EventConfirmationRequest{
+			LocationId:      "matchpoint",
+			DateTime:        slot,
+			JoinRequestsIds: []string{joinRequestId},
+		}).
+		Post(tConfig.ServiceHost + "/api/events/" + eventId + "/confirmation")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+		return
+	}
+
+	t.Run("OnlyHostCanMarkPayments", func(tt *testing.T) {
+		r, _, err := setPaid(player, true)
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
+
+	t.Run("MarkPaidAndUnpaid", func(tt *testing.T) {
+		r, resp, err := setPaid(host, true)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.NotEmpty(tt, resp.JoinRequest.PaidAt)
+
+		r, resp, err = setPaid(host, false)
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Empty(tt, resp.JoinRequest.PaidAt)
+	})
+}
+
+func Test_PartnerCards(t *testing.T) {
+	host, user2, user3, err := createProfiles()
+	if err != nil {
+		t.Fatalf("Failed to create profiles: %v", err)
+	}
+	defer deleteProfiles(host, user2, user3)
+
+	t.Run("ListPartnerCards", func(tt *testing.T) {
+		var resp api.ListPartnerCardsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/partner-cards/")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Contains(tt, resp.PartnerCards, api.PartnerCard{Id: "medicover", Name: "Medicover"})
+		}
+	})
+
+	updateCards := func(tt *testing.T, cards []string) *resty.Response {
+		var profile api.GetUserProfileResponse
+		_, err := restClient.R().SetHeader("Authentication", host).SetResult(&profile).Get(tConfig.ServiceHost + "/api/profiles/me")
+		if err != nil || profile.Profile == nil {
+			tt.Fatalf("Failed to get profile: %v", err)
+		}
+		profile.Profile.PartnerCards = cards
+
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.UpdateUserProfileRequest{UserProfileData: *profile.Profile}).
+			Put(tConfig.ServiceHost + "/api/profiles/me")
+		if err != nil {
+			tt.Fatalf("Failed to update profile: %v", err)
+		}
+		return r
+	}
+
+	t.Run("UnknownCard", func(tt *testing.T) {
+		r := updateCards(tt, []string{"no-such-card"})
+		assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+	})
+
+	t.Run("ProfileCards", func(tt *testing.T) {
+		r := updateCards(tt, []string{"medicover", "medicover"})
+		if !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		var profile api.GetUserProfileResponse
+		_, err := restClient.R().SetHeader("Authentication", host).SetResult(&profile).Get(tConfig.ServiceHost + "/api/profiles/me")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, []string{"medicover"}, profile.Profile.PartnerCards)
+		}
+	})
+
+	createEvent := func(tt *testing.T, locations []string) *api.Event {
+		var resp api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CreateEventRequest{
+				Event: api.EventData{
+					Locations:       locations,
+					SkillLevel:      api.SkillLevelIntermediate,
+					EventType:       api.ActivityTypeMatch,
+					ExpectedPlayers: 2,
+					SessionDuration: 60,
+					TimeSlots:       []string{getRelativeDate(4, 19)},
+					Visibility:      api.EventVisibilityPublic,
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/")
+		if err != nil || r.StatusCode() != http.StatusOK {
+			tt.Fatalf("Failed to create event: %v %s", err, string(r.Body()))
+		}
+
+		var event api.GetEventResponse
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetResult(&event).
+			Get(tConfig.ServiceHost + "/api/events/" + resp.Event.Id)
+		if err != nil || r.StatusCode() != http.StatusOK {
+			tt.Fatalf("Failed to get event: %v %s", err, string(r.Body()))
+		}
+		return event.Event
+	}
+
+	t.Run("EventLocations", func(tt *testing.T) {
+		assert.Equal(tt, []string{"spartan-pultuska"}, createEvent(tt, []string{"spartan-pultuska"}).PartnerCardLocations)
+		// matchpoint does not accept Medicover
+		assert.Equal(tt, []string{"spartan-pultuska"}, createEvent(tt, []string{"spartan-pultuska", "matchpoint"}).PartnerCardLocations)
+	})
+}
+
+func Test_FavouriteFacilities(t *testing.T) {
+	user, host, user3, err := createProfiles()
+	if err != nil {
+		t.Fatalf("Failed to create profiles: %v", err)
+	}
+	defer deleteProfiles(user, host, user3)
+
+	favourites := func(tt *testing.T, r *resty.Response, err error, resp *api.FavouriteFacilitiesResponse) []string {
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
 		}
-	})
+		ids := []string{}
+		for _, location := range resp.Favourites {
+			ids = append(ids, location.ID)
+		}
+		return ids
+	}
 
-	// Players whose join requests were confirmed should see the event as CONFIRMED with their request accepted.
-	acceptedJoiners := joiners[:3]
-	for _, uid := range acceptedJoiners {
-		t.Run(fmt.Sprintf("AcceptedPlayerView_%s", uid), func(tt *testing.T) {
-			var listResp api.ListEventsResponse
-			r, err := restClient.R().
-				SetHeader("Authentication", uid).
-				SetResult(&listResp).
-				Get(tConfig.ServiceHost + "/api/events/joined")
+	t.Run("AddAndSetHome", func(tt *testing.T) {
+		var resp api.FavouriteFacilitiesResponse
+		r, err := restClient.R().SetHeader("Authentication", user).SetResult(&resp).
+			Put(tConfig.ServiceHost + "/api/profiles/me/favourites/matchpoint")
+		assert.Equal(tt, []string{"matchpoint"}, favourites(tt, r, err, &resp))
+
+		resp = api.FavouriteFacilitiesResponse{}
+		r, err = restClient.R().SetHeader("Authentication", user).SetResult(&resp).
+			SetBody(api.SetHomeFacilityRequest{FacilityId: "krzycka-park"}).
+			Put(tConfig.ServiceHost + "/api/profiles/me/home-facility")
+		assert.Equal(tt, []string{"krzycka-park", "matchpoint"}, favourites(tt, r, err, &resp), "home facility first")
+		assert.Equal(tt, "krzycka-park", resp.HomeFacilityId)
+	})
 
-			if !assert.NoError(tt, err) {
-				return
-			}
-			assert.Equal(tt, http.StatusOK, r.StatusCode(), "Response: %s", string(r.Body()))
+	t.Run("Profile", func(tt *testing.T) {
+		var resp api.GetUserProfileResponse
+		r, err := restClient.R().SetHeader("Authentication", user).SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/profiles/me")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+		assert.Equal(tt, "krzycka-park", resp.HomeFacilityId)
+		ids := []string{}
+		for _, location := range resp.FavouriteFacilities {
+			ids = append(ids, location.ID)
+		}
+		assert.Equal(tt, []string{"krzycka-park", "matchpoint"}, ids)
+	})
 
-			var joined *api.Event
-			for _, ev := range listResp.Events {
-				if ev.Id == eventId {
-					joined = ev
-					break
-				}
-			}
-			if !assert.NotNil(tt, joined, "event should appear in joined list for %s", uid) {
-				return
-			}
-			assert.Equal(tt, api.EventStatusConfirmed, joined.Status)
-			if assert.NotNil(tt, joined.Confirmation) {
-				assert.Equal(tt, confirmedLocation, joined.Confirmation.LocationId)
-				assert.Equal(tt, confirmedDate, joined.Confirmation.Datetime)
-			}
+	t.Run("HomeWithoutPreferences", func(tt *testing.T) {
+		userId := "no_pref_" + time.Now().Format("150405.000")
+		createProfile(tt, userId)
+		defer deleteProfiles(userId)
+		if _, err := openTestDb(t).Exec(`DELETE FROM user_pref WHERE uid = ?`, userId); err != nil {
+			tt.Fatalf("Failed to delete preferences: %v", err)
+		}
 
-			var self *api.JoinRequest
-			for _, jr := range joined.JoinRequests {
-				if jr.UserId == uid {
-					self = jr
-					break
-				}
-			}
-			if assert.NotNil(tt, self, "join request for this user should be present") {
-				// API uses omitempty on isRejected; false is omitted from JSON, so *IsRejected may be nil for accepted requests.
-				rejected := self.IsRejected != nil && *self.IsRejected
-				assert.False(tt, rejected, "accepted player should see their request as accepted")
-			}
+		var resp api.FavouriteFacilitiesResponse
+		r, err := restClient.R().SetHeader("Authentication", userId).SetResult(&resp).
+			SetBody(api.SetHomeFacilityRequest{FacilityId: "matchpoint"}).
+			Put(tConfig.ServiceHost + "/api/profiles/me/home-facility")
+		assert.Equal(tt, []string{"matchpoint"}, favourites(tt, r, err, &resp))
+		assert.Equal(tt, "matchpoint", resp.HomeFacilityId, "home facility is stored without preferences")
+	})
 
-			var public api.GetEventResponse
-			r2, err2 := restClient.R().
-				SetHeader("Authentication", uid).
-				SetResult(&public).
-				Get(tConfig.ServiceHost + "/api/events/public/" + eventId)
-			if assert.NoError(tt, err2) {
-				assert.Equal(tt, http.StatusOK, r2.StatusCode(), "Response: %s", string(r2.Body()))
-				assert.Equal(tt, api.EventStatusConfirmed, public.Event.Status)
-				if assert.NotNil(tt, public.Event.Confirmation) {
-					assert.Equal(tt, confirmedLocation, public.Event.Confirmation.LocationId)
-					assert.Equal(tt, confirmedDate, public.Event.Confirmation.Datetime)
-				}
-			}
-		})
-	}
-}
+	t.Run("UnknownFacility", func(tt *testing.T) {
+		r, err := restClient.R().SetHeader("Authentication", user).
+			Put(tConfig.ServiceHost + "/api/profiles/me/favourites/no-such-facility")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
 
-func Test_InvalidExpectedPlayers(t *testing.T) {
-	user := "invalid-players-user"
-	timeSlots := getRelativeTimeSlots()
+	t.Run("DefaultEventLocations", func(tt *testing.T) {
+		var resp api.CreateEventResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user).
+			SetBody(api.CreateEventRequest{
+				Event: api.EventData{
+					SkillLevel:      api.SkillLevelIntermediate,
+					EventType:       api.ActivityTypeMatch,
+					ExpectedPlayers: 2,
+					SessionDuration: 60,
+					TimeSlots:       []string{getRelativeDate(4, 19)},
+					Visibility:      api.EventVisibilityPublic,
+				},
+			}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, []string{"krzycka-park", "matchpoint"}, resp.Event.Locations)
+		}
 
-	invalidCounts := []int{0, 1, 1001}
-	for _, count := range invalidCounts {
-		t.Run(fmt.Sprintf("ExpectedPlayers_%d", count), func(tt *testing.T) {
-			eventData := api.CreateEventRequest{
+		// without favourites locations are required
+		r, err = restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.CreateEventRequest{
 				Event: api.EventData{
-					Locations:       []string{"matchpoint"},
 					SkillLevel:      api.SkillLevelIntermediate,
 					EventType:       api.ActivityTypeMatch,
-					ExpectedPlayers: count,
+					ExpectedPlayers: 2,
 					SessionDuration: 60,
-					TimeSlots:       timeSlots,
+					TimeSlots:       []string{getRelativeDate(4, 19)},
 					Visibility:      api.EventVisibilityPublic,
 				},
-			}
+			}).
+			Post(tConfig.ServiceHost + "/api/events/")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
+	})
 
+	t.Run("PublicEventsFavouritesFirst", func(tt *testing.T) {
+		for _, location := range []string{"krzycka-park", "spartan-pultuska"} {
 			r, err := restClient.R().
-				SetHeader("Authentication", user).
-				SetBody(eventData).
+				SetHeader("Authentication", host).
+				SetBody(api.CreateEventRequest{
+					Event: api.EventData{
+						Locations:       []string{location},
+						SkillLevel:      api.SkillLevelIntermediate,
+						EventType:       api.ActivityTypeMatch,
+						ExpectedPlayers: 2,
+						SessionDuration: 60,
+						TimeSlots:       []string{getRelativeDate(5, 18)},
+						Visibility:      api.EventVisibilityPublic,
+					},
+				}).
 				Post(tConfig.ServiceHost + "/api/events/")
+			if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+				return
+			}
+		}
 
-			if assert.NoError(tt, err) {
-				assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Response: %s", string(r.Body()))
+		var resp api.ListEventsResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", user).
+			SetQueryParam("limit", "100").
+			SetResult(&resp).
+			Get(tConfig.ServiceHost + "/api/events/public")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return
+		}
+
+		atFavourite := func(event *api.Event) bool {
+			return slices.Contains(event.Locations, "krzycka-park") || slices.Contains(event.Locations, "matchpoint")
+		}
+		seenOther := false
+		for _, event := range resp.Events {
+			if !atFavourite(event) {
+				seenOther = true
+			} else {
+				assert.False(tt, seenOther, "event %s at a favourite facility listed after other events", event.Id)
 			}
-		})
-	}
+		}
+	})
+
+	t.Run("RemoveHome", func(tt *testing.T) {
+		var resp api.FavouriteFacilitiesResponse
+		r, err := restClient.R().SetHeader("Authentication", user).SetResult(&resp).
+			Delete(tConfig.ServiceHost + "/api/profiles/me/favourites/krzycka-park")
+		assert.Equal(tt, []string{"matchpoint"}, favourites(tt, r, err, &resp))
+		assert.Empty(tt, resp.HomeFacilityId)
+	})
 }
 
-func Test_ConfirmWithWrongEventJoinRequests(t *testing.T) {
-	users, err := createNProfiles(3)
-	if err != nil {
-		t.Fatalf("Failed to create profiles: %v", err)
-	}
-	defer deleteProfiles(users...)
+func Test_PostMatchWindow(t *testing.T) {
+	conn := openTestDb(t)
+	host := "test-user-post-match-host"
+	player := "test-user-post-match-player"
 
-	host := users[0]
-	joiner1 := users[1]
-	joiner2 := users[2]
-	timeSlots := getRelativeTimeSlots()
-	confirmedDate := timeSlots[0]
+	eventId := createConfirmedMatch(t, host, []string{player}, nil)
+	event := completeEvent(t, conn, host, eventId)
 
-	var event1Id, event2Id string
-	var event2JoinRequestId string
+	sets := []api.SetScore{{Player: 6, Opponent: 3}, {Player: 6, Opponent: 4}}
 
-	t.Run("CreateEvent1", func(tt *testing.T) {
-		eventData := api.CreateEventRequest{
-			Event: api.EventData{
-				Locations:       []string{"matchpoint"},
-				SkillLevel:      api.SkillLevelIntermediate,
-				EventType:       api.ActivityTypeMatch,
-				ExpectedPlayers: 2,
-				SessionDuration: 60,
-				TimeSlots:       timeSlots,
-				Visibility:      api.EventVisibilityPublic,
-			},
+	t.Run("CompletionOpensWindow", func(tt *testing.T) {
+		until, err := time.Parse(time.RFC3339, event.PostMatchUntil)
+		if assert.NoError(tt, err) {
+			assert.True(tt, until.After(time.Now()), "Post-match window should be open until %s", event.PostMatchUntil)
 		}
 
-		var response api.CreateEventResponse
+		var resp api.MatchResultResponse
 		r, err := restClient.R().
 			SetHeader("Authentication", host).
-			SetBody(eventData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/")
+			SetBody(api.SubmitMatchResultRequest{OpponentIds: []string{player}, Sets: sets}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/result")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, api.MatchResultStatusPending, resp.Result.Status)
+		}
+	})
+
+	t.Run("Feedback", func(tt *testing.T) {
+		var resp api.FeedbackResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.SubmitFeedbackRequest{UserId: host, Rating: 5, Comment: "Fair play"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/feedback")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, player, resp.Feedback.FromUserId)
+			assert.Equal(tt, host, resp.Feedback.ToUserId)
+			assert.Equal(tt, 5, resp.Feedback.Rating)
+		}
+
+		// the feedback can be changed while the window is open
+		r, err = restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.SubmitFeedbackRequest{UserId: host, Rating: 4}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/feedback")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, 4, resp.Feedback.Rating)
+		}
+	})
 
+	t.Run("FeedbackInvalidRating", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.SubmitFeedbackRequest{UserId: host, Rating: 6}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/feedback")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
-			event1Id = response.Event.Id
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	t.Run("CreateEvent2", func(tt *testing.T) {
-		eventData := api.CreateEventRequest{
-			Event: api.EventData{
-				Locations:       []string{"matchpoint"},
-				SkillLevel:      api.SkillLevelIntermediate,
-				EventType:       api.ActivityTypeMatch,
-				ExpectedPlayers: 2,
-				SessionDuration: 60,
-				TimeSlots:       timeSlots,
-				Visibility:      api.EventVisibilityPublic,
-			},
+	t.Run("FeedbackFromStrangerFails", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", "test-user-post-match-stranger").
+			SetBody(api.SubmitFeedbackRequest{UserId: host, Rating: 1}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/feedback")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusNotFound, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
+	})
 
-		var response api.CreateEventResponse
+	t.Run("NoShowReport", func(tt *testing.T) {
+		var resp api.NoShowReportResponse
 		r, err := restClient.R().
 			SetHeader("Authentication", host).
-			SetBody(eventData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/")
+			SetBody(api.ReportNoShowRequest{UserId: player, Comment: "Came an hour late"}).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/no-shows")
+		if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, host, resp.Report.ReportedBy)
+			assert.Equal(tt, player, resp.Report.UserId)
+			assert.Equal(tt, "Came an hour late", resp.Report.Comment)
+		}
+	})
 
+	t.Run("NoShowReportAboutSelfFails", func(tt *testing.T) {
+		r, err := restClient.R().
+			SetHeader("Authentication", host).
+			SetBody(api.ReportNoShowRequest{UserId: host}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/no-shows")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
-			event2Id = response.Event.Id
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
 	})
 
-	t.Run("JoinEvent1", func(tt *testing.T) {
-		joinRequestData := api.JoinRequestRequest{
-			JoinRequest: api.JoinRequestData{
-				Locations: []string{"matchpoint"},
-				TimeSlots: []string{confirmedDate},
-			},
+	t.Run("WindowCloses", func(tt *testing.T) {
+		_, err := conn.Exec(`UPDATE events SET post_match_until = ? WHERE id = ?`, time.Now().UTC().Add(-time.Minute), eventId)
+		if !assert.NoError(tt, err) {
+			return
 		}
 
 		r, err := restClient.R().
-			SetHeader("Authentication", joiner1).
-			SetBody(joinRequestData).
-			Post(tConfig.ServiceHost + "/api/events/public/" + event1Id + "/joins")
+			SetHeader("Authentication", player).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/result/confirmation")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
+		}
 
+		r, err = restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.SubmitFeedbackRequest{UserId: host, Rating: 3}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/feedback")
 		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
-	})
 
-	t.Run("JoinEvent2", func(tt *testing.T) {
-		joinRequestData := api.JoinRequestRequest{
-			JoinRequest: api.JoinRequestData{
-				Locations: []string{"matchpoint"},
-				TimeSlots: []string{confirmedDate},
-			},
+		r, err = restClient.R().
+			SetHeader("Authentication", player).
+			SetBody(api.ReportNoShowRequest{UserId: host}).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/no-shows")
+		if assert.NoError(tt, err) {
+			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body()))
 		}
+	})
+}
 
-		var response api.JoinRequestResponse
+func Test_MatchResult(t *testing.T) {
+	conn := openTestDb(t)
+	sets := []api.SetScore{{Player: 4, Opponent: 6}, {Player: 6, Opponent: 2}, {Player: 10, Opponent: 7}}
+
+	submit := func(tt *testing.T, eventId string, userId string, req api.SubmitMatchResultRequest) *api.MatchResult {
+		var resp api.MatchResultResponse
 		r, err := restClient.R().
-			SetHeader("Authentication", joiner2).
-			SetBody(joinRequestData).
-			SetResult(&response).
-			Post(tConfig.ServiceHost + "/api/events/public/" + event2Id + "/joins")
+			SetHeader("Authentication", userId).
+			SetBody(req).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/result")
+		if !assert.NoError(tt, err) || !assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			return nil
+		}
+		return resp.Result
+	}
+	respond := func(tt *testing.T, eventId string, userId string) (*resty.Response, *api.MatchResult) {
+		var resp api.MatchResultResponse
+		r, err := restClient.R().
+			SetHeader("Authentication", userId).
+			SetResult(&resp).
+			Post(tConfig.ServiceHost + "/api/events/" + eventId + "/result/confirmation")
+		if !assert.NoError(tt, err) {
+			tt.FailNow()
+		}
+		return r, resp.Result
+	}
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusOK, r.StatusCode())
-			event2JoinRequestId = response.JoinRequest.Id
+	t.Run("Singles", func(tt *testing.T) {
+		host := "test-user-result-singles-host"
+		player := "test-user-result-singles-player"
+		eventId := createConfirmedMatch(tt, host, []string{player}, nil)
+		completeEvent(tt, conn, host, eventId)
+
+		result := submit(tt, eventId, player, api.SubmitMatchResultRequest{Sets: sets})
+		if result == nil {
+			return
+		}
+		assert.Equal(tt, []string{player}, result.Team)
+		assert.Equal(tt, []string{host}, result.Opponents)
+		assert.Equal(tt, []string{player}, result.Winners)
+		assert.Equal(tt, api.MatchResultStatusPending, result.Status)
+
+		r, _ := respond(tt, eventId, player)
+		assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Submitter cannot confirm own result. Response body: %s", string(r.Body()))
+
+		r, result = respond(tt, eventId, host)
+		if assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, api.MatchResultStatusConfirmed, result.Status)
+			assert.Equal(tt, host, result.RespondedBy)
 		}
 	})
 
-	t.Run("ConfirmEvent1WithEvent2JoinRequest", func(tt *testing.T) {
-		if event2JoinRequestId == "" {
-			assert.Fail(tt, "Event2 join request ID not available")
+	t.Run("Doubles", func(tt *testing.T) {
+		host := "test-user-result-doubles-host"
+		partner := "test-user-result-doubles-partner"
+		opponent1 := "test-user-result-doubles-opponent-1"
+		opponent2 := "test-user-result-doubles-opponent-2"
+		eventId := createConfirmedMatch(tt, host, []string{partner, opponent1, opponent2},
+			[][]string{{host, partner}, {opponent1, opponent2}})
+		completeEvent(tt, conn, host, eventId)
+
+		result := submit(tt, eventId, partner, api.SubmitMatchResultRequest{Sets: sets})
+		if result == nil {
 			return
 		}
+		assert.ElementsMatch(tt, []string{host, partner}, result.Team)
+		assert.ElementsMatch(tt, []string{opponent1, opponent2}, result.Opponents)
+		assert.ElementsMatch(tt, []string{host, partner}, result.Winners)
 
-		confirmation := api.EventConfirmationRequest{
-			EventId:         event1Id,
-			LocationId:      "matchpoint",
-			DateTime:        confirmedDate,
-			JoinRequestsIds: []string{event2JoinRequestId},
+		r, _ := respond(tt, eventId, host)
+		assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Partner of the submitter cannot confirm. Response body: %s", string(r.Body()))
+
+		r, result = respond(tt, eventId, opponent2)
+		if assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) {
+			assert.Equal(tt, api.MatchResultStatusConfirmed, result.Status)
+			assert.Equal(tt, opponent2, result.RespondedBy)
 		}
 
-		r, err := restClient.R().
-			SetHeader("Authentication", host).
-			SetBody(confirmation).
-			Post(tConfig.ServiceHost + "/api/events/" + event1Id + "/confirmation")
+		r, _ = respond(tt, eventId, opponent1)
+		assert.Equal(tt, http.StatusBadRequest, r.StatusCode(), "Result is already confirmed. Response body: %s", string(r.Body()))
 
-		if assert.NoError(tt, err) {
-			assert.Equal(tt, http.StatusBadRequest, r.StatusCode(),
-				"Should reject confirmation with join requests from different event. Response: %s", string(r.Body()))
+		// all players start from the same NTRP level, so each winner gains and each loser drops half of the provisional K
+		expected := map[string]int{host: 1520, partner: 1520, opponent1: 1480, opponent2: 1480}
+		for userId, rating := range expected {
+			var profile api.GetUserProfileResponse
+			r, err := restClient.R().
+				SetHeader("Authentication", userId).
+				SetResult(&profile).
+				Get(tConfig.ServiceHost + "/api/profiles/me")
+			if assert.NoError(tt, err) && assert.Equal(tt, http.StatusOK, r.StatusCode(), "Invalid status code. Response body: %s", string(r.Body())) &&
+				assert.NotNil(tt, profile.Rating, "Rating of %s", userId) {
+				assert.Equal(tt, rating, profile.Rating.Rating, "Rating of %s", userId)
+				assert.Equal(tt, 1, profile.Rating.MatchesPlayed, "Matches of %s", userId)
+			}
 		}
 	})
 }
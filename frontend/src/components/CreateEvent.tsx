@@ -104,7 +104,8 @@ const CreateEvent: React.FC<{ onEventCreated?: () => void }> = ({ onEventCreated
       setIsLoadingLocations(true);
       setLocationError(null);
       try {
-        const response = await api.listLocations();
+        // clubs near the city of the user come first
+        const response = await api.listLocations({ nearProfile: true });
         if (Array.isArray(response) && response.length > 0) {
           setLocations(response);
         } else {
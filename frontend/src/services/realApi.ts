@@ -1,4 +1,4 @@
-import { APIConfig, APIError, ApiEvent, ApiConfirmation, ApiJoinRequest, ApiLocation, ListEventsResponse, CreateEventResponse, GetEventResponse, ConfirmEventResponse, JoinRequestResponse, ListLocationsResponse, CreateEventRequest, ConfirmEventRequest, JoinEventRequest, GetUserProfileResponse, CreateUserProfileRequest, CreateUserProfileResponse, UpdateUserProfileRequest, UpdateUserProfileResponse, CalendarAuthURLResponse, CalendarCallbackRequest, CalendarConnectionStatusResponse, CalendarPreferencesRequest, CalendarPreferencesResponse, ApiUserCalendar, EventMessage, PlaceSearchResult, SearchPlacesResponse, AddPlaceResponse, AdminFacility, AdminListFacilitiesResponse, GetMessagesResponse, CreateMessageResponse } from '../types/api';
+import { APIConfig, APIError, ApiEvent, ApiConfirmation, ApiJoinRequest, ApiLocation, ListEventsResponse, CreateEventResponse, GetEventResponse, ConfirmEventResponse, JoinRequestResponse, ListLocationsResponse, ListLocationsOptions, CreateEventRequest, ConfirmEventRequest, JoinEventRequest, GetUserProfileResponse, CreateUserProfileRequest, CreateUserProfileResponse, UpdateUserProfileRequest, UpdateUserProfileResponse, CalendarAuthURLResponse, CalendarCallbackRequest, CalendarConnectionStatusResponse, CalendarPreferencesRequest, CalendarPreferencesResponse, ApiUserCalendar, EventMessage, PlaceSearchResult, SearchPlacesResponse, AddPlaceResponse, AdminFacility, AdminListFacilitiesResponse, GetMessagesResponse, CreateMessageResponse } from '../types/api';
 import { components } from '../types/schema';
 
 // Debug information interface
@@ -235,8 +235,11 @@ export class RealAPIClient {
     return await this.fetch<ListEventsResponse>('/api/events/');
   }
 
-  async listLocations(): Promise<ApiLocation[]> {
-    const response = await this.fetch<ListLocationsResponse>('/api/locations/');
+  async listLocations(options?: ListLocationsOptions): Promise<ApiLocation[]> {
+    const params = new URLSearchParams();
+    if (options?.nearProfile) params.set('nearProfile', 'true');
+    const query = params.toString() ? `?${params.toString()}` : '';
+    const response = await this.fetch<ListLocationsResponse>(`/api/locations/${query}`);
     return response.locations || [];
   }
 
@@ -33,6 +33,11 @@ export type JoinEventRequest = {
   joinRequest: components['schemas']['ApiJoinRequestData'];
 };
 
+export type ListLocationsOptions = {
+  // sort locations by distance from the city of the user profile
+  nearProfile?: boolean;
+};
+
 export type CreateUserProfileRequest = components['schemas']['CreateUserProfileHandler-FmInput'];
 export type UpdateUserProfileRequest = components['schemas']['UpdateUserProfileHandler-FmInput'];
 
@@ -60,7 +65,7 @@ export interface APIClient {
   cancelJoinRequest(eventId: string, joinRequestId: string): Promise<void>;
 
   // Location endpoints
-  listLocations(): Promise<ApiLocation[]>;
+  listLocations(options?: ListLocationsOptions): Promise<ApiLocation[]>;
 
   // Profile endpoints
   getUserProfile(): Promise<GetUserProfileResponse>;
@@ -501,9 +501,10 @@ export interface components {
             address?: string;
             coordinates?: components["schemas"]["ApiCoordinates"];
             createdAt?: string;
-            googlePlaceId?: string;
             id?: string;
             name?: string;
+            placeId?: string;
+            placeProvider?: string;
             source?: string;
             status?: string;
         };